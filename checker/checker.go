@@ -3,17 +3,24 @@
 package checker
 
 import (
+	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/designatorresolution"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
 	"github.com/bazo-blockchain/lazo/checker/symbolconstruction"
 	"github.com/bazo-blockchain/lazo/checker/typecheck"
 	"github.com/bazo-blockchain/lazo/checker/typeresolution"
+	"github.com/bazo-blockchain/lazo/diagnostic"
+	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
+	"strings"
 )
 
 // Checker contains the syntax tree, symbol table and errors. It performs semantic analysis on abstract syntax tree and
 // generates a symbol table which holds meta information about each node (e.g. scope).
+// Contract is the name of the contract, whose symbol table is returned. It can be omitted, if the program declares
+// only one contract.
 type Checker struct {
+	Contract    string
 	syntaxTree  *node.ProgramNode
 	symbolTable *symbol.SymbolTable
 	errors      []error
@@ -27,24 +34,73 @@ func New(syntaxTree *node.ProgramNode) *Checker {
 	return p
 }
 
-// Run performs all the checker phases
+// Run performs all the checker phases for every contract of the program.
+// Each contract is checked in its own symbol table, which also contains the interfaces of the program.
+// The interfaces are checked once before the contracts, since they do not depend on a contract.
 // Executed phases are symbol construction, type resolution, designator resolution and type checking.
-// If errors occur during one of those phases, the process is stopped at the end of the failing phase.
-// Returns the symbol table and errors
+// All phases are executed even if errors occur, so that all independent errors are reported at once.
+// Declarations and expressions with an erroneous type get the error type, which suppresses follow-up errors.
+// Only a program without contract stops the process after the symbol construction.
+// Returns the symbol table of the selected contract and errors
 func (c *Checker) Run() (*symbol.SymbolTable, []error) {
-	c.symbolTable, c.errors = symbolconstruction.Run(c.syntaxTree)
-	if !c.hasErrors() {
-		c.errors = typeresolution.Run(c.symbolTable)
+	if len(c.syntaxTree.Contracts) == 0 {
+		c.symbolTable, c.errors = symbolconstruction.Run(c.syntaxTree, nil)
+		return c.symbolTable, c.errors
 	}
-	if !c.hasErrors() {
-		c.errors = designatorresolution.Run(c.symbolTable)
-	}
-	if !c.hasErrors() {
-		c.errors = typecheck.Run(c.symbolTable)
+
+	c.checkInterfaces()
+
+	contractNames := make(map[string]bool)
+	for _, contractNode := range c.syntaxTree.Contracts {
+		if contractNames[contractNode.Name] {
+			c.reportError(diagnostic.New(diagnostic.DuplicateDeclaration, contractNode.Pos(), contractNode.End(),
+				fmt.Sprintf("Contract '%s' is already declared", contractNode.Name)))
+			continue
+		}
+		contractNames[contractNode.Name] = true
+
+		symbolTable, errors := c.checkContract(contractNode)
+		if c.symbolTable == nil || contractNode.Name == c.Contract {
+			c.symbolTable = symbolTable
+		}
+		c.errors = append(c.errors, errors...)
 	}
+
+	c.checkSelectedContract(contractNames)
 	return c.symbolTable, c.errors
 }
 
-func (c *Checker) hasErrors() bool {
-	return len(c.errors) > 0
+// checkInterfaces constructs the interfaces without a contract and resolves their types
+func (c *Checker) checkInterfaces() {
+	symbolTable, errors := symbolconstruction.RunInterfaces(c.syntaxTree)
+	c.errors = append(c.errors, errors...)
+	c.errors = append(c.errors, typeresolution.RunInterfaces(symbolTable)...)
+}
+
+func (c *Checker) checkContract(contractNode *node.ContractNode) (*symbol.SymbolTable, []error) {
+	symbolTable, errors := symbolconstruction.Run(c.syntaxTree, contractNode)
+	errors = append(errors, typeresolution.Run(symbolTable)...)
+	errors = append(errors, designatorresolution.Run(symbolTable)...)
+	errors = append(errors, typecheck.Run(symbolTable)...)
+	return symbolTable, errors
+}
+
+// checkSelectedContract reports an error, if the selected contract is not declared
+// or if no contract is selected in a program with multiple contracts.
+func (c *Checker) checkSelectedContract(contractNames map[string]bool) {
+	if c.Contract == "" && len(contractNames) > 1 {
+		var names []string
+		for _, contractNode := range c.syntaxTree.Contracts {
+			names = append(names, contractNode.Name)
+		}
+		c.reportError(diagnostic.New(diagnostic.MissingContract, token.Position{}, token.Position{},
+			fmt.Sprintf("Program declares multiple contracts, select one of %s", strings.Join(names, ", "))))
+	} else if c.Contract != "" && !contractNames[c.Contract] {
+		c.reportError(diagnostic.New(diagnostic.MissingContract, token.Position{}, token.Position{},
+			fmt.Sprintf("Contract %s is not declared", c.Contract)))
+	}
+}
+
+func (c *Checker) reportError(err error) {
+	c.errors = append(c.errors, err)
 }
@@ -1,6 +1,7 @@
 package checker
 
 import (
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/parser/node"
 	"gotest.tools/assert"
 	"testing"
@@ -17,6 +18,7 @@ func TestUndefinedDesignator(t *testing.T) {
 	`, false)
 
 	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.UndefinedDesignator, "4:4", "4:5")
 }
 
 // Field Designators
@@ -29,7 +31,7 @@ func TestFieldDesignator(t *testing.T) {
 	`, true)
 
 	tester.assertBasicDesignator(
-		tester.syntaxTree.Contract.Fields[1].Expression,
+		tester.syntaxTree.Contracts[0].Fields[1].Expression,
 		tester.globalScope.Contract.Fields[0],
 		tester.globalScope.IntType)
 }
@@ -40,7 +42,7 @@ func TestMixedDesignatorExpression(t *testing.T) {
 		int y = 2 * x
 	`, true)
 
-	binExpr := tester.syntaxTree.Contract.Fields[1].Expression.(*node.BinaryExpressionNode)
+	binExpr := tester.syntaxTree.Contracts[0].Fields[1].Expression.(*node.BinaryExpressionNode)
 	tester.assertBasicDesignator(
 		binExpr.Right,
 		tester.globalScope.Contract.Fields[0],
@@ -296,6 +298,46 @@ func TestLocalVarAccessOutOfScope(t *testing.T) {
 	tester.assertTotalErrors(1)
 }
 
+func TestLoopVarAccessOutOfScope(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(){
+			for (int i : 0..2) {
+			}
+			i = 4
+		}
+	`, false)
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Local Variable i is not visible")
+}
+
+func TestLoopVarInNestedLoop(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(int[] arr){
+			for (int i : 0..2) {
+				foreach (x in arr) {
+					int y = x * i
+				}
+			}
+		}
+	`, true)
+
+	foreachStmt := tester.getFuncStatementNode(0, 0).(*node.ForStatementNode).Body[0].(*node.ForEachStatementNode)
+	binExpr := foreachStmt.Body[0].(*node.VariableNode).Expression.(*node.BinaryExpressionNode)
+	tester.assertBasicDesignator(binExpr.Left, tester.getLocalVariableSymbol(0, 1), tester.globalScope.IntType)
+	tester.assertBasicDesignator(binExpr.Right, tester.getLocalVariableSymbol(0, 0), tester.globalScope.IntType)
+}
+
+func TestForEachLoopTypeNotInferred(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(int x){
+			foreach (y in x) {
+			}
+		}
+	`, false)
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Type of loop variable y cannot be inferred")
+}
+
 func TestLocalVarAccessIfElse(t *testing.T) {
 	tester := newCheckerTestUtil(t, `
 		function void test(){
@@ -370,6 +412,59 @@ func TestUndefinedDesignatorWithFuncCall(t *testing.T) {
 	tester.assertErrorAt(0, "Designator x is undefined")
 }
 
+func TestPrivateFuncCall(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function int test() {
+			return test2() + test3()
+		}
+
+		function int test2() private {
+			return 1
+		}
+
+		function int test3() internal {
+			return 2
+		}
+	`, true)
+
+	tester.assertFunction(1, 1, 0, 0)
+}
+
+func TestPublicFuncCallThroughThis(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			this.test2(1)
+		}
+
+		function void test2(int a) public {
+		}
+	`, true)
+
+	fc := tester.getFuncStatementNode(0, 0).(*node.CallStatementNode).Call
+	assert.Equal(t, tester.symbolTable.GetDeclByDesignator(fc.Designator), tester.globalScope.Contract.Functions[1])
+}
+
+func TestPrivateFuncCallThroughThis(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			this.test2(1)
+			int x = this.test3()
+		}
+
+		function void test2(int a) private {
+		}
+
+		function int test3() internal {
+			return 1
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Function test2 is private and cannot be called through 'this'")
+	tester.assertErrorSpan(0, diagnostic.NotVisible, "4:4", "4:14")
+	tester.assertErrorAt(1, "Function test3 is internal and cannot be called through 'this'")
+}
+
 // Struct
 // ------
 
@@ -469,6 +564,41 @@ func TestStructUndefinedFieldAccess(t *testing.T) {
 	tester.assertErrorAt(0, "Member balance does not exist on struct Person")
 }
 
+// Enum
+// ----
+
+func TestEnumMemberAccess(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		enum State { Open, Closed }
+
+		function State test() {
+			return State.Closed
+		}
+	`, true)
+
+	enumType := tester.globalScope.Enums["State"]
+	returnStmt := tester.getFuncStatementNode(0, 0).(*node.ReturnStatementNode)
+	memberAccess := returnStmt.Expressions[0].(*node.MemberAccessNode)
+	tester.assertMemberAccess(memberAccess, enumType.Members[1], enumType)
+	assert.Equal(t, tester.symbolTable.GetDeclByDesignator(memberAccess.Designator), enumType)
+}
+
+func TestUndefinedEnumMember(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		enum State { Open, Closed }
+
+		function void test() {
+			State s = State.Pending
+			State t = State
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Member Pending does not exist on enum State")
+	tester.assertErrorSpan(0, diagnostic.UndefinedMember, "6:14", "6:27")
+	tester.assertErrorAt(1, "Designator State is undefined")
+}
+
 // This designator
 // ---------------
 
@@ -519,6 +649,187 @@ func TestThisDesignatorResolvesToContractSymbol(t *testing.T) {
 	tester.assertBasicDesignator(thisDesignatorNode, tester.globalScope.Contract, tester.globalScope.Contract)
 }
 
+// Modifier Designators
+// --------------------
+
+func TestModifierDesignators(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int total
+
+		modifier minAmount(int amount) {
+			require(amount >= total, "Amount too low")
+			_
+		}
+
+		function void test(int x) minAmount(x) {
+		}
+	`, true)
+
+	contract := tester.globalScope.Contract
+	modifier := contract.Modifiers[0]
+	modifierNode := tester.symbolTable.GetNodeBySymbol(modifier).(*node.ModifierNode)
+	condition := modifierNode.Body[0].(*node.RequireStatementNode).Condition.(*node.BinaryExpressionNode)
+	tester.assertBasicDesignator(condition.Left, modifier.Parameters[0], tester.globalScope.IntType)
+	tester.assertBasicDesignator(condition.Right, contract.Fields[0], tester.globalScope.IntType)
+
+	invocation := tester.symbolTable.GetNodeBySymbol(contract.Functions[0]).(*node.FunctionNode).Modifiers[0]
+	assert.Equal(t, tester.symbolTable.GetDeclByDesignator(invocation.Modifier), modifier)
+	tester.assertBasicDesignator(invocation.Args[0], contract.Functions[0].Parameters[0], tester.globalScope.IntType)
+}
+
+func TestModifierCannotAccessFunctionVariables(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier check() {
+			require(x > 0, "Invalid")
+			_
+		}
+
+		function void test(int x) check {
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Designator x is undefined")
+}
+
+func TestUndefinedModifier(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() onlyOwner {
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Modifier onlyOwner is undefined")
+	tester.assertErrorSpan(0, diagnostic.UndefinedDesignator, "3:24", "3:33")
+}
+
+// Built-in Namespaces
+// -------------------
+
+func TestMsgMemberAccess(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		address sender = msg.sender
+		int value = msg.value
+
+		constructor() payable {
+		}
+	`, true)
+
+	msg := tester.globalScope.Namespaces[0]
+	tester.assertMemberAccess(tester.getFieldNode(0).Expression, msg.GetField("sender"), tester.globalScope.AddressType)
+	tester.assertMemberAccess(tester.getFieldNode(1).Expression, msg.GetField("value"), tester.globalScope.IntType)
+
+	memberAccessNode := tester.getFieldNode(0).Expression.(*node.MemberAccessNode)
+	assert.Equal(t, tester.symbolTable.GetDeclByDesignator(memberAccessNode.Designator), msg)
+}
+
+func TestMsgValueInPayableFunction(t *testing.T) {
+	newCheckerTestUtil(t, `
+		function int deposit() payable {
+			return msg.value
+		}
+	`, true)
+}
+
+func TestMsgValueInModifier(t *testing.T) {
+	newCheckerTestUtil(t, `
+		modifier minAmount(int amount) {
+			require(msg.value >= amount, "Amount too small")
+			_
+		}
+	`, true)
+}
+
+func TestMsgValueInNonPayableFunction(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int x = msg.value
+
+		function int test() {
+			return msg.value + 1
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "msg.value can only be used in payable functions")
+	tester.assertErrorSpan(1, diagnostic.InvalidMemberAccess, "6:11", "6:20")
+}
+
+func TestContractMemberAccess(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function (int, publicKey, address) test() {
+			return contract.balance, contract.address, contract.owner
+		}
+	`, true)
+
+	contract := tester.globalScope.Namespaces[1]
+	assert.Equal(t, contract.Identifier(), "contract")
+	assert.Equal(t, len(contract.Fields), 3)
+	assert.Equal(t, contract.GetField("balance").Type, tester.globalScope.IntType)
+	assert.Equal(t, contract.GetField("address").Type, tester.globalScope.PublicKeyType)
+	assert.Equal(t, contract.GetField("owner").Type, tester.globalScope.AddressType)
+}
+
+func TestArithmeticWithContractAddress(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function bool test() {
+			int x = contract.address + 1
+			return contract.address == contract.address
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "+ operator can only be applied to int/string types")
+}
+
+func TestUndefinedNamespaceMember(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int x = msg.gas
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Member gas does not exist on msg")
+	tester.assertErrorSpan(0, diagnostic.UndefinedMember, "3:11", "3:18")
+}
+
+func TestNamespaceWithoutMember(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int x = msg
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Built-in msg can only be used with member access")
+}
+
+func TestNamespaceShadowedByParameter(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		struct Message {
+			int sender
+		}
+
+		function int test(Message msg) {
+			return msg.sender
+		}
+	`, true)
+
+	returnNode := tester.getFuncStatementNode(0, 0).(*node.ReturnStatementNode)
+	tester.assertMemberAccess(returnNode.Expressions[0], tester.globalScope.Structs["Message"].Fields[0],
+		tester.globalScope.IntType)
+}
+
+func TestAssignmentToBuiltIn(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			msg.sender = msg.sender
+			contract.owner = msg.sender
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Built-in msg.sender cannot be modified")
+	tester.assertErrorSpan(0, diagnostic.ReadOnlyAssignment, "4:4", "4:27")
+	tester.assertErrorAt(1, "Built-in contract.owner cannot be modified")
+}
+
 // Arrays
 // ------
 
@@ -598,9 +909,8 @@ func TestUndefinedMapAccess(t *testing.T) {
 		int n = m[1]
 	`, false)
 
-	tester.assertTotalErrors(2)
+	tester.assertTotalErrors(1)
 	tester.assertErrorAt(0, "Designator m is undefined")
-	tester.assertErrorAt(1, "Designator m[1] does not refer to an array/map type")
 }
 
 func TestInvalidElementAccessTarget(t *testing.T) {
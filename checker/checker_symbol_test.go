@@ -1,6 +1,8 @@
 package checker
 
 import (
+	"github.com/bazo-blockchain/lazo/diagnostic"
+	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 	"gotest.tools/assert"
 	"testing"
@@ -10,7 +12,8 @@ import (
 // ===================================================
 
 func TestEmptyProgram(t *testing.T) {
-	_ = newCheckerTestUtilWithRawInput(t, ``, false)
+	tester := newCheckerTestUtilWithRawInput(t, ``, false)
+	tester.assertErrorSpan(0, diagnostic.MissingContract, "0:0", "0:0")
 }
 
 // Global Scope
@@ -24,9 +27,9 @@ func TestGlobalScope(t *testing.T) {
 
 	gs := tester.globalScope
 	assert.Check(t, gs.Contract != nil)
-	assert.Equal(t, len(gs.Types), 4)
-	assert.Equal(t, len(gs.BuiltInTypes), 4)
-	assert.Equal(t, len(gs.BuiltInFunctions), 0)
+	assert.Equal(t, len(gs.Types), 7)
+	assert.Equal(t, len(gs.BuiltInTypes), 7)
+	assert.Equal(t, len(gs.BuiltInFunctions), 2)
 	assert.Equal(t, len(gs.Constants), 2)
 
 	// Built-in types
@@ -34,6 +37,21 @@ func TestGlobalScope(t *testing.T) {
 	assert.Equal(t, gs.CharType.Identifier(), "char")
 	assert.Equal(t, gs.StringType.Identifier(), "String")
 	assert.Equal(t, gs.IntType.Identifier(), "int")
+	assert.Equal(t, gs.AddressType.Identifier(), "address")
+	assert.Equal(t, gs.HashType.Identifier(), "hash")
+	assert.Equal(t, gs.PublicKeyType.Identifier(), "publicKey")
+
+	// Built-in functions
+	sha3 := gs.BuiltInFunctions[0]
+	assert.Equal(t, sha3.Identifier(), "sha3")
+	assert.Equal(t, sha3.Parameters[0].Type, gs.StringType)
+	assert.Equal(t, sha3.ReturnTypes[0], gs.HashType)
+
+	checkSig := gs.BuiltInFunctions[1]
+	assert.Equal(t, checkSig.Identifier(), "checkSig")
+	assert.Equal(t, checkSig.Parameters[0].Type, gs.HashType)
+	assert.Equal(t, checkSig.Parameters[1].Type, gs.PublicKeyType)
+	assert.Equal(t, checkSig.ReturnTypes[0], gs.BoolType)
 
 	// Constants
 	assert.Equal(t, gs.TrueConstant.Identifier(), "true")
@@ -71,6 +89,124 @@ func TestInvalidContractName(t *testing.T) {
 	`, false)
 }
 
+// Interfaces
+// ----------
+
+func TestInterface(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface IToken {
+			function int balanceOf(address owner)
+			function (bool, int) transfer(address to, int amount)
+		}
+
+		contract Token implements IToken {
+			function int balanceOf(address owner) {
+				return 0
+			}
+
+			function (bool, int) transfer(address to, int amount) {
+				return true, amount
+			}
+		}
+	`, true)
+
+	gs := tester.globalScope
+	interfaceSymbol := gs.Interfaces["IToken"]
+	assert.Equal(t, interfaceSymbol.Scope(), gs)
+	assert.Equal(t, len(interfaceSymbol.AllDeclarations()), 2)
+	assert.Equal(t, gs.Contract.Interfaces[0], interfaceSymbol)
+	assert.Equal(t, tester.symbolTable.GetNodeBySymbol(interfaceSymbol), tester.syntaxTree.Interfaces[0])
+
+	balanceOf := interfaceSymbol.GetFunction("balanceOf")
+	assert.Equal(t, balanceOf.Scope(), interfaceSymbol)
+	assert.Equal(t, balanceOf.ReturnTypes[0], gs.IntType)
+	assert.Equal(t, balanceOf.Parameters[0].Type, gs.AddressType)
+	assert.Equal(t, len(balanceOf.LocalVariables), 0)
+
+	transfer := interfaceSymbol.GetFunction("transfer")
+	assert.Equal(t, len(transfer.ReturnTypes), 2)
+	assert.Equal(t, len(transfer.Parameters), 2)
+	assert.Assert(t, interfaceSymbol.GetFunction("approve") == nil)
+}
+
+func TestDuplicateInterface(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface I {
+		}
+
+		interface I {
+		}
+
+		contract Test {
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.DuplicateDeclaration, "5:3", "6:4")
+	tester.assertErrorAt(0, "Interface 'I' is already declared")
+}
+
+func TestDuplicateInterfaceFunction(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface I {
+			function void test()
+			function int test(int a, int a)
+		}
+
+		contract Test {
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Identifier 'test' is already declared")
+	tester.assertErrorAt(1, "Identifier 'a' is already declared")
+}
+
+func TestInvalidInterfaceNames(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface int {
+			function void char(int bool)
+		}
+
+		contract Test {
+		}
+	`, false)
+
+	tester.assertTotalErrors(3)
+	tester.assertErrorAt(0, "Reserved keyword 'int'")
+	tester.assertErrorAt(1, "Reserved keyword 'char'")
+	tester.assertErrorAt(2, "Reserved keyword 'bool'")
+}
+
+func TestUndefinedInterface(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		contract Test implements IToken {
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.UndefinedType, "2:28", "2:34")
+	tester.assertErrorAt(0, "Interface 'IToken' is undefined")
+	assert.Equal(t, len(tester.globalScope.Contract.Interfaces), 0)
+}
+
+func TestInterfaceUnknownType(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface I {
+			function void test(Person p)
+		}
+
+		contract Test implements I {
+			function void test(Person p) {
+			}
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorSpan(0, diagnostic.UndefinedType, "3:23", "3:29")
+	tester.assertErrorSpan(1, diagnostic.UndefinedType, "7:23", "7:29")
+}
+
 // Field Symbol
 // ------------
 
@@ -121,6 +257,219 @@ func TestStructType(t *testing.T) {
 	tester.assertField(0, gs.Structs["Person"])
 }
 
+// Enum Types
+// ----------
+
+func TestEnum(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		enum State { Open, Closed }
+		State state
+	`, true)
+
+	gs := tester.globalScope
+	enumType := gs.Enums["State"]
+	assert.Equal(t, gs.Types["State"], enumType)
+	assert.Equal(t, enumType.Scope(), gs.Contract)
+	assert.Equal(t, len(enumType.Members), 2)
+	assert.Equal(t, enumType.Members[1].Identifier(), "Closed")
+	assert.Equal(t, enumType.Members[1].Type, enumType)
+	assert.Equal(t, enumType.Members[1].Scope(), enumType)
+	assert.Equal(t, enumType.GetMember("Open"), enumType.Members[0])
+	assert.Equal(t, enumType.GetMemberIndex("Closed"), 1)
+	assert.Equal(t, enumType.GetMemberIndex("Pending"), -1)
+	tester.assertField(0, enumType)
+}
+
+func TestDuplicateEnum(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		struct State {
+		}
+		enum State { Open }
+	`, false)
+	tester.assertErrorAt(0, "Type 'State' is already declared")
+}
+
+func TestDuplicateEnumMember(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		enum State { Open, Closed, Open }
+	`, false)
+	tester.assertErrorAt(0, "Identifier 'Open' is already declared")
+}
+
+func TestEmptyEnum(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		enum State {}
+	`, false)
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Enum 'State' must have at least one member")
+}
+
+func TestEnumNameAsIdentifier(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		enum State { Open }
+		int State
+	`, false)
+	tester.assertErrorAt(0, "Enum name State cannot be used as an identifier")
+}
+
+// Events
+//-------
+
+func TestEvent(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		event Transfer(address from, address to, int amount)
+		event Opened()
+	`, true)
+
+	gs := tester.globalScope
+	events := gs.Contract.Events
+	assert.Equal(t, len(events), 2)
+	assert.Equal(t, events[0].Identifier(), "Transfer")
+	assert.Equal(t, len(events[0].Parameters), 3)
+	tester.assertParam(events[0].Parameters[0], events[0], gs.AddressType)
+	tester.assertParam(events[0].Parameters[2], events[0], gs.IntType)
+	assert.Equal(t, len(events[1].Parameters), 0)
+
+	// Events are not accessible as designators
+	assert.Equal(t, len(gs.Contract.AllDeclarations()), 0)
+	assert.Equal(t, gs.Contract.GetEvent("Opened"), events[1])
+}
+
+func TestEventUnknownParameterType(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		event Opened(Person p)
+	`, false)
+	tester.assertErrorAt(0, "Invalid type 'Person'")
+}
+
+func TestDuplicateEvent(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		event Opened()
+		event Opened(int x)
+	`, false)
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Event 'Opened' is already declared")
+}
+
+func TestDuplicateEventParameter(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		event Opened(int x, bool x)
+	`, false)
+	tester.assertErrorAt(0, "Identifier 'x' is already declared")
+}
+
+func TestInvalidEventName(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		event int()
+		event Opened(int this)
+	`, false)
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Reserved keyword 'int' cannot be used")
+	tester.assertErrorAt(1, "Reserved keyword 'this' cannot be used")
+}
+
+// Modifiers
+//----------
+
+func TestModifier(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier minAmount(int amount) {
+			int min = 1
+			require(amount >= min, "Amount too low")
+			_
+		}
+
+		function void test(int x) minAmount(x) {
+		}
+	`, true)
+
+	gs := tester.globalScope
+	modifiers := gs.Contract.Modifiers
+	assert.Equal(t, len(modifiers), 1)
+	assert.Equal(t, modifiers[0].Identifier(), "minAmount")
+	assert.Equal(t, len(modifiers[0].ReturnTypes), 0)
+	tester.assertParam(modifiers[0].Parameters[0], modifiers[0], gs.IntType)
+	tester.assertLocalVariable(modifiers[0].LocalVariables[0], modifiers[0], gs.IntType, 2)
+
+	// Modifiers are not accessible as designators
+	assert.Equal(t, len(gs.Contract.AllDeclarations()), 1)
+	assert.Equal(t, gs.Contract.GetModifier("minAmount"), modifiers[0])
+	assert.Assert(t, gs.Contract.IsModifier(modifiers[0]))
+	assert.Assert(t, !gs.Contract.IsModifier(gs.Contract.Functions[0]))
+}
+
+func TestDuplicateModifier(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier onlyOwner() {
+			_
+		}
+
+		modifier onlyOwner(int x) {
+			_
+		}
+	`, false)
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Modifier 'onlyOwner' is already declared")
+}
+
+func TestDuplicateModifierVariable(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier check(int x) {
+			bool x = true
+			_
+		}
+	`, false)
+	tester.assertErrorAt(0, "Identifier 'x' is already declared")
+}
+
+func TestInvalidModifierName(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier void() {
+			_
+		}
+	`, false)
+	tester.assertErrorAt(0, "Reserved keyword 'void' cannot be used")
+}
+
+// Constants
+// ---------
+
+func TestConstant(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		const int FEE = 1000
+		const String NAME = "Token"
+		int x
+	`, true)
+
+	gs := tester.globalScope
+	constants := gs.Contract.Constants
+	assert.Equal(t, len(constants), 2)
+	assert.Equal(t, constants[0].Identifier(), "FEE")
+	assert.Equal(t, constants[0].Type, gs.IntType)
+	assert.Equal(t, constants[0].Scope(), gs.Contract)
+	assert.Equal(t, constants[1].Type, gs.StringType)
+	assert.Equal(t, tester.symbolTable.GetNodeBySymbol(constants[0]), tester.syntaxTree.Contracts[0].Constants[0])
+
+	// Constants do not occupy a contract field
+	assert.Equal(t, len(gs.Contract.Fields), 1)
+	assert.Equal(t, len(gs.Contract.AllDeclarations()), 3)
+}
+
+func TestDuplicateConstant(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		const int x = 1
+		bool x
+	`, false)
+	tester.assertErrorAt(0, "Identifier 'x' is already declared")
+}
+
+func TestInvalidConstantName(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		const int this = 1
+	`, false)
+	tester.assertErrorAt(0, "Reserved keyword 'this' cannot be used")
+}
+
 // Map Types
 //----------
 
@@ -161,7 +510,7 @@ func TestInvalidMapType(t *testing.T) {
 	assert.Equal(t, len(gs.Types), len(gs.BuiltInTypes))
 
 	tester.assertErrorAt(0, "Invalid type 'Map<None,int>'")
-	assert.Equal(t, gs.Contract.Fields[0].Type, nil)
+	assert.Equal(t, gs.Contract.Fields[0].Type, gs.ErrorType)
 }
 
 func TestMapTypeWithStructType(t *testing.T) {
@@ -232,6 +581,58 @@ func TestFunctionVoid(t *testing.T) {
 	tester.assertFunction(0, 0, 0, 0)
 }
 
+func TestFunctionVisibility(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+		}
+
+		function void test2() private {
+		}
+
+		function void test3() internal {
+		}
+	`, true)
+
+	functions := tester.globalScope.Contract.Functions
+	assert.Assert(t, functions[0].IsPublic())
+	assert.Assert(t, !functions[1].IsPublic())
+	assert.Equal(t, functions[1].Visibility, token.Private)
+	assert.Equal(t, functions[2].Visibility, token.Internal)
+}
+
+func TestPayableFunction(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		constructor() payable {
+		}
+
+		function void test() payable {
+		}
+
+		function void test2() {
+		}
+	`, true)
+
+	contract := tester.globalScope.Contract
+	assert.Assert(t, contract.Constructor.Payable)
+	assert.Assert(t, contract.Functions[0].Payable)
+	assert.Assert(t, !contract.Functions[1].Payable)
+}
+
+func TestNonPublicPayableFunction(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() private payable {
+		}
+
+		function void test2() payable internal {
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "private function test cannot be payable")
+	tester.assertErrorSpan(0, diagnostic.InvalidModifier, "3:3", "4:4")
+	tester.assertErrorAt(1, "internal function test2 cannot be payable")
+}
+
 func TestFunctionMultipleVoids(t *testing.T) {
 	tester := newCheckerTestUtil(t, `
 		function (void, void) test() {
@@ -449,7 +850,7 @@ func TestInvalidFieldName(t *testing.T) {
 		bool void
 		int int
 		char this
-		string null
+		String null
 	`, false)
 	tester.assertTotalErrors(4)
 }
@@ -478,7 +879,7 @@ func TestInvalidLocalVarNames(t *testing.T) {
 			if(true) {
 				char char
 			} else {
-				string string
+				String string
 			}
 		}
 	`, false)
@@ -495,6 +896,15 @@ func TestInvalidIdentifiersInConstructor(t *testing.T) {
 	tester.assertErrorAt(1, "Reserved keyword 'bool' cannot be used")
 }
 
+func TestAddressAsIdentifier(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int address
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Reserved keyword 'address' cannot be used")
+}
+
 func TestInvalidMultiLocalVarNames(t *testing.T) {
 	tester := newCheckerTestUtil(t, `
 		function void test() {
@@ -625,7 +1035,7 @@ func TestUniqueLocalVar(t *testing.T) {
 			if(true) {
 				bool i
 			} else {
-				string i
+				String i
 			}
 		}
 	`, false)
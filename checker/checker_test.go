@@ -0,0 +1,238 @@
+package checker
+
+import (
+	"github.com/bazo-blockchain/lazo/diagnostic"
+	"gotest.tools/assert"
+	"testing"
+)
+
+// Error Recovery
+// --------------
+
+func TestErrorsOfAllPhases(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int int
+		Person p
+		int x = y
+		bool b = 1
+	`, false)
+
+	tester.assertTotalErrors(4)
+	tester.assertErrorSpan(0, diagnostic.ReservedIdentifier, "3:3", "3:10")
+	tester.assertErrorSpan(1, diagnostic.UndefinedType, "4:3", "4:9")
+	tester.assertErrorSpan(2, diagnostic.UndefinedDesignator, "5:11", "5:12")
+	tester.assertErrorSpan(3, diagnostic.TypeMismatch, "6:12", "6:13")
+}
+
+func TestNoFollowUpErrorsOfUndefinedType(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		Person p
+		int x = p.age + 1
+
+		function Person test(Person q) {
+			return q
+		}
+
+		function void test2() {
+			foreach (a in p) {
+			}
+			Person r = test(p)
+		}
+	`, false)
+
+	tester.assertTotalErrors(4)
+	for i := 0; i < 4; i++ {
+		tester.assertErrorAt(i, "Invalid type 'Person'")
+	}
+	tester.assertExpressionType(tester.getFieldNode(1).Expression, tester.globalScope.ErrorType)
+}
+
+func TestNoFollowUpErrorsOfUndefinedDesignator(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int x = -(y * 2 + z[1]) > 4 ? 1 : 0
+		bool b = !f(x)
+		int[] a = new int[n]
+	`, false)
+
+	tester.assertTotalErrors(4)
+	tester.assertErrorAt(0, "Designator y is undefined")
+	tester.assertErrorAt(1, "Designator z is undefined")
+	tester.assertErrorAt(2, "Designator f is undefined")
+	tester.assertErrorAt(3, "Designator n is undefined")
+}
+
+func TestNoFollowUpErrorsOfTypeError(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int x = (true + 1) * 2
+		bool b = x
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "+ operator can only be applied to int/string types")
+	tester.assertErrorSpan(1, diagnostic.TypeMismatch, "4:12", "4:13")
+}
+
+func TestNoFollowUpErrorsOfDuplicateLocalVariable(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function int test(bool b) {
+			int sum
+			for (int i : 0..3) {
+				sum += i
+			}
+			for (int i : 0..3) {
+				sum += i
+			}
+
+			if (b) {
+				int x = 1
+				sum += x
+			} else {
+				bool x = true
+				b = x
+			}
+			return sum
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorSpan(0, diagnostic.DuplicateDeclaration, "8:9", "8:14")
+	tester.assertErrorSpan(1, diagnostic.DuplicateDeclaration, "16:5", "16:18")
+}
+
+func TestNoFollowUpErrorsOfShadowedLocalVariable(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			int i
+			if (true) {
+				bool i
+				i = true
+			}
+			i = 1
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Identifier 'i' is already declared")
+}
+
+// Multiple Contracts
+// ------------------
+
+func TestSelectedContract(t *testing.T) {
+	tester := newCheckerTestUtilForContract(t, `
+		contract A {
+			int x
+		}
+
+		contract B {
+			bool b
+		}
+	`, "B", true)
+
+	assert.Equal(t, tester.globalScope.Contract.Identifier(), "B")
+	tester.assertField(0, tester.globalScope.BoolType)
+}
+
+func TestSingleContractWithoutSelection(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface I {
+			function void test()
+		}
+
+		contract A implements I {
+			function void test() {
+			}
+		}
+	`, true)
+
+	assert.Equal(t, tester.globalScope.Contract.Identifier(), "A")
+}
+
+func TestMultipleContractsWithoutSelection(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		contract A {
+		}
+
+		contract B {
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.MissingContract, "0:0", "0:0")
+	tester.assertErrorAt(0, "Program declares multiple contracts, select one of A, B")
+}
+
+func TestUndeclaredSelectedContract(t *testing.T) {
+	tester := newCheckerTestUtilForContract(t, `
+		contract A {
+		}
+	`, "B", false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.MissingContract, "0:0", "0:0")
+	tester.assertErrorAt(0, "Contract B is not declared")
+}
+
+func TestDuplicateContract(t *testing.T) {
+	tester := newCheckerTestUtilForContract(t, `
+		contract A {
+		}
+
+		contract A {
+		}
+	`, "A", false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.DuplicateDeclaration, "5:3", "6:4")
+	tester.assertErrorAt(0, "Contract 'A' is already declared")
+}
+
+func TestErrorsOfAllContracts(t *testing.T) {
+	tester := newCheckerTestUtilForContract(t, `
+		contract A {
+			int x = true
+		}
+
+		contract B {
+			bool b = 1
+		}
+	`, "A", false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorSpan(0, diagnostic.TypeMismatch, "3:12", "3:16")
+	tester.assertErrorSpan(1, diagnostic.TypeMismatch, "7:13", "7:14")
+}
+
+func TestInterfaceErrorsReportedOnce(t *testing.T) {
+	tester := newCheckerTestUtilForContract(t, `
+		interface I {
+			function Person test()
+		}
+
+		contract A {
+		}
+
+		contract B {
+		}
+	`, "A", false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.UndefinedType, "3:13", "3:19")
+}
+
+func TestInterfaceWithContractType(t *testing.T) {
+	tester := newCheckerTestUtilForContract(t, `
+		interface I {
+			function void test(Person p)
+		}
+
+		contract A {
+			struct Person {
+				int age
+			}
+		}
+	`, "A", false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.UndefinedType, "3:23", "3:29")
+}
@@ -1,7 +1,12 @@
 package checker
 
 import (
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/parser/node"
+	"gotest.tools/assert"
+	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -78,6 +83,7 @@ func TestConstructorLocalVars(t *testing.T) {
 	constructor := gs.Contract.Constructor
 	tester.assertLocalVariable(constructor.LocalVariables[0], constructor, gs.IntType, 1)
 	tester.assertErrorAt(0, "expected char, given int")
+	tester.assertErrorSpan(0, diagnostic.TypeMismatch, "5:13", "5:14")
 }
 
 // Return Types
@@ -328,6 +334,315 @@ func TestIfConditionIntType(t *testing.T) {
 	`, false)
 }
 
+// Loop Statement Types
+// --------------------
+
+func TestForLoopTypes(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(int n) {
+			int sum
+			for (int i : 0..n) {
+				sum += i
+			}
+		}
+	`, true)
+
+	gs := tester.globalScope
+	tester.assertFuncLocalVariable(0, 1, gs.IntType, 1)
+	forStmt := tester.getFuncStatementNode(0, 1).(*node.ForStatementNode)
+	tester.assertExpressionType(forStmt.From, gs.IntType)
+	tester.assertExpressionType(forStmt.To, gs.IntType)
+}
+
+func TestForLoopInvalidTypes(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			for (char c : 'a'..true) {
+			}
+		}
+	`, false)
+
+	tester.assertTotalErrors(3)
+	tester.assertErrorAt(0, "Loop variable c must be of type int")
+	tester.assertErrorAt(1, "expected Type int, got Type char")
+	tester.assertErrorAt(2, "expected Type int, got Type bool")
+}
+
+func TestForLoopVariableModification(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			for (int i : 0..10) {
+				i = 10
+				i++
+			}
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Loop variable i cannot be modified")
+	tester.assertErrorAt(1, "Loop variable i cannot be modified")
+}
+
+func TestForEachLoopInferredType(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function int test(int[] arr) {
+			int sum
+			foreach (x in arr) {
+				sum += x
+			}
+			return sum
+		}
+	`, true)
+
+	tester.assertFuncLocalVariable(0, 1, tester.globalScope.IntType, 1)
+}
+
+func TestForEachLoopDeclaredType(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(bool[] arr) {
+			foreach (bool b in arr) {
+			}
+		}
+	`, true)
+
+	tester.assertFuncLocalVariable(0, 0, tester.globalScope.BoolType, 0)
+}
+
+func TestForEachLoopTypeMismatch(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(bool[] arr) {
+			foreach (int x in arr) {
+			}
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "expected Type bool, got Type int")
+}
+
+func TestForEachLoopNonArray(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(Map<int, int> m) {
+			foreach (int x in m) {
+			}
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "foreach requires array type, given Map<int,int>")
+}
+
+func TestForEachLoopVariableModification(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(int[] arr) {
+			foreach (x in arr) {
+				x += 1
+			}
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Loop variable x cannot be modified")
+}
+
+// Abort Statement Types
+// ---------------------
+
+func TestRequireStatement(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(int x) {
+			require(x > 0, "x must be positive")
+		}
+	`, true)
+
+	stmt := tester.getFuncStatementNode(0, 0).(*node.RequireStatementNode)
+	tester.assertExpressionType(stmt.Condition, tester.globalScope.BoolType)
+	tester.assertExpressionType(stmt.Message, tester.globalScope.StringType)
+}
+
+func TestRequireStatementWithInvalidCondition(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(int x) {
+			require(x, "x must be positive")
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "condition must return boolean")
+}
+
+func TestRequireStatementWithInvalidMessage(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(int x) {
+			require(x > 0, 'c')
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Type mismatch: expected String, given char")
+}
+
+func TestAssertStatement(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(int x) {
+			assert(x != 0)
+		}
+	`, true)
+
+	stmt := tester.getFuncStatementNode(0, 0).(*node.AssertStatementNode)
+	tester.assertExpressionType(stmt.Condition, tester.globalScope.BoolType)
+}
+
+func TestAssertStatementWithInvalidCondition(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			assert("true")
+		}
+	`, false)
+
+	tester.assertErrorAt(0, "condition must return boolean")
+}
+
+func TestRevertStatementWithInvalidMessage(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			revert(1)
+		}
+	`, false)
+
+	tester.assertErrorAt(0, "Type mismatch: expected String, given int")
+}
+
+// Emit Statements
+// ---------------
+
+func TestEmitStatement(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		event Transfer(address to, int amount)
+
+		function void test(address to) {
+			emit Transfer(to, 5)
+		}
+	`, true)
+
+	emit := tester.getFuncStatementNode(0, 0).(*node.EmitStatementNode)
+	assert.Equal(t, tester.symbolTable.GetDeclByDesignator(emit.Event), tester.globalScope.Contract.Events[0])
+	tester.assertExpressionType(emit.Args[0], tester.globalScope.AddressType)
+	tester.assertExpressionType(emit.Args[1], tester.globalScope.IntType)
+}
+
+func TestEmitUndefinedEvent(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			emit Opened(x)
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Designator x is undefined")
+	tester.assertErrorAt(1, "Event Opened is undefined")
+}
+
+func TestEmitArgumentErrors(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		event Transfer(address to, int amount)
+
+		function void test() {
+			emit Transfer(5)
+			emit Transfer(5, true)
+			emit Transfer(this, x)
+		}
+	`, false)
+
+	tester.assertTotalErrors(5)
+	tester.assertErrorAt(0, "Designator x is undefined")
+	tester.assertErrorAt(1, "expected 2 args, got 1")
+	tester.assertErrorAt(2, "expected Type address, got Type int")
+	tester.assertErrorAt(3, "expected Type int, got Type bool")
+	tester.assertErrorAt(4, "'this' cannot be used as an argument")
+}
+
+// Modifiers
+// ---------
+
+func TestModifierInvocation(t *testing.T) {
+	newCheckerTestUtil(t, `
+		modifier check(int min, String message) {
+			_
+		}
+
+		function void test(int x) check(x + 1, "Too low") check(5, "Invalid") {
+		}
+	`, true)
+}
+
+func TestModifierArgumentErrors(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier check(int min) {
+			_
+		}
+
+		function void test() check {
+		}
+
+		function void test2() check(true) {
+		}
+
+		function void test3() check(this) {
+		}
+	`, false)
+
+	tester.assertTotalErrors(3)
+	tester.assertErrorAt(0, "expected 1 args, got 0")
+	tester.assertErrorAt(1, "expected Type int, got Type bool")
+	tester.assertErrorAt(2, "'this' cannot be used as an argument")
+}
+
+func TestModifierWithoutPlaceholder(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier check() {
+			require(true, "Invalid")
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Modifier check must contain a placeholder '_'")
+}
+
+func TestInvalidPlaceholders(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier check() {
+			_
+			if (true) {
+				_
+			}
+			_
+		}
+
+		function void test() {
+			_
+		}
+	`, false)
+
+	tester.assertTotalErrors(3)
+	tester.assertErrorAt(0, "Placeholder '_' can only be used in the top level of a modifier body")
+	tester.assertErrorSpan(1, diagnostic.InvalidPlaceholder, "8:4", "8:5")
+	tester.assertErrorAt(1, "Only one placeholder is allowed in a modifier")
+	tester.assertErrorAt(2, "Placeholder '_' can only be used in the top level of a modifier body")
+}
+
+func TestReturnInModifier(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		modifier check() {
+			_
+			return
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "return is not allowed in modifier")
+}
+
 // Ternary Expressions
 // -------------------
 
@@ -457,6 +772,62 @@ func TestStringConcatenationError(t *testing.T) {
 	tester.assertErrorAt(0, "+ operator can only be applied to int/string types")
 }
 
+func TestStringMemberFunctions(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			String s = "hello"
+			int length = s.length()
+			char c = s.at(0)
+			String part = s.substring(1, 3)
+			bool isEqual = s.equals("world")
+			bool isContained = s.contains("ell")
+		}
+	`, true)
+
+	gs := tester.globalScope
+	expectedTypes := []symbol.TypeSymbol{gs.IntType, gs.CharType, gs.StringType, gs.BoolType, gs.BoolType}
+	for i, expectedType := range expectedTypes {
+		call := tester.getFuncStatementNode(0, i+1).(*node.VariableNode).Expression.(*node.FuncCallNode)
+		tester.assertExpressionType(call, expectedType)
+	}
+
+	call := tester.getFuncStatementNode(0, 5).(*node.VariableNode).Expression.(*node.FuncCallNode)
+	assert.Equal(t, tester.symbolTable.GetDeclByDesignator(call.Designator), gs.StringMemberFunctions["contains"])
+}
+
+func TestStringMemberFunctionArgumentErrors(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			String s = "hello"
+			char c = s.at('0')
+			String part = s.substring(1)
+			bool isEqual = s.equals(1)
+			bool isContained = s.contains('e')
+		}
+	`, false)
+
+	tester.assertTotalErrors(4)
+	tester.assertErrorAt(0, "expected Type int, got Type char")
+	tester.assertErrorAt(1, "expected 2 args, got 1")
+	tester.assertErrorAt(2, "expected Type String, got Type int")
+	tester.assertErrorAt(3, "expected Type String, got Type char")
+}
+
+func TestInvalidStringMember(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			String s = "hello"
+			int length = s.size()
+			bool b = true
+			b.equals(false)
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Invalid member access size on string s.size")
+	tester.assertErrorAt(1, "Designator b.equals does not refer to a composite type")
+}
+
 func TestEqualityComparisonType(t *testing.T) {
 	tester := newCheckerTestUtil(t, `
 		bool a = true == false
@@ -577,19 +948,58 @@ func TestTypeCastToStringError(t *testing.T) {
 		String s = (String) i
 	`, false)
 
-	tester.assertTotalErrors(2)
+	tester.assertTotalErrors(1)
 	tester.assertErrorAt(0, "String type cast is not supported for Array of Type int")
-	tester.assertExpressionType(tester.getFieldNode(1).Expression, nil)
+	tester.assertExpressionType(tester.getFieldNode(1).Expression, tester.globalScope.ErrorType)
+}
+
+func TestTypeCastToInt(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int i = (int) 'c'
+		int i2 = (int) true
+		int i3 = (int) "5"
+		int i4 = (int) i
+	`, true)
+
+	intType := tester.globalScope.IntType
+	for i := 0; i < 4; i++ {
+		tester.assertExpressionType(tester.getFieldNode(i).Expression, intType)
+	}
+}
+
+func TestTypeCastToChar(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		char c = (char) 97
+		char c2 = (char) c
+	`, true)
+
+	tester.assertExpressionType(tester.getFieldNode(0).Expression, tester.globalScope.CharType)
+	tester.assertExpressionType(tester.getFieldNode(1).Expression, tester.globalScope.CharType)
+}
+
+func TestTypeCastError(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int[] a
+		int i = (int) a
+		char c = (char) true
+		char c2 = (char) "c"
+	`, false)
+
+	tester.assertTotalErrors(3)
+	tester.assertErrorAt(0, "int type cast is not supported for Array of Type int")
+	tester.assertErrorAt(1, "char type cast is not supported for Type bool")
+	tester.assertErrorAt(2, "char type cast is not supported for Type String")
+	tester.assertExpressionType(tester.getFieldNode(1).Expression, tester.globalScope.ErrorType)
 }
 
 func TestTypeCastUnsupportedType(t *testing.T) {
 	tester := newCheckerTestUtil(t, `
-		int i = (int) true
+		bool b = (bool) 1
 	`, false)
 
-	tester.assertTotalErrors(2)
-	tester.assertErrorAt(0, "Unsupported type cast to Type int")
-	tester.assertExpressionType(tester.getFieldNode(0).Expression, nil)
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Unsupported type cast to Type bool")
+	tester.assertExpressionType(tester.getFieldNode(0).Expression, tester.globalScope.ErrorType)
 }
 
 // Unary Expression Types
@@ -1220,6 +1630,45 @@ func TestThisParameter(t *testing.T) {
 	tester.assertErrorAt(0, "'this' cannot be used as an argument")
 }
 
+// Enum Types
+// ----------
+
+func TestEnumEqualityComparison(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		enum State { Open, Closed }
+		State state
+
+		function bool test(State s) {
+			return s == State.Open || state != s
+		}
+	`, true)
+
+	returnStmt := tester.getFuncStatementNode(0, 0).(*node.ReturnStatementNode)
+	tester.assertExpressionType(returnStmt.Expressions[0], tester.globalScope.BoolType)
+}
+
+func TestEnumTypeErrors(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		enum State { Open, Closed }
+		enum Kind { Open }
+
+		function void test() {
+			bool b = State.Open < State.Closed
+			State s = 0
+			int i = State.Open + 1
+			b = State.Open == Kind.Open
+			State.Open = State.Closed
+		}
+	`, false)
+
+	tester.assertTotalErrors(5)
+	tester.assertErrorAt(0, "Enum State can only be compared with == and !=")
+	tester.assertErrorAt(1, "Type mismatch: expected State, given int")
+	tester.assertErrorAt(2, "+ operator can only be applied to int/string types")
+	tester.assertErrorAt(3, "Equality comparison should have the same type")
+	tester.assertErrorAt(4, "Constant State.Open cannot be modified")
+}
+
 // Arrays
 // ------
 
@@ -1331,6 +1780,74 @@ func TestInvalidArrayElementAccess(t *testing.T) {
 	tester.assertErrorAt(0, "Array index must be of type int")
 }
 
+// Array Member Functions
+// ----------------------
+
+func TestArrayMemberFunctions(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			int[] a
+			a.append(1)
+			a.insert(0, 2)
+			a.remove(a.length - 1)
+		}
+	`, true)
+
+	appendCall := tester.getFuncStatementNode(0, 1).(*node.CallStatementNode).Call
+	assert.Equal(t, tester.symbolTable.GetDeclByDesignator(appendCall.Designator),
+		tester.globalScope.ArrayMemberFunctions["append"])
+}
+
+func TestArrayAppendTypeMismatch(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			bool[] a
+			a.append(1)
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "expected Type bool, got Type int")
+}
+
+func TestArrayInsertTypeMismatch(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			String[] a
+			a.insert("0", 'c')
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "expected Type int, got Type String")
+	tester.assertErrorAt(1, "expected Type String, got Type char")
+}
+
+func TestArrayRemoveTypeMismatch(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			int[] a
+			a.remove(true)
+			a.remove()
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "expected Type int, got Type bool")
+	tester.assertErrorAt(1, "expected 1 args, got 0")
+}
+
+func TestArrayMemberFunctionIsVoid(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test() {
+			int[] a
+			int x = a.append(1)
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+}
+
 // Maps
 // ----
 
@@ -1442,3 +1959,359 @@ func TestMapContainsKeyTypeMismatch(t *testing.T) {
 
 	tester.assertErrorAt(0, "expected Type char, got Type String")
 }
+
+// Addresses
+// ---------
+
+const testAddress = "@000000000000000000000000000000000000000000000000000000000000abcd"
+
+func TestAddressLiteral(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		address a = `+testAddress+`
+		address b
+	`, true)
+
+	tester.assertField(0, tester.globalScope.AddressType)
+	tester.assertExpressionType(tester.getFieldNode(0).Expression, tester.globalScope.AddressType)
+	tester.assertField(1, tester.globalScope.AddressType)
+}
+
+func TestAddressEquality(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		address owner = `+testAddress+`
+		bool isOwner = msg.sender == owner
+		bool isOther = msg.sender != contract.owner
+	`, true)
+
+	tester.assertExpressionType(tester.getFieldNode(1).Expression, tester.globalScope.BoolType)
+	tester.assertExpressionType(tester.getFieldNode(2).Expression, tester.globalScope.BoolType)
+}
+
+func TestAddressWithInt(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		address a = 0x01
+		bool b = msg.sender == 1
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Type mismatch: expected address, given int")
+	tester.assertErrorAt(1, "Equality comparison should have the same type, given Type address and Type int")
+}
+
+func TestInvalidAddressOperators(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void test(address a) {
+			int b = a + a
+			bool c = a < a
+			int d = -a
+			int e = a & a
+		}
+	`, false)
+
+	tester.assertTotalErrors(4)
+	tester.assertErrorAt(0, "+ operator can only be applied to int/string types")
+	tester.assertErrorAt(1, "Relational comparison is not supported for Type address")
+	tester.assertErrorAt(2, "+ and - unary operators can only be applied to expressions of type int")
+	tester.assertErrorAt(3, "Bitwise logic operators can only be applied to int types")
+}
+
+func TestAddressAsMapKey(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		Map<address, int> balances
+
+		function int test() {
+			balances[msg.sender] += 1
+			balances[`+testAddress+`] = 2
+			return balances[msg.sender]
+		}
+	`, true)
+
+	returnStmt := tester.getFuncStatementNode(0, 2).(*node.ReturnStatementNode)
+	tester.assertExpressionType(returnStmt.Expressions[0], tester.globalScope.IntType)
+}
+
+func TestAddressMapWithIntKey(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		Map<address, int> balances
+		int x = balances[1]
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "expected Type address, got Type int")
+}
+
+// Cryptographic Functions
+// -----------------------
+
+func TestSha3(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		hash h = sha3("vote" + "salt")
+		bool b = h == sha3("other")
+	`, true)
+
+	tester.assertField(0, tester.globalScope.HashType)
+	tester.assertExpressionType(tester.getFieldNode(0).Expression, tester.globalScope.HashType)
+}
+
+func TestSha3WithInvalidArgument(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		hash h = sha3(1)
+		String s = sha3("a")
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "expected Type String, got Type int")
+	tester.assertErrorAt(1, "Return type mismatch")
+}
+
+func TestCheckSig(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function bool test(publicKey key) {
+			return checkSig(sha3("message"), key)
+		}
+	`, true)
+
+	returnStmt := tester.getFuncStatementNode(0, 0).(*node.ReturnStatementNode)
+	tester.assertExpressionType(returnStmt.Expressions[0], tester.globalScope.BoolType)
+}
+
+func TestCheckSigWithInvalidArguments(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function bool test(publicKey key) {
+			return checkSig(key, sha3("message")) && checkSig(sha3("message"))
+		}
+	`, false)
+
+	tester.assertTotalErrors(3)
+	tester.assertErrorAt(0, "expected Type hash, got Type publicKey")
+	tester.assertErrorAt(1, "expected Type publicKey, got Type hash")
+	tester.assertErrorAt(2, "expected 2 args, got 1")
+}
+
+func TestInvalidHashOperators(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		bool b = sha3("a") < sha3("b")
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "Relational comparison is not supported for Type hash")
+}
+
+func TestShadowedBuiltInFunction(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int x = sha3(1)
+
+		function int sha3(int a) {
+			return a
+		}
+	`, true)
+
+	tester.assertField(0, tester.globalScope.IntType)
+}
+
+// Transfers
+// ---------
+
+func TestUnsupportedTransfer(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void pay(address to) {
+			transfer(to, 5)
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorAt(0, "transfer is not supported, since the Bazo VM cannot send Bazo coins")
+	tester.assertErrorSpan(0, diagnostic.UnsupportedBuiltIn, "4:4", "4:12")
+}
+
+func TestTransferFunction(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		function void pay(address to) {
+			transfer(to, 5)
+		}
+
+		function void transfer(address to, int amount) {
+		}
+	`, true)
+
+	st := tester.getFuncStatementNode(0, 0).(*node.CallStatementNode)
+	assert.Equal(t, tester.symbolTable.GetDeclByDesignator(st.Call.Designator), tester.globalScope.Contract.Functions[1])
+}
+
+// Constants
+// ---------
+
+func TestConstantFolding(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		const int FEE = 1000
+		const int TOTAL = FEE * 2 + 7 % 3
+		const bool ENABLED = !(TOTAL < FEE) && true
+		const String NAME = "Lazo" + " Token"
+		const char SEPARATOR = ','
+
+		function int test(int x) {
+			return x + TOTAL
+		}
+	`, true)
+
+	constants := tester.syntaxTree.Contracts[0].Constants
+	assert.Equal(t, tester.symbolTable.GetValueByExpression(constants[1].Expression).(*big.Int).Int64(), int64(2001))
+	assert.Equal(t, tester.symbolTable.GetValueByExpression(constants[2].Expression), true)
+	assert.Equal(t, tester.symbolTable.GetValueByExpression(constants[3].Expression), "Lazo Token")
+	assert.Equal(t, tester.symbolTable.GetValueByExpression(constants[4].Expression), ',')
+
+	returnStmt := tester.getFuncStatementNode(0, 0).(*node.ReturnStatementNode)
+	binExpr := returnStmt.Expressions[0].(*node.BinaryExpressionNode)
+	tester.assertBasicDesignator(binExpr.Right, tester.globalScope.Contract.Constants[1], tester.globalScope.IntType)
+	assert.Equal(t, tester.symbolTable.GetValueByExpression(binExpr.Right).(*big.Int).Int64(), int64(2001))
+	assert.Assert(t, tester.symbolTable.GetValueByExpression(binExpr) == nil)
+}
+
+func TestConstantWithoutCompileTimeValue(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		int x
+		const int A = x + 1
+		const int B = 1 / 0
+		const bool C = (1 & 1) == 1
+		const int D = E
+		const int E = 1
+	`, false)
+
+	tester.assertTotalErrors(4)
+	tester.assertErrorAt(0, "Value of constant A cannot be computed at compile time")
+	tester.assertErrorAt(1, "Value of constant B cannot be computed at compile time")
+	tester.assertErrorAt(2, "Value of constant C cannot be computed at compile time")
+	tester.assertErrorAt(3, "Value of constant D cannot be computed at compile time")
+	tester.assertErrorSpan(0, diagnostic.InvalidConstant, "4:17", "4:22")
+}
+
+func TestConstantFoldingLimits(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		const int BIG = 2 ** 4000000000
+		const String LONG = "`+strings.Repeat("a", 200)+`" + "`+strings.Repeat("b", 200)+`"
+		const int MAX = 2 ** 2039
+
+		function bool test() {
+			return 2 ** 2040 > MAX
+		}
+	`, false)
+
+	tester.assertTotalErrors(2)
+	tester.assertErrorAt(0, "Value of constant BIG cannot be computed at compile time")
+	tester.assertErrorAt(1, "Value of constant LONG cannot be computed at compile time")
+
+	constants := tester.syntaxTree.Contracts[0].Constants
+	assert.Equal(t, tester.symbolTable.GetValueByExpression(constants[2].Expression).(*big.Int).BitLen(), 2040)
+
+	returnStmt := tester.getFuncStatementNode(0, 0).(*node.ReturnStatementNode)
+	binExpr := returnStmt.Expressions[0].(*node.BinaryExpressionNode)
+	assert.Assert(t, tester.symbolTable.GetValueByExpression(binExpr.Left) == nil)
+}
+
+func TestConstantTypeErrors(t *testing.T) {
+	tester := newCheckerTestUtil(t, `
+		const int A = true
+		const int[] B = new int[]{1}
+
+		function void test() {
+			A = 2
+		}
+	`, false)
+
+	tester.assertTotalErrors(3)
+	tester.assertErrorAt(0, "expected int, given bool")
+	tester.assertErrorAt(1, "Constants of type int[] are not supported")
+	tester.assertErrorAt(2, "Constant A cannot be modified")
+}
+
+// Interfaces
+// ----------
+
+func TestImplementedInterfaces(t *testing.T) {
+	_ = newCheckerTestUtilWithRawInput(t, `
+		interface IToken {
+			function int balanceOf(address owner)
+		}
+
+		interface IOwned {
+			function void setOwner(address owner)
+		}
+
+		contract Token implements IToken, IOwned {
+			Map<address, int> balances
+			address owner
+
+			function int balanceOf(address a) {
+				return balances[a]
+			}
+
+			function void setOwner(address o) {
+				owner = o
+			}
+
+			function void other() {
+			}
+		}
+	`, true)
+}
+
+func TestMissingInterfaceFunction(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface IToken {
+			function int balanceOf(address owner)
+		}
+
+		contract Token implements IToken {
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.InterfaceMismatch, "6:29", "6:35")
+	tester.assertErrorAt(0, "Contract Token does not implement function balanceOf of interface IToken")
+}
+
+func TestInterfaceFunctionMismatch(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface IToken {
+			function int balanceOf(address owner)
+			function void reset()
+			function (bool, int) transfer(address to, int amount)
+		}
+
+		contract Token implements IToken {
+			function bool balanceOf(address owner) {
+				return true
+			}
+
+			function void reset(int a) {
+			}
+
+			function (bool, int) transfer(int amount, address to) {
+				return true, amount
+			}
+		}
+	`, false)
+
+	tester.assertTotalErrors(3)
+	tester.assertErrorSpan(0, diagnostic.InterfaceMismatch, "9:4", "11:5")
+	tester.assertErrorAt(0, "Function balanceOf does not match the signature in interface IToken")
+	tester.assertErrorAt(1, "Function reset does not match the signature in interface IToken")
+	tester.assertErrorAt(2, "Function transfer does not match the signature in interface IToken")
+}
+
+func TestPrivateInterfaceFunction(t *testing.T) {
+	tester := newCheckerTestUtilWithRawInput(t, `
+		interface I {
+			function void test()
+		}
+
+		contract Test implements I {
+			function void test() internal {
+			}
+		}
+	`, false)
+
+	tester.assertTotalErrors(1)
+	tester.assertErrorSpan(0, diagnostic.InterfaceMismatch, "7:4", "8:5")
+	tester.assertErrorAt(0, "Function test must be public to implement interface I")
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/lexer"
 	"github.com/bazo-blockchain/lazo/parser"
 	"github.com/bazo-blockchain/lazo/parser/node"
@@ -29,6 +30,10 @@ func newCheckerTestUtil(t *testing.T, contractCode string, isValidCode bool) *Ch
 }
 
 func newCheckerTestUtilWithRawInput(t *testing.T, code string, isValidCode bool) *CheckerTestUtil {
+	return newCheckerTestUtilForContract(t, code, "", isValidCode)
+}
+
+func newCheckerTestUtilForContract(t *testing.T, code string, contract string, isValidCode bool) *CheckerTestUtil {
 	p := parser.New(lexer.New(bufio.NewReader(strings.NewReader(code))))
 	program, err := p.ParseProgram()
 	assert.Equal(t, len(err), 0, "Program has syntax errors", err)
@@ -37,7 +42,9 @@ func newCheckerTestUtilWithRawInput(t *testing.T, code string, isValidCode bool)
 		t:          t,
 		syntaxTree: program,
 	}
-	tester.symbolTable, tester.errors = New(program).Run()
+	checker := New(program)
+	checker.Contract = contract
+	tester.symbolTable, tester.errors = checker.Run()
 	tester.globalScope = tester.symbolTable.GlobalScope
 	assert.Equal(t, len(tester.errors) == 0, isValidCode, tester.errors)
 
@@ -57,6 +64,15 @@ func (ct *CheckerTestUtil) assertErrorAt(index int, errSubStr string) {
 	assert.Assert(ct.t, strings.Contains(err, errSubStr), err)
 }
 
+func (ct *CheckerTestUtil) assertErrorSpan(index int, code diagnostic.Code, start string, end string) {
+	assert.Assert(ct.t, len(ct.errors) > index)
+	d, ok := ct.errors[index].(*diagnostic.Diagnostic)
+	assert.Assert(ct.t, ok)
+	assert.Equal(ct.t, d.Code, code)
+	assert.Equal(ct.t, d.Start.String(), start)
+	assert.Equal(ct.t, d.End.String(), end)
+}
+
 func (ct *CheckerTestUtil) assertContract(totalVars int, totalFunctions int) {
 	contractSymbol := ct.symbolTable.GlobalScope.Contract
 	assert.Equal(ct.t, contractSymbol.Scope(), ct.symbolTable.GlobalScope)
@@ -266,15 +282,15 @@ func (ct *CheckerTestUtil) assertArrayValueCreation(expr node.ExpressionNode, ex
 // ----------------
 
 func (ct *CheckerTestUtil) getFieldNode(index int) *node.FieldNode {
-	return ct.syntaxTree.Contract.Fields[index]
+	return ct.syntaxTree.Contracts[0].Fields[index]
 }
 
 func (ct *CheckerTestUtil) getConstructorStatementNode(stmtIndex int) node.StatementNode {
-	return ct.syntaxTree.Contract.Constructor.Body[stmtIndex]
+	return ct.syntaxTree.Contracts[0].Constructor.Body[stmtIndex]
 }
 
 func (ct *CheckerTestUtil) getFuncStatementNode(funcIndex int, stmtIndex int) node.StatementNode {
-	return ct.syntaxTree.Contract.Functions[funcIndex].Body[stmtIndex]
+	return ct.syntaxTree.Contracts[0].Functions[funcIndex].Body[stmtIndex]
 }
 
 func (ct *CheckerTestUtil) getLocalVariableSymbol(funcIndex int, varIndex int) *symbol.LocalVariableSymbol {
@@ -3,9 +3,17 @@ package designatorresolution
 import (
 	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/diagnostic"
+	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 )
 
+// unsupportedBuiltIns are the built-in functions of other contract languages, which the Bazo VM cannot provide.
+// They are reported with the reason instead of as undefined designators.
+var unsupportedBuiltIns = map[string]string{
+	"transfer": "the Bazo VM cannot send Bazo coins",
+}
+
 type designatorResolutionVisitor struct {
 	node.AbstractVisitor
 	symbolTable           *symbol.SymbolTable
@@ -24,12 +32,24 @@ func newDesignatorResolutionVisitor(symbolTable *symbol.SymbolTable, contractSym
 	return v
 }
 
-// VisitContractNode visits all fields and functions of the contract. Stores the current function in the visitor.
+// VisitContractNode visits all constants, fields, modifiers and functions of the contract.
+// Stores the current function in the visitor.
 func (v *designatorResolutionVisitor) VisitContractNode(node *node.ContractNode) {
+	for _, constant := range node.Constants {
+		constant.Accept(v.ConcreteVisitor)
+	}
+
 	for _, variable := range node.Fields {
 		variable.Accept(v.ConcreteVisitor)
 	}
 
+	for _, modifier := range v.contractSymbol.Modifiers {
+		v.currentFunctionSymbol = modifier
+		modifierNode := v.symbolTable.GetNodeBySymbol(modifier)
+		modifierNode.Accept(v)
+		v.currentFunctionSymbol = nil
+	}
+
 	if node.Constructor != nil {
 		v.currentFunctionSymbol = v.contractSymbol.Constructor
 		node.Constructor.Accept(v)
@@ -53,30 +73,89 @@ func (v *designatorResolutionVisitor) VisitStatementBlock(stmts []node.Statement
 	}
 }
 
+// VisitForEachStatementNode visits the collection and infers the type of the loop variable if it is not declared.
+// The loop variable type has to be known before its designators in the loop body are resolved.
+func (v *designatorResolutionVisitor) VisitForEachStatementNode(node *node.ForEachStatementNode) {
+	node.Collection.Accept(v.ConcreteVisitor)
+
+	// The loop variable is not found, if its identifier is already declared as parameter
+	loopVariable, ok := v.symbolTable.Find(v.currentFunctionSymbol, node.Variable.Identifier).(*symbol.LocalVariableSymbol)
+	if node.Variable.Type == nil && ok {
+		collectionType := v.symbolTable.GetTypeByExpression(node.Collection)
+		if arrayType, ok := collectionType.(*symbol.ArrayTypeSymbol); ok {
+			loopVariable.Type = arrayType.ElementType
+		} else {
+			loopVariable.Type = v.symbolTable.GlobalScope.ErrorType
+			if collectionType != v.symbolTable.GlobalScope.ErrorType {
+				v.reportError(node.Variable, diagnostic.TypeNotInferable,
+					fmt.Sprintf("Type of loop variable %s cannot be inferred", node.Variable.Identifier))
+			}
+		}
+	}
+
+	v.ConcreteVisitor.VisitStatementBlock(node.Body)
+}
+
+// VisitEmitStatementNode maps the event designator to the event declared in the contract and visits the arguments
+func (v *designatorResolutionVisitor) VisitEmitStatementNode(node *node.EmitStatementNode) {
+	v.AbstractVisitor.VisitEmitStatementNode(node)
+
+	event := v.contractSymbol.GetEvent(node.Event.Value)
+	if event == nil {
+		v.reportError(node.Event, diagnostic.UndefinedDesignator, fmt.Sprintf("Event %s is undefined", node.Event.Value))
+		return
+	}
+	v.symbolTable.MapDesignatorToDecl(node.Event, event)
+}
+
+// VisitModifierInvocationNode maps the modifier designator to the modifier declared in the contract and visits the
+// arguments. The arguments are resolved in the scope of the modified function.
+func (v *designatorResolutionVisitor) VisitModifierInvocationNode(node *node.ModifierInvocationNode) {
+	v.AbstractVisitor.VisitModifierInvocationNode(node)
+
+	modifier := v.contractSymbol.GetModifier(node.Modifier.Value)
+	if modifier == nil {
+		v.reportError(node.Modifier, diagnostic.UndefinedDesignator, fmt.Sprintf("Modifier %s is undefined", node.Modifier.Value))
+		return
+	}
+	v.symbolTable.MapDesignatorToDecl(node.Modifier, modifier)
+}
+
 // VisitBasicDesignatorNode visits the designator node, maps the designator to its declaration and
 // maps the expression to the type
 func (v *designatorResolutionVisitor) VisitBasicDesignatorNode(node *node.BasicDesignatorNode) {
-	var scope symbol.Symbol
-	if v.currentFunctionSymbol == nil {
-		scope = v.contractSymbol
-	} else {
-		scope = v.currentFunctionSymbol
+	sym := v.symbolTable.Find(v.currentScope(), node.Value)
+	if _, ok := sym.(*symbol.NamespaceSymbol); ok {
+		v.reportError(node, diagnostic.InvalidMemberAccess, fmt.Sprintf("Built-in %s can only be used with member access", node.Value))
+		return
+	}
+	if reason, ok := unsupportedBuiltIns[node.Value]; ok && sym == nil {
+		v.reportError(node, diagnostic.UnsupportedBuiltIn, fmt.Sprintf("%s is not supported, since %s", node.Value, reason))
+		return
 	}
-	sym := v.symbolTable.Find(scope, node.Value)
 	if sym == nil || !isAllowedTarget(sym) && node.Value != symbol.This {
-		v.reportError(node, fmt.Sprintf("Designator %s is undefined", node.Value))
+		v.reportError(node, diagnostic.UndefinedDesignator, fmt.Sprintf("Designator %s is undefined", node.Value))
 		return
 	}
 
-	if local, ok := sym.(*symbol.LocalVariableSymbol); ok && !containsStatement(local.VisibleIn, v.currentStatement) {
-		v.reportError(node, fmt.Sprintf("Local Variable %s is not visible", node.Value))
-		return
+	if _, ok := sym.(*symbol.LocalVariableSymbol); ok {
+		visibleLocals := v.findVisibleLocalVariables(node.Value)
+		if len(visibleLocals) == 0 {
+			v.reportError(node, diagnostic.NotVisible, fmt.Sprintf("Local Variable %s is not visible", node.Value))
+			return
+		}
+		if len(visibleLocals) > 1 {
+			// The duplicate declaration is already reported in the symbol construction
+			v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.ErrorType)
+			return
+		}
+		sym = visibleLocals[0]
 	}
 
 	v.symbolTable.MapDesignatorToDecl(node, sym)
 	symType, err := getType(sym)
 	if err != nil {
-		v.reportError(node, err.Error())
+		v.reportError(node, diagnostic.Internal, err.Error())
 	} else {
 		v.symbolTable.MapExpressionToType(node, symType)
 	}
@@ -85,23 +164,38 @@ func (v *designatorResolutionVisitor) VisitBasicDesignatorNode(node *node.BasicD
 func (v *designatorResolutionVisitor) VisitElementAccessNode(node *node.ElementAccessNode) {
 	v.AbstractVisitor.VisitElementAccessNode(node)
 	typeSymbol := v.symbolTable.GetTypeByExpression(node.Designator)
-	if arrayType, ok := typeSymbol.(*symbol.ArrayTypeSymbol); ok {
+	if typeSymbol == v.symbolTable.GlobalScope.ErrorType {
+		v.symbolTable.MapExpressionToType(node, typeSymbol)
+	} else if arrayType, ok := typeSymbol.(*symbol.ArrayTypeSymbol); ok {
 		v.symbolTable.MapExpressionToType(node, arrayType.ElementType)
 		v.symbolTable.MapDesignatorToDecl(node, arrayType)
 	} else if mapType, ok := typeSymbol.(*symbol.MapTypeSymbol); ok {
 		v.symbolTable.MapExpressionToType(node, mapType.ValueType)
 		v.symbolTable.MapDesignatorToDecl(node, mapType)
 	} else {
-		v.reportError(node, fmt.Sprintf("Designator %v does not refer to an array/map type", node))
+		v.reportError(node, diagnostic.InvalidMemberAccess, fmt.Sprintf("Designator %v does not refer to an array/map type", node))
 	}
 }
 
 func (v *designatorResolutionVisitor) VisitMemberAccessNode(node *node.MemberAccessNode) {
+	if namespace := v.findNamespace(node.Designator); namespace != nil {
+		v.visitNamespaceMemberAccess(node, namespace)
+		return
+	}
+	if enumType := v.findEnum(node.Designator); enumType != nil {
+		v.visitEnumMemberAccess(node, enumType)
+		return
+	}
+
 	v.AbstractVisitor.VisitMemberAccessNode(node)
 	designatorType := v.symbolTable.GetTypeByExpression(node.Designator)
 
 	if node.Identifier == symbol.This {
-		v.reportError(node, "Invalid member designator 'this'")
+		v.reportError(node, diagnostic.InvalidMemberAccess, "Invalid member designator 'this'")
+		return
+	}
+	if designatorType == v.symbolTable.GlobalScope.ErrorType {
+		v.symbolTable.MapExpressionToType(node, designatorType)
 		return
 	}
 
@@ -115,20 +209,38 @@ func (v *designatorResolutionVisitor) VisitMemberAccessNode(node *node.MemberAcc
 	case *symbol.ContractSymbol:
 		v.visitContractMemberAccess(node, designatorType.(*symbol.ContractSymbol))
 	default:
-		v.reportError(node, fmt.Sprintf("Designator %v does not refer to a composite type", node))
+		if designatorType == v.symbolTable.GlobalScope.StringType {
+			v.visitStringMemberAccess(node)
+			return
+		}
+		v.reportError(node, diagnostic.InvalidMemberAccess, fmt.Sprintf("Designator %v does not refer to a composite type", node))
+	}
+}
+
+func (v *designatorResolutionVisitor) visitStringMemberAccess(node *node.MemberAccessNode) {
+	function, ok := v.symbolTable.GlobalScope.StringMemberFunctions[node.Identifier]
+	if !ok {
+		v.reportError(node, diagnostic.UndefinedMember, fmt.Sprintf("Invalid member access %v on string %v", node.Identifier, node))
+		return
 	}
+	v.symbolTable.MapDesignatorToDecl(node, function)
 }
 
 func (v *designatorResolutionVisitor) visitArrayMemberAccess(node *node.MemberAccessNode) {
+	if function, ok := v.symbolTable.GlobalScope.ArrayMemberFunctions[node.Identifier]; ok {
+		v.symbolTable.MapDesignatorToDecl(node, function)
+		return
+	}
+
 	arrayLength := v.symbolTable.GlobalScope.ArrayLengthField
 	if node.Identifier != arrayLength.Identifier() {
-		v.reportError(node, fmt.Sprintf("Invalid member access %v on array %v", node.Identifier, node))
+		v.reportError(node, diagnostic.UndefinedMember, fmt.Sprintf("Invalid member access %v on array %v", node.Identifier, node))
 		return
 	}
 
 	targetType, err := getType(arrayLength)
 	if err != nil {
-		v.reportError(node, err.Error())
+		v.reportError(node, diagnostic.Internal, err.Error())
 	}
 
 	v.symbolTable.MapDesignatorToDecl(node, arrayLength)
@@ -139,14 +251,14 @@ func (v *designatorResolutionVisitor) visitStructMemberAccess(node *node.MemberA
 	fieldSymbol := structType.GetField(node.Identifier)
 
 	if fieldSymbol == (*symbol.FieldSymbol)(nil) {
-		v.reportError(node, fmt.Sprintf("Member %s does not exist on struct %s",
+		v.reportError(node, diagnostic.UndefinedMember, fmt.Sprintf("Member %s does not exist on struct %s",
 			node.Identifier, structType.Identifier()))
 		return
 	}
 
 	targetType, err := getType(fieldSymbol)
 	if err != nil {
-		v.reportError(node, err.Error())
+		v.reportError(node, diagnostic.Internal, err.Error())
 	}
 
 	v.symbolTable.MapDesignatorToDecl(node, fieldSymbol)
@@ -155,17 +267,30 @@ func (v *designatorResolutionVisitor) visitStructMemberAccess(node *node.MemberA
 
 func (v *designatorResolutionVisitor) visitMapMemberAccess(node *node.MemberAccessNode) {
 	if node.Identifier != symbol.Contains {
-		v.reportError(node, fmt.Sprintf("Invalid member access %v on map %v", node.Identifier, node))
+		v.reportError(node, diagnostic.InvalidMemberAccess, fmt.Sprintf("Invalid member access %v on map %v", node.Identifier, node))
 		return
 	}
 	target := v.symbolTable.GlobalScope.MapMemberFunctions[symbol.Contains]
 	v.symbolTable.MapDesignatorToDecl(node, target)
 }
 
+// visitContractMemberAccess resolves fields and functions accessed through 'this'.
+// A function call through 'this' addresses the public interface of the contract,
+// therefore private and internal functions are not visible.
 func (v *designatorResolutionVisitor) visitContractMemberAccess(node *node.MemberAccessNode, contractType *symbol.ContractSymbol) {
+	if function := contractType.GetFunction(node.Identifier); function != nil {
+		if !function.IsPublic() {
+			v.reportError(node, diagnostic.NotVisible, fmt.Sprintf("Function %s is %s and cannot be called through 'this'",
+				node.Identifier, token.SymbolLexeme[function.Visibility]))
+			return
+		}
+		v.symbolTable.MapDesignatorToDecl(node, function)
+		return
+	}
+
 	targetIndex := contractType.GetFieldIndex(node.Identifier)
 	if targetIndex < 0 {
-		v.reportError(node, fmt.Sprintf("Member %s does not exist on contract %v", node.Identifier, contractType.Identifier()))
+		v.reportError(node, diagnostic.UndefinedMember, fmt.Sprintf("Member %s does not exist on contract %v", node.Identifier, contractType.Identifier()))
 		return
 	}
 
@@ -173,15 +298,103 @@ func (v *designatorResolutionVisitor) visitContractMemberAccess(node *node.Membe
 	targetType, err := getType(target)
 
 	if err != nil {
-		v.reportError(node, err.Error())
+		v.reportError(node, diagnostic.Internal, err.Error())
 	}
 
 	v.symbolTable.MapDesignatorToDecl(node, target)
 	v.symbolTable.MapExpressionToType(node, targetType)
 }
 
-func (v *designatorResolutionVisitor) reportError(node node.Node, msg string) {
-	v.Errors = append(v.Errors, fmt.Errorf("[%s] %s", node.Pos(), msg))
+func (v *designatorResolutionVisitor) visitNamespaceMemberAccess(node *node.MemberAccessNode, namespace *symbol.NamespaceSymbol) {
+	v.symbolTable.MapDesignatorToDecl(node.Designator, namespace)
+
+	fieldSymbol := namespace.GetField(node.Identifier)
+	if fieldSymbol == nil {
+		v.reportError(node, diagnostic.UndefinedMember, fmt.Sprintf("Member %s does not exist on %s",
+			node.Identifier, namespace.Identifier()))
+		return
+	}
+
+	// Functions, which are not payable, reject transactions with Bazo coins
+	if namespace.Identifier() == symbol.Msg && node.Identifier == "value" && !v.isPayable() {
+		v.reportError(node, diagnostic.InvalidMemberAccess, "msg.value can only be used in payable functions")
+		return
+	}
+
+	v.symbolTable.MapDesignatorToDecl(node, fieldSymbol)
+	v.symbolTable.MapExpressionToType(node, fieldSymbol.Type)
+}
+
+// visitEnumMemberAccess resolves the members of an enum type (e.g. State.Open)
+func (v *designatorResolutionVisitor) visitEnumMemberAccess(node *node.MemberAccessNode, enumType *symbol.EnumTypeSymbol) {
+	v.symbolTable.MapDesignatorToDecl(node.Designator, enumType)
+
+	member := enumType.GetMember(node.Identifier)
+	if member == nil {
+		v.reportError(node, diagnostic.UndefinedMember, fmt.Sprintf("Member %s does not exist on enum %s",
+			node.Identifier, enumType.Identifier()))
+		return
+	}
+
+	v.symbolTable.MapDesignatorToDecl(node, member)
+	v.symbolTable.MapExpressionToType(node, member.Type)
+}
+
+// isPayable checks whether the current function accepts Bazo coins.
+// Field initializers are executed by the constructor. Modifiers are inlined into payable and other functions.
+func (v *designatorResolutionVisitor) isPayable() bool {
+	function := v.currentFunctionSymbol
+	if function == nil {
+		function = v.contractSymbol.Constructor
+	}
+	return function != nil && (function.Payable || v.contractSymbol.IsModifier(function))
+}
+
+// findNamespace returns the built-in namespace, if the designator refers to it (e.g. msg in msg.sender)
+func (v *designatorResolutionVisitor) findNamespace(designator node.DesignatorNode) *symbol.NamespaceSymbol {
+	if basicDesignator, ok := designator.(*node.BasicDesignatorNode); ok {
+		namespace, _ := v.symbolTable.Find(v.currentScope(), basicDesignator.Value).(*symbol.NamespaceSymbol)
+		return namespace
+	}
+	return nil
+}
+
+// findEnum returns the enum type, if the designator refers to it (e.g. State in State.Open)
+func (v *designatorResolutionVisitor) findEnum(designator node.DesignatorNode) *symbol.EnumTypeSymbol {
+	if basicDesignator, ok := designator.(*node.BasicDesignatorNode); ok {
+		enumType, _ := v.symbolTable.Find(v.currentScope(), basicDesignator.Value).(*symbol.EnumTypeSymbol)
+		return enumType
+	}
+	return nil
+}
+
+func (v *designatorResolutionVisitor) currentScope() symbol.Symbol {
+	if v.currentFunctionSymbol == nil {
+		return v.contractSymbol
+	}
+	return v.currentFunctionSymbol
+}
+
+// findVisibleLocalVariables returns the local variables with the identifier, which are visible in the current statement.
+// Local variables with the same identifier are rejected as duplicate declarations in the symbol construction,
+// but the visible one is used to resolve the designator, so that its usages do not cause follow-up errors.
+func (v *designatorResolutionVisitor) findVisibleLocalVariables(identifier string) []*symbol.LocalVariableSymbol {
+	var locals []*symbol.LocalVariableSymbol
+	for _, local := range v.currentFunctionSymbol.LocalVariables {
+		if local.Identifier() == identifier && containsStatement(local.VisibleIn, v.currentStatement) {
+			locals = append(locals, local)
+		}
+	}
+	return locals
+}
+
+// reportError reports the error and sets the type of an erroneous designator to the error type,
+// so that the enclosing expressions do not report follow-up errors
+func (v *designatorResolutionVisitor) reportError(n node.Node, code diagnostic.Code, msg string) {
+	v.Errors = append(v.Errors, diagnostic.New(code, n.Pos(), n.End(), msg))
+	if expression, ok := n.(node.ExpressionNode); ok {
+		v.symbolTable.MapExpressionToType(expression, v.symbolTable.GlobalScope.ErrorType)
+	}
 }
 
 func containsStatement(list []node.StatementNode, element node.StatementNode) bool {
@@ -201,6 +414,8 @@ func getType(sym symbol.Symbol) (symbol.TypeSymbol, error) {
 		return sym.(*symbol.ParameterSymbol).Type, nil
 	case *symbol.LocalVariableSymbol:
 		return sym.(*symbol.LocalVariableSymbol).Type, nil
+	case *symbol.ConstantSymbol:
+		return sym.(*symbol.ConstantSymbol).Type, nil
 	case *symbol.FunctionSymbol:
 		// FuncCall expression type will be resolved in type checker
 		return nil, nil
@@ -213,7 +428,7 @@ func getType(sym symbol.Symbol) (symbol.TypeSymbol, error) {
 
 func isAllowedTarget(sym symbol.Symbol) bool {
 	switch sym.(type) {
-	case *symbol.ContractSymbol:
+	case *symbol.ContractSymbol, *symbol.EnumTypeSymbol:
 		return false
 	default:
 		return true
@@ -2,26 +2,40 @@ package symbol
 
 import "fmt"
 
-// GlobalScope encapsulates global information such as the contract, types, built-ins, constants a.s.o.
+// GlobalScope encapsulates global information such as the contract, interfaces, types, built-ins, constants a.s.o.
 // It is used to lookup global information
 type GlobalScope struct {
 	AbstractSymbol
 	Contract         *ContractSymbol
+	Interfaces       map[string]*InterfaceSymbol
 	Types            map[string]TypeSymbol
 	BuiltInTypes     []*BasicTypeSymbol
 	BuiltInFunctions []*FunctionSymbol
 	Constants        []*ConstantSymbol
+	Namespaces       []*NamespaceSymbol
 	Structs          map[string]*StructTypeSymbol
+	Enums            map[string]*EnumTypeSymbol
 
 	BoolType   *BasicTypeSymbol
 	CharType   *BasicTypeSymbol
 	StringType *BasicTypeSymbol
 	IntType    *BasicTypeSymbol
+	// AddressType is the type of accounts. Addresses can only be compared for equality.
+	AddressType *BasicTypeSymbol
+	// HashType and PublicKeyType are the types of the cryptographic built-in functions.
+	// Like addresses, they can only be compared for equality.
+	HashType      *BasicTypeSymbol
+	PublicKeyType *BasicTypeSymbol
+
+	// ErrorType is the type of declarations and expressions, whose type cannot be determined because of an error.
+	// Checks involving the error type are skipped, so that an error does not cause follow-up errors.
+	ErrorType *BasicTypeSymbol
 
 	ArrayLengthField *FieldSymbol
 
 	StringMemberFunctions map[string]*FunctionSymbol
 	MapMemberFunctions    map[string]*FunctionSymbol
+	ArrayMemberFunctions  map[string]*FunctionSymbol
 
 	TrueConstant  *ConstantSymbol
 	FalseConstant *ConstantSymbol
@@ -29,15 +43,19 @@ type GlobalScope struct {
 
 func newGlobalScope() *GlobalScope {
 	gs := &GlobalScope{}
+	gs.Interfaces = make(map[string]*InterfaceSymbol)
 	gs.Structs = make(map[string]*StructTypeSymbol)
+	gs.Enums = make(map[string]*EnumTypeSymbol)
 	gs.Types = make(map[string]TypeSymbol)
 
 	gs.StringMemberFunctions = make(map[string]*FunctionSymbol)
 	gs.MapMemberFunctions = make(map[string]*FunctionSymbol)
+	gs.ArrayMemberFunctions = make(map[string]*FunctionSymbol)
 	return gs
 }
 
-// AllDeclarations returns all declarations made within the global scope such as types, built-ins and constants
+// AllDeclarations returns all declarations made within the global scope such as types, built-ins, constants and
+// namespaces
 func (gs *GlobalScope) AllDeclarations() []Symbol {
 	var symbols []Symbol
 	for _, s := range gs.Types {
@@ -49,6 +67,9 @@ func (gs *GlobalScope) AllDeclarations() []Symbol {
 	for _, s := range gs.Constants {
 		symbols = append(symbols, s)
 	}
+	for _, s := range gs.Namespaces {
+		symbols = append(symbols, s)
+	}
 	return symbols
 }
 
@@ -5,12 +5,14 @@ import (
 	"github.com/bazo-blockchain/lazo/parser/node"
 )
 
-// SymbolTable maps symbols to nodes, designators to declarations, expressions to types and contains the global scope
+// SymbolTable maps symbols to nodes, designators to declarations, expressions to types and values
+// and contains the global scope
 type SymbolTable struct {
 	GlobalScope            *GlobalScope
 	symbolToNode           map[Symbol]node.Node
 	designatorDeclarations map[node.DesignatorNode]Symbol
 	expressionTypes        map[node.ExpressionNode]TypeSymbol
+	expressionValues       map[node.ExpressionNode]interface{}
 }
 
 // NewSymbolTable creates a new symbol table and initializes mappings
@@ -20,6 +22,7 @@ func NewSymbolTable() *SymbolTable {
 		symbolToNode:           make(map[Symbol]node.Node),
 		designatorDeclarations: make(map[node.DesignatorNode]Symbol),
 		expressionTypes:        make(map[node.ExpressionNode]TypeSymbol),
+		expressionValues:       make(map[node.ExpressionNode]interface{}),
 	}
 }
 
@@ -128,6 +131,17 @@ func (t *SymbolTable) GetTypeByExpression(expressionNode node.ExpressionNode) Ty
 	return t.expressionTypes[expressionNode]
 }
 
+// MapExpressionToValue maps a constant expression to its value, which is computed at compile time.
+// The value is a *big.Int, bool, rune, string or []byte (address) depending on the expression type.
+func (t *SymbolTable) MapExpressionToValue(expressionNode node.ExpressionNode, value interface{}) {
+	t.expressionValues[expressionNode] = value
+}
+
+// GetValueByExpression returns the compile-time value of the expression or nil, if the expression is not constant
+func (t *SymbolTable) GetValueByExpression(expressionNode node.ExpressionNode) interface{} {
+	return t.expressionValues[expressionNode]
+}
+
 // String creates a string representation for the symbol table
 func (t *SymbolTable) String() string {
 	return fmt.Sprintf("Global Scope: %s", t.GlobalScope)
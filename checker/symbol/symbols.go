@@ -3,15 +3,52 @@ package symbol
 
 import (
 	"fmt"
+	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 )
 
 // This is a constant for the 'this' keyword
 const This = "this"
 
-// Contains is an identifier for built-in map member function.
+// Contains is an identifier for built-in map and string member function.
 const Contains = "contains"
 
+// Identifiers of the built-in string member functions
+const (
+	Length    = "length"
+	At        = "at"
+	Substring = "substring"
+	Equals    = "equals"
+)
+
+// Identifiers of the built-in array member functions
+const (
+	Append = "append"
+	Insert = "insert"
+	Remove = "remove"
+)
+
+// Sha3 is an identifier for the built-in hash function.
+const Sha3 = "sha3"
+
+// CheckSig is an identifier for the built-in signature verification function.
+const CheckSig = "checkSig"
+
+// HashLength is the number of bytes of a sha3 hash
+const HashLength = 32
+
+// PublicKeyLength is the number of bytes of a public key
+const PublicKeyLength = 64
+
+// MaxPushLength is the maximum number of bytes of an integer or a string, which the VM can push as a value
+const MaxPushLength = 255
+
+// Msg is the namespace of the built-in transaction fields (e.g. msg.sender)
+const Msg = "msg"
+
+// ContractNamespace is the namespace of the built-in contract account fields (e.g. contract.owner)
+const ContractNamespace = "contract"
+
 // Symbol declares functions which symbols have to implement
 type Symbol interface {
 	Scope() Symbol
@@ -57,10 +94,16 @@ func (sym *AbstractSymbol) String() string {
 // Concrete Symbols
 //-----------------
 
-// ContractSymbol contains fields and functions
+// ContractSymbol contains constants, fields, events, modifiers and functions.
+// Modifiers are function symbols without return types. They are not part of the contract declarations,
+// since they can only be applied to functions.
 type ContractSymbol struct {
 	AbstractSymbol
+	Interfaces  []*InterfaceSymbol
+	Constants   []*ConstantSymbol
 	Fields      []*FieldSymbol
+	Events      []*EventSymbol
+	Modifiers   []*FunctionSymbol
 	Constructor *FunctionSymbol
 	Functions   []*FunctionSymbol
 }
@@ -72,9 +115,12 @@ func NewContractSymbol(scope Symbol, identifier string) *ContractSymbol {
 	}
 }
 
-// AllDeclarations returns all field and function declarations
+// AllDeclarations returns all constant, field and function declarations
 func (sym *ContractSymbol) AllDeclarations() []Symbol {
 	var symbols []Symbol
+	for _, s := range sym.Constants {
+		symbols = append(symbols, s)
+	}
 	for _, s := range sym.Fields {
 		symbols = append(symbols, s)
 	}
@@ -94,10 +140,85 @@ func (sym *ContractSymbol) GetFieldIndex(id string) int {
 	return -1
 }
 
+// GetFunction returns the function symbol by identifier
+func (sym *ContractSymbol) GetFunction(identifier string) *FunctionSymbol {
+	for _, f := range sym.Functions {
+		if f.Identifier() == identifier {
+			return f
+		}
+	}
+	return nil
+}
+
+// GetModifier returns the modifier symbol by identifier
+func (sym *ContractSymbol) GetModifier(identifier string) *FunctionSymbol {
+	for _, m := range sym.Modifiers {
+		if m.Identifier() == identifier {
+			return m
+		}
+	}
+	return nil
+}
+
+// IsModifier checks whether the function symbol is a modifier of the contract
+func (sym *ContractSymbol) IsModifier(function *FunctionSymbol) bool {
+	return function != nil && sym.GetModifier(function.Identifier()) == function
+}
+
+// GetEvent returns the event symbol by identifier
+func (sym *ContractSymbol) GetEvent(identifier string) *EventSymbol {
+	for _, e := range sym.Events {
+		if e.Identifier() == identifier {
+			return e
+		}
+	}
+	return nil
+}
+
 // String creates the string representation
 func (sym *ContractSymbol) String() string {
-	return fmt.Sprintf("Contract: %s, \nFields: %s, \nConstructor %s \nFunctions %s",
-		sym.ID, sym.Fields, sym.Constructor, sym.Functions)
+	return fmt.Sprintf("Contract: %s, \nFields: %s, \nEvents: %s, \nConstructor %s \nFunctions %s",
+		sym.ID, sym.Fields, sym.Events, sym.Constructor, sym.Functions)
+}
+
+//----------------
+
+// InterfaceSymbol contains the function signatures, which an implementing contract has to provide.
+// The function symbols have no local variables, since interface functions have no body.
+type InterfaceSymbol struct {
+	AbstractSymbol
+	Functions []*FunctionSymbol
+}
+
+// NewInterfaceSymbol creates a new InterfaceSymbol
+func NewInterfaceSymbol(scope Symbol, identifier string) *InterfaceSymbol {
+	return &InterfaceSymbol{
+		AbstractSymbol: NewAbstractSymbol(scope, identifier),
+	}
+}
+
+// AllDeclarations returns all function declarations
+func (sym *InterfaceSymbol) AllDeclarations() []Symbol {
+	symbols := make([]Symbol, len(sym.Functions))
+	for i, s := range sym.Functions {
+		symbols[i] = s
+	}
+	return symbols
+}
+
+// GetFunction returns the function symbol by identifier
+func (sym *InterfaceSymbol) GetFunction(identifier string) *FunctionSymbol {
+	for _, f := range sym.Functions {
+		if f.Identifier() == identifier {
+			return f
+		}
+	}
+	return nil
+}
+
+// String creates the string representation
+func (sym *InterfaceSymbol) String() string {
+	return fmt.Sprintf("Interface: %s, \nFunctions %s", sym.ID, sym.Functions)
 }
 
 //----------------
@@ -122,12 +243,45 @@ func (sym *FieldSymbol) String() string {
 
 //----------------
 
-// FunctionSymbol contains the return types, parameters and local variables of a function
+// EventSymbol contains the parameters of an event.
+// Events are not part of the contract declarations, since they can only be used in emit statements.
+type EventSymbol struct {
+	AbstractSymbol
+	Parameters []*ParameterSymbol
+}
+
+// NewEventSymbol creates a new EventSymbol
+func NewEventSymbol(scope Symbol, identifier string) *EventSymbol {
+	return &EventSymbol{
+		AbstractSymbol: NewAbstractSymbol(scope, identifier),
+	}
+}
+
+// AllDeclarations returns all parameter declarations
+func (sym *EventSymbol) AllDeclarations() []Symbol {
+	symbols := make([]Symbol, len(sym.Parameters))
+	for i, s := range sym.Parameters {
+		symbols[i] = s
+	}
+	return symbols
+}
+
+// String creates the string representation
+func (sym *EventSymbol) String() string {
+	return fmt.Sprintf("\n event %s(%s)", sym.ID, sym.Parameters)
+}
+
+//----------------
+
+// FunctionSymbol contains the return types, parameters, local variables, visibility of a function and
+// whether the function accepts Bazo coins
 type FunctionSymbol struct {
 	AbstractSymbol
 	ReturnTypes    []TypeSymbol
 	Parameters     []*ParameterSymbol
 	LocalVariables []*LocalVariableSymbol
+	Visibility     token.Symbol
+	Payable        bool
 }
 
 // NewFunctionSymbol creates a new FunctionSymbol
@@ -149,6 +303,11 @@ func (sym *FunctionSymbol) AllDeclarations() []Symbol {
 	return symbols
 }
 
+// IsPublic checks whether the function can be called by transactions
+func (sym *FunctionSymbol) IsPublic() bool {
+	return sym.Visibility == token.Public
+}
+
 // GetVarIndex returns the index of a variable
 func (sym *FunctionSymbol) GetVarIndex(id string) int {
 	for i, s := range sym.AllDeclarations() {
@@ -225,7 +384,9 @@ func (sym *VariableSymbol) String() string {
 
 //----------------
 
-// ConstantSymbol contains the type of the constant
+// ConstantSymbol contains the type of the constant.
+// Constants are built-in (e.g. true) or declared in the contract. Their values are computed at compile time,
+// therefore they do not occupy a contract variable.
 type ConstantSymbol struct {
 	AbstractSymbol
 	Type TypeSymbol
@@ -246,6 +407,45 @@ func (sym *ConstantSymbol) String() string {
 
 //----------------
 
+// NamespaceSymbol groups read-only built-in fields, which are provided by the VM (e.g. msg.sender)
+type NamespaceSymbol struct {
+	AbstractSymbol
+	Fields []*FieldSymbol
+}
+
+// NewNamespaceSymbol creates a new NamespaceSymbol
+func NewNamespaceSymbol(scope Symbol, identifier string) *NamespaceSymbol {
+	return &NamespaceSymbol{
+		AbstractSymbol: NewAbstractSymbol(scope, identifier),
+	}
+}
+
+// AllDeclarations returns all field declarations
+func (sym *NamespaceSymbol) AllDeclarations() []Symbol {
+	symbols := make([]Symbol, len(sym.Fields))
+	for i, s := range sym.Fields {
+		symbols[i] = s
+	}
+	return symbols
+}
+
+// GetField returns the field symbol by identifier
+func (sym *NamespaceSymbol) GetField(identifier string) *FieldSymbol {
+	for _, f := range sym.Fields {
+		if f.Identifier() == identifier {
+			return f
+		}
+	}
+	return nil
+}
+
+// String creates the string representation
+func (sym *NamespaceSymbol) String() string {
+	return fmt.Sprintf("Namespace: %s, \nFields: %s", sym.ID, sym.Fields)
+}
+
+//----------------
+
 // TypeSymbol declares functions which all type symbols should implement
 type TypeSymbol interface {
 	Symbol
@@ -342,6 +542,56 @@ func (sym *StructTypeSymbol) GetFieldIndex(identifier string) int {
 
 //----------------
 
+// EnumTypeSymbol represents an enum type. The members are constants of the enum type,
+// which are lowered to their index in the declaration.
+type EnumTypeSymbol struct {
+	AbstractSymbol
+	Members []*ConstantSymbol
+}
+
+// NewEnumTypeSymbol creates a new EnumTypeSymbol
+func NewEnumTypeSymbol(scope Symbol, identifier string) *EnumTypeSymbol {
+	return &EnumTypeSymbol{
+		AbstractSymbol: NewAbstractSymbol(scope, identifier),
+	}
+}
+
+// AllDeclarations returns all member declarations
+func (sym *EnumTypeSymbol) AllDeclarations() []Symbol {
+	symbols := make([]Symbol, len(sym.Members))
+	for i, s := range sym.Members {
+		symbols[i] = s
+	}
+	return symbols
+}
+
+// String creates the string representation
+func (sym *EnumTypeSymbol) String() string {
+	return fmt.Sprintf("Enum: %s, \nMembers: %s", sym.Identifier(), sym.Members)
+}
+
+// GetMember returns the member symbol by identifier
+func (sym *EnumTypeSymbol) GetMember(identifier string) *ConstantSymbol {
+	for _, m := range sym.Members {
+		if m.Identifier() == identifier {
+			return m
+		}
+	}
+	return nil
+}
+
+// GetMemberIndex returns the index of the member, which is its integer value
+func (sym *EnumTypeSymbol) GetMemberIndex(identifier string) int {
+	for i, m := range sym.Members {
+		if m.Identifier() == identifier {
+			return i
+		}
+	}
+	return -1
+}
+
+//----------------
+
 // MapTypeSymbol represents a map type consists of key and value type
 type MapTypeSymbol struct {
 	AbstractSymbol
@@ -58,6 +58,23 @@ func (v *localVariableVisitor) VisitIfStatementNode(node *node.IfStatementNode)
 	v.AbstractVisitor.VisitIfStatementNode(node)
 }
 
+// VisitForStatementNode adds the loop variable to a new block scope, which is only visible in the loop body
+func (v *localVariableVisitor) VisitForStatementNode(node *node.ForStatementNode) {
+	v.visitLoop(node.Variable, node.Body)
+}
+
+// VisitForEachStatementNode adds the loop variable to a new block scope, which is only visible in the loop body
+func (v *localVariableVisitor) VisitForEachStatementNode(node *node.ForEachStatementNode) {
+	v.visitLoop(node.Variable, node.Body)
+}
+
+func (v *localVariableVisitor) visitLoop(variable *node.VariableNode, body []node.StatementNode) {
+	v.blockScopes = append(v.blockScopes, []*symbol.LocalVariableSymbol{}) // add loop scope
+	v.VisitVariableNode(variable)
+	v.VisitStatementBlock(body)
+	v.blockScopes = v.blockScopes[:v.currentBlockIndex()] // remove loop scope
+}
+
 func (v *localVariableVisitor) recordVisiblity(stmt node.StatementNode) {
 	for _, scope := range v.blockScopes {
 		for _, localVariable := range scope {
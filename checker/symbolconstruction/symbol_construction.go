@@ -3,44 +3,74 @@ package symbolconstruction
 import (
 	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/diagnostic"
+	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 )
 
 type symbolConstruction struct {
-	programNode *node.ProgramNode
-	symbolTable *symbol.SymbolTable
-	globalScope *symbol.GlobalScope
-	errors      []error
+	programNode  *node.ProgramNode
+	contractNode *node.ContractNode
+	symbolTable  *symbol.SymbolTable
+	globalScope  *symbol.GlobalScope
+	errors       []error
 }
 
-// Run prepares global scope, creates symbols and checks identifiers
+// Run prepares global scope, creates symbols and checks identifiers.
+// The symbols are created for all interfaces of the program and the given contract.
+// The interfaces are only registered, their errors are reported once by RunInterfaces.
 // Returns errors that occurred during construction
-func Run(programNode *node.ProgramNode) (*symbol.SymbolTable, []error) {
+func Run(programNode *node.ProgramNode, contractNode *node.ContractNode) (*symbol.SymbolTable, []error) {
 	symTable := symbol.NewSymbolTable()
 	construction := symbolConstruction{
-		symbolTable: symTable,
-		programNode: programNode,
-		globalScope: symTable.GlobalScope,
+		symbolTable:  symTable,
+		programNode:  programNode,
+		contractNode: contractNode,
+		globalScope:  symTable.GlobalScope,
 	}
 
-	if programNode.Contract == nil {
-		construction.reportError(nil, "Program has no contract")
+	if contractNode == nil {
+		construction.reportError(nil, diagnostic.MissingContract, "Program has no contract")
 		return symTable, construction.errors
 	}
 
 	construction.registerBuiltins()
-	construction.registerDeclarations()
+	construction.registerInterfaces()
+	construction.registerContract()
 	construction.checkValidIdentifiers()
 	construction.checkUniqueIdentifiers()
 
 	return symTable, construction.errors
 }
 
+// RunInterfaces creates the symbols of the interfaces of the program without a contract and checks their identifiers.
+// Since the interfaces are shared by all contracts, they are checked once for the whole program.
+// Returns errors that occurred during construction
+func RunInterfaces(programNode *node.ProgramNode) (*symbol.SymbolTable, []error) {
+	symTable := symbol.NewSymbolTable()
+	construction := symbolConstruction{
+		symbolTable: symTable,
+		programNode: programNode,
+		globalScope: symTable.GlobalScope,
+	}
+
+	construction.registerBuiltins()
+	for _, duplicate := range construction.registerInterfaces() {
+		construction.reportError(duplicate, diagnostic.DuplicateDeclaration,
+			fmt.Sprintf("Interface '%s' is already declared", duplicate.Identifier()))
+	}
+	construction.checkInterfaceIdentifiers()
+
+	return symTable, construction.errors
+}
+
 func (sc *symbolConstruction) registerBuiltins() {
 	sc.registerBuiltInTypes()
 	sc.registerBuiltInConstants()
+	sc.registerBuiltInFunctions()
 	sc.registerBuiltInField()
 	sc.registerBuiltInMemberFunctions()
+	sc.registerBuiltInNamespaces()
 }
 
 func (sc *symbolConstruction) registerBuiltInTypes() {
@@ -48,6 +78,12 @@ func (sc *symbolConstruction) registerBuiltInTypes() {
 	sc.globalScope.CharType = sc.registerBuiltInType("char")
 	sc.globalScope.IntType = sc.registerBuiltInType("int")
 	sc.globalScope.StringType = sc.registerBuiltInType("String")
+	sc.globalScope.AddressType = sc.registerBuiltInType("address")
+	sc.globalScope.HashType = sc.registerBuiltInType("hash")
+	sc.globalScope.PublicKeyType = sc.registerBuiltInType("publicKey")
+
+	// The error type is not registered as type, since it cannot be used in the source code
+	sc.globalScope.ErrorType = symbol.NewBasicTypeSymbol(sc.globalScope, "<error>")
 }
 
 func (sc *symbolConstruction) registerBuiltInType(name string) *symbol.BasicTypeSymbol {
@@ -68,6 +104,34 @@ func (sc *symbolConstruction) registerBuiltInConstant(typeSymbol *symbol.BasicTy
 	return constant
 }
 
+// registerBuiltInFunctions registers the functions, which are provided by the VM opcodes.
+// sha3(String data) returns the hash of the data and checkSig(hash digest, publicKey key) verifies,
+// whether the transaction is signed by the key.
+func (sc *symbolConstruction) registerBuiltInFunctions() {
+	gs := sc.globalScope
+
+	sha3 := sc.registerBuiltInFunction(symbol.Sha3, gs.HashType)
+	sc.registerBuiltInParameter(sha3, "data", gs.StringType)
+
+	checkSig := sc.registerBuiltInFunction(symbol.CheckSig, gs.BoolType)
+	sc.registerBuiltInParameter(checkSig, "digest", gs.HashType)
+	sc.registerBuiltInParameter(checkSig, "key", gs.PublicKeyType)
+}
+
+func (sc *symbolConstruction) registerBuiltInFunction(name string, returnTypes ...symbol.TypeSymbol) *symbol.FunctionSymbol {
+	function := symbol.NewFunctionSymbol(sc.globalScope, name)
+	function.ReturnTypes = returnTypes
+	sc.globalScope.BuiltInFunctions = append(sc.globalScope.BuiltInFunctions, function)
+	return function
+}
+
+func (sc *symbolConstruction) registerBuiltInParameter(function *symbol.FunctionSymbol, name string,
+	paramType symbol.TypeSymbol) {
+	param := symbol.NewParameterSymbol(function, name)
+	param.Type = paramType
+	function.Parameters = append(function.Parameters, param)
+}
+
 func (sc *symbolConstruction) registerBuiltInField() {
 	arrayLength := &symbol.FieldSymbol{
 		AbstractSymbol: symbol.NewAbstractSymbol(sc.globalScope, "length"),
@@ -77,7 +141,36 @@ func (sc *symbolConstruction) registerBuiltInField() {
 }
 
 func (sc *symbolConstruction) registerBuiltInMemberFunctions() {
+	sc.registerStringMemberFunctions()
 	sc.registerMapMemberFunctions()
+	sc.registerArrayMemberFunctions()
+}
+
+// registerStringMemberFunctions registers length(), at(index), substring(start, end), equals(other) and contains(part).
+func (sc *symbolConstruction) registerStringMemberFunctions() {
+	gs := sc.globalScope
+
+	sc.registerStringMemberFunction(symbol.Length, gs.IntType)
+
+	atFunc := sc.registerStringMemberFunction(symbol.At, gs.CharType)
+	sc.registerBuiltInParameter(atFunc, "index", gs.IntType)
+
+	substringFunc := sc.registerStringMemberFunction(symbol.Substring, gs.StringType)
+	sc.registerBuiltInParameter(substringFunc, "start", gs.IntType)
+	sc.registerBuiltInParameter(substringFunc, "end", gs.IntType)
+
+	equalsFunc := sc.registerStringMemberFunction(symbol.Equals, gs.BoolType)
+	sc.registerBuiltInParameter(equalsFunc, "other", gs.StringType)
+
+	containsFunc := sc.registerStringMemberFunction(symbol.Contains, gs.BoolType)
+	sc.registerBuiltInParameter(containsFunc, "part", gs.StringType)
+}
+
+func (sc *symbolConstruction) registerStringMemberFunction(name string, returnType symbol.TypeSymbol) *symbol.FunctionSymbol {
+	function := symbol.NewFunctionSymbol(sc.globalScope, name)
+	function.ReturnTypes = append(function.ReturnTypes, returnType)
+	sc.globalScope.StringMemberFunctions[name] = function
+	return function
 }
 
 func (sc *symbolConstruction) registerMapMemberFunctions() {
@@ -91,18 +184,108 @@ func (sc *symbolConstruction) registerMapMemberFunctions() {
 	sc.globalScope.MapMemberFunctions[symbol.Contains] = containsFunc
 }
 
-func (sc *symbolConstruction) registerDeclarations() {
-	sc.registerContract()
-	// interfaces
+// registerArrayMemberFunctions registers append(element), insert(index, element) and remove(index).
+// The functions modify the array, on which they are called.
+func (sc *symbolConstruction) registerArrayMemberFunctions() {
+	intType := sc.globalScope.IntType
+
+	appendFunc := sc.registerArrayMemberFunction(symbol.Append)
+	sc.registerBuiltInParameter(appendFunc, "element", nil)
+
+	insertFunc := sc.registerArrayMemberFunction(symbol.Insert)
+	sc.registerBuiltInParameter(insertFunc, "index", intType)
+	sc.registerBuiltInParameter(insertFunc, "element", nil)
+
+	removeFunc := sc.registerArrayMemberFunction(symbol.Remove)
+	sc.registerBuiltInParameter(removeFunc, "index", intType)
+}
+
+// registerArrayMemberFunction registers a void array member function.
+// Element parameters are generic, therefore their type is nil and checked against the array element type.
+func (sc *symbolConstruction) registerArrayMemberFunction(name string) *symbol.FunctionSymbol {
+	function := symbol.NewFunctionSymbol(sc.globalScope, name)
+	sc.globalScope.ArrayMemberFunctions[name] = function
+	return function
+}
+
+// registerBuiltInNamespaces registers the read-only transaction and contract fields, which are provided by the VM.
+// The contract address has the type publicKey, since the VM provides the full 64 byte account address
+// instead of its 32 byte hash.
+func (sc *symbolConstruction) registerBuiltInNamespaces() {
+	intType := sc.globalScope.IntType
+	addressType := sc.globalScope.AddressType
+	publicKeyType := sc.globalScope.PublicKeyType
+
+	msg := sc.registerBuiltInNamespace(symbol.Msg)
+	sc.registerNamespaceField(msg, "sender", addressType)
+	sc.registerNamespaceField(msg, "value", intType)
+
+	contract := sc.registerBuiltInNamespace(symbol.ContractNamespace)
+	sc.registerNamespaceField(contract, "address", publicKeyType)
+	sc.registerNamespaceField(contract, "balance", intType)
+	sc.registerNamespaceField(contract, "owner", addressType)
+}
+
+func (sc *symbolConstruction) registerBuiltInNamespace(name string) *symbol.NamespaceSymbol {
+	namespace := symbol.NewNamespaceSymbol(sc.globalScope, name)
+	sc.globalScope.Namespaces = append(sc.globalScope.Namespaces, namespace)
+	return namespace
+}
+
+func (sc *symbolConstruction) registerNamespaceField(namespace *symbol.NamespaceSymbol, name string,
+	fieldType symbol.TypeSymbol) {
+	field := symbol.NewFieldSymbol(namespace, name)
+	field.Type = fieldType
+	namespace.Fields = append(namespace.Fields, field)
+}
+
+// registerInterfaces registers all interfaces of the program.
+// Returns the interfaces, which are not registered, since their name is already declared.
+func (sc *symbolConstruction) registerInterfaces() []*symbol.InterfaceSymbol {
+	var duplicates []*symbol.InterfaceSymbol
+	for _, interfaceNode := range sc.programNode.Interfaces {
+		if interfaceSymbol := sc.registerInterface(interfaceNode); interfaceSymbol != nil {
+			duplicates = append(duplicates, interfaceSymbol)
+		}
+	}
+	return duplicates
+}
+
+// registerInterface registers the interface and its function signatures. The types are set in the type resolution.
+// Returns the interface symbol, if the interface is already declared.
+func (sc *symbolConstruction) registerInterface(node *node.InterfaceNode) *symbol.InterfaceSymbol {
+	interfaceSymbol := symbol.NewInterfaceSymbol(sc.globalScope, node.Name)
+	sc.symbolTable.MapSymbolToNode(interfaceSymbol, node)
+
+	if _, ok := sc.globalScope.Interfaces[node.Name]; ok {
+		return interfaceSymbol
+	}
+	sc.globalScope.Interfaces[node.Name] = interfaceSymbol
+
+	for _, functionNode := range node.Functions {
+		functionSymbol := symbol.NewFunctionSymbol(interfaceSymbol, functionNode.Name)
+		functionSymbol.Visibility = functionNode.Visibility
+		interfaceSymbol.Functions = append(interfaceSymbol.Functions, functionSymbol)
+		sc.symbolTable.MapSymbolToNode(functionSymbol, functionNode)
+
+		for _, parameter := range functionNode.Parameters {
+			sc.registerParameter(functionSymbol, parameter)
+		}
+	}
+	return nil
 }
 
 func (sc *symbolConstruction) registerContract() {
-	contractNode := sc.programNode.Contract
+	contractNode := sc.contractNode
 
 	contractSymbol := symbol.NewContractSymbol(sc.globalScope, contractNode.Name)
 	sc.globalScope.Contract = contractSymbol
 	sc.symbolTable.MapSymbolToNode(contractSymbol, contractNode)
 
+	for _, constantNode := range contractNode.Constants {
+		sc.registerConstant(contractSymbol, constantNode)
+	}
+
 	for _, fieldNode := range contractNode.Fields {
 		sc.registerField(contractSymbol, fieldNode)
 	}
@@ -111,6 +294,18 @@ func (sc *symbolConstruction) registerContract() {
 		sc.registerStruct(contractSymbol, structNode)
 	}
 
+	for _, enumNode := range contractNode.Enums {
+		sc.registerEnum(contractSymbol, enumNode)
+	}
+
+	for _, eventNode := range contractNode.Events {
+		sc.registerEvent(contractSymbol, eventNode)
+	}
+
+	for _, modifierNode := range contractNode.Modifiers {
+		sc.registerModifier(contractSymbol, modifierNode)
+	}
+
 	if contractNode.Constructor != nil {
 		sc.registerConstructor(contractSymbol, contractNode.Constructor)
 	}
@@ -120,6 +315,13 @@ func (sc *symbolConstruction) registerContract() {
 	}
 }
 
+// registerConstant registers a named constant. The type is set in the type resolution.
+func (sc *symbolConstruction) registerConstant(contractSymbol *symbol.ContractSymbol, node *node.ConstantNode) {
+	constantSymbol := symbol.NewConstantSymbol(contractSymbol, node.Identifier, nil)
+	contractSymbol.Constants = append(contractSymbol.Constants, constantSymbol)
+	sc.symbolTable.MapSymbolToNode(constantSymbol, node)
+}
+
 func (sc *symbolConstruction) registerField(contractSymbol *symbol.ContractSymbol, node *node.FieldNode) {
 	fieldSymbol := symbol.NewFieldSymbol(contractSymbol, node.Identifier)
 	contractSymbol.Fields = append(contractSymbol.Fields, fieldSymbol)
@@ -131,7 +333,7 @@ func (sc *symbolConstruction) registerStruct(contractSymbol *symbol.ContractSymb
 	sc.symbolTable.MapSymbolToNode(structType, node)
 
 	if _, ok := sc.globalScope.Structs[node.Name]; ok {
-		sc.reportError(structType,
+		sc.reportError(structType, diagnostic.DuplicateDeclaration,
 			fmt.Sprintf("Struct '%s' is already declared", structType.Identifier()))
 		return
 	}
@@ -146,8 +348,72 @@ func (sc *symbolConstruction) registerStruct(contractSymbol *symbol.ContractSymb
 	}
 }
 
+// registerEnum registers the enum type and its members. The members are constants of the enum type.
+func (sc *symbolConstruction) registerEnum(contractSymbol *symbol.ContractSymbol, node *node.EnumNode) {
+	enumType := symbol.NewEnumTypeSymbol(contractSymbol, node.Name)
+	sc.symbolTable.MapSymbolToNode(enumType, node)
+
+	if _, ok := sc.globalScope.Types[node.Name]; ok {
+		sc.reportError(enumType, diagnostic.DuplicateDeclaration,
+			fmt.Sprintf("Type '%s' is already declared", enumType.Identifier()))
+		return
+	}
+
+	sc.globalScope.Enums[node.Name] = enumType
+	sc.globalScope.Types[node.Name] = enumType
+
+	// Variables of an enum type are initialized with the first member
+	if len(node.Members) == 0 {
+		sc.reportError(enumType, diagnostic.EmptyEnum, fmt.Sprintf("Enum '%s' must have at least one member", node.Name))
+	}
+
+	for _, memberNode := range node.Members {
+		memberSymbol := symbol.NewConstantSymbol(enumType, memberNode.Identifier, enumType)
+		enumType.Members = append(enumType.Members, memberSymbol)
+		sc.symbolTable.MapSymbolToNode(memberSymbol, memberNode)
+	}
+}
+
+func (sc *symbolConstruction) registerEvent(contractSymbol *symbol.ContractSymbol, node *node.EventNode) {
+	eventSymbol := symbol.NewEventSymbol(contractSymbol, node.Name)
+	sc.symbolTable.MapSymbolToNode(eventSymbol, node)
+
+	if contractSymbol.GetEvent(node.Name) != nil {
+		sc.reportError(eventSymbol, diagnostic.DuplicateDeclaration,
+			fmt.Sprintf("Event '%s' is already declared", eventSymbol.Identifier()))
+		return
+	}
+	contractSymbol.Events = append(contractSymbol.Events, eventSymbol)
+
+	for _, parameterNode := range node.Parameters {
+		parameterSymbol := symbol.NewParameterSymbol(eventSymbol, parameterNode.Identifier)
+		eventSymbol.Parameters = append(eventSymbol.Parameters, parameterSymbol)
+		sc.symbolTable.MapSymbolToNode(parameterSymbol, parameterNode)
+	}
+}
+
+func (sc *symbolConstruction) registerModifier(contractSymbol *symbol.ContractSymbol, node *node.ModifierNode) {
+	modifierSymbol := symbol.NewFunctionSymbol(contractSymbol, node.Name)
+	sc.symbolTable.MapSymbolToNode(modifierSymbol, node)
+
+	if contractSymbol.GetModifier(node.Name) != nil {
+		sc.reportError(modifierSymbol, diagnostic.DuplicateDeclaration,
+			fmt.Sprintf("Modifier '%s' is already declared", modifierSymbol.Identifier()))
+		return
+	}
+	contractSymbol.Modifiers = append(contractSymbol.Modifiers, modifierSymbol)
+
+	for _, parameter := range node.Parameters {
+		sc.registerParameter(modifierSymbol, parameter)
+	}
+
+	v := newLocalVariableVisitor(sc.symbolTable, modifierSymbol)
+	v.VisitStatementBlock(node.Body)
+}
+
 func (sc *symbolConstruction) registerConstructor(contractSymbol *symbol.ContractSymbol, node *node.ConstructorNode) {
 	constructor := symbol.NewFunctionSymbol(contractSymbol, "constructor")
+	constructor.Payable = node.Payable
 	contractSymbol.Constructor = constructor
 	sc.symbolTable.MapSymbolToNode(constructor, node)
 
@@ -161,9 +427,17 @@ func (sc *symbolConstruction) registerConstructor(contractSymbol *symbol.Contrac
 
 func (sc *symbolConstruction) registerFunction(contractSymbol *symbol.ContractSymbol, node *node.FunctionNode) {
 	functionSymbol := symbol.NewFunctionSymbol(contractSymbol, node.Name)
+	functionSymbol.Visibility = node.Visibility
+	functionSymbol.Payable = node.Payable
 	contractSymbol.Functions = append(contractSymbol.Functions, functionSymbol)
 	sc.symbolTable.MapSymbolToNode(functionSymbol, node)
 
+	// Only transactions can send Bazo coins to a function
+	if functionSymbol.Payable && !functionSymbol.IsPublic() {
+		sc.reportError(functionSymbol, diagnostic.InvalidModifier,
+			fmt.Sprintf("%s function %s cannot be payable", token.SymbolLexeme[node.Visibility], node.Name))
+	}
+
 	for _, parameter := range node.Parameters {
 		sc.registerParameter(functionSymbol, parameter)
 	}
@@ -178,9 +452,33 @@ func (sc *symbolConstruction) registerParameter(functionSymbol *symbol.FunctionS
 	sc.symbolTable.MapSymbolToNode(parameterSymbol, node)
 }
 
+// checkInterfaceIdentifiers checks the identifiers of the interfaces, their functions and parameters
+func (sc *symbolConstruction) checkInterfaceIdentifiers() {
+	for _, interfaceSymbol := range sc.globalScope.Interfaces {
+		sc.checkValidIdentifier(interfaceSymbol)
+		for _, function := range interfaceSymbol.Functions {
+			sc.checkValidIdentifier(function)
+			for _, param := range function.Parameters {
+				sc.checkValidIdentifier(param)
+			}
+		}
+	}
+
+	for _, interfaceSymbol := range sc.globalScope.Interfaces {
+		sc.checkUniqueIdentifier(interfaceSymbol)
+		for _, function := range interfaceSymbol.Functions {
+			sc.checkUniqueIdentifier(function)
+		}
+	}
+}
+
 func (sc *symbolConstruction) checkValidIdentifiers() {
 	contract := sc.globalScope.Contract
 	sc.checkValidIdentifier(contract)
+	for _, constant := range contract.Constants {
+		sc.checkValidIdentifier(constant)
+	}
+
 	for _, field := range contract.Fields {
 		sc.checkValidIdentifier(field)
 	}
@@ -192,6 +490,27 @@ func (sc *symbolConstruction) checkValidIdentifiers() {
 		}
 	}
 
+	for _, enumType := range sc.globalScope.Enums {
+		sc.checkValidIdentifier(enumType)
+		for _, member := range enumType.Members {
+			sc.checkValidIdentifier(member)
+		}
+	}
+
+	for _, event := range contract.Events {
+		sc.checkValidIdentifier(event)
+		for _, param := range event.Parameters {
+			sc.checkValidIdentifier(param)
+		}
+	}
+
+	for _, modifier := range contract.Modifiers {
+		sc.checkValidIdentifier(modifier)
+		for _, decl := range modifier.AllDeclarations() {
+			sc.checkValidIdentifier(decl)
+		}
+	}
+
 	if contract.Constructor != nil {
 		for _, decl := range contract.Constructor.AllDeclarations() {
 			sc.checkValidIdentifier(decl)
@@ -206,22 +525,29 @@ func (sc *symbolConstruction) checkValidIdentifiers() {
 	}
 }
 
-var reservedKeywords = []string{"char", "int", "bool", "string", "this", "null", "void"}
+var reservedKeywords = []string{"char", "int", "bool", "string", "address", "hash", "publicKey", "this", "null", "void"}
 
 func (sc *symbolConstruction) checkValidIdentifier(sym symbol.Symbol) {
 	for _, keyword := range reservedKeywords {
 		if sym.Identifier() == keyword {
-			sc.reportError(sym, fmt.Sprintf("Reserved keyword '%s' cannot be used as an identifier", keyword))
+			sc.reportError(sym, diagnostic.ReservedIdentifier, fmt.Sprintf("Reserved keyword '%s' cannot be used as an identifier", keyword))
 			return
 		}
 	}
 	for _, structType := range sc.globalScope.Structs {
 		if sym != structType && sym.Identifier() == structType.Identifier() {
-			sc.reportError(sym, fmt.Sprintf("Struct name %s cannot be used as an identifier",
+			sc.reportError(sym, diagnostic.ReservedIdentifier, fmt.Sprintf("Struct name %s cannot be used as an identifier",
 				structType.Identifier()))
 			return
 		}
 	}
+	for _, enumType := range sc.globalScope.Enums {
+		if sym != enumType && sym.Identifier() == enumType.Identifier() {
+			sc.reportError(sym, diagnostic.ReservedIdentifier, fmt.Sprintf("Enum name %s cannot be used as an identifier",
+				enumType.Identifier()))
+			return
+		}
+	}
 }
 
 func (sc *symbolConstruction) checkUniqueIdentifiers() {
@@ -232,6 +558,18 @@ func (sc *symbolConstruction) checkUniqueIdentifiers() {
 		sc.checkUniqueIdentifier(structType)
 	}
 
+	for _, enumType := range sc.globalScope.Enums {
+		sc.checkUniqueIdentifier(enumType)
+	}
+
+	for _, event := range sc.globalScope.Contract.Events {
+		sc.checkUniqueIdentifier(event)
+	}
+
+	for _, modifier := range sc.globalScope.Contract.Modifiers {
+		sc.checkUniqueIdentifier(modifier)
+	}
+
 	if sc.globalScope.Contract.Constructor != nil {
 		sc.checkUniqueIdentifier(sc.globalScope.Contract.Constructor)
 	}
@@ -246,7 +584,7 @@ func (sc *symbolConstruction) checkUniqueIdentifier(sym symbol.Symbol) {
 	for r, decl := range allDecl {
 		for c, otherDecl := range allDecl {
 			if c > r && decl.Identifier() == otherDecl.Identifier() {
-				sc.reportError(otherDecl,
+				sc.reportError(otherDecl, diagnostic.DuplicateDeclaration,
 					fmt.Sprintf("Identifier '%s' is already declared", otherDecl.Identifier()))
 				break
 			}
@@ -254,10 +592,11 @@ func (sc *symbolConstruction) checkUniqueIdentifier(sym symbol.Symbol) {
 	}
 }
 
-func (sc *symbolConstruction) reportError(sym symbol.Symbol, msg string) {
-	var pos string
+func (sc *symbolConstruction) reportError(sym symbol.Symbol, code diagnostic.Code, msg string) {
+	var start, end token.Position
 	if sym != nil {
-		pos = sc.symbolTable.GetNodeBySymbol(sym).Pos().String()
+		node := sc.symbolTable.GetNodeBySymbol(sym)
+		start, end = node.Pos(), node.End()
 	}
-	sc.errors = append(sc.errors, fmt.Errorf("[%s] %s", pos, msg))
+	sc.errors = append(sc.errors, diagnostic.New(code, start, end, msg))
 }
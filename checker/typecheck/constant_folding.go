@@ -0,0 +1,150 @@
+package typecheck
+
+import (
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/lexer/token"
+	"github.com/bazo-blockchain/lazo/parser/node"
+	"math/big"
+)
+
+// Constant expressions are computed at compile time, so that they cost no runtime computation.
+// An expression is constant, if it only consists of literals, named constants and the operators folded below.
+// Operations, which abort the transaction (e.g. division by zero), are not folded and still fail at runtime.
+// Results, which cannot be pushed by the VM (i.e. integers and strings over symbol.MaxPushLength bytes),
+// are not folded either and are computed at runtime instead.
+
+// maxFoldedBits is the maximum number of bits of a folded integer
+const maxFoldedBits = 8 * symbol.MaxPushLength
+
+// VisitBasicDesignatorNode maps a designator of a named constant to the value of the constant
+func (v *typeCheckVisitor) VisitBasicDesignatorNode(designator *node.BasicDesignatorNode) {
+	constant, ok := v.symbolTable.GetDeclByDesignator(designator).(*symbol.ConstantSymbol)
+	if !ok {
+		return
+	}
+
+	// Built-in constants are parsed as literals and have no declaration node
+	if constantNode, ok := v.symbolTable.GetNodeBySymbol(constant).(*node.ConstantNode); ok {
+		if value := v.symbolTable.GetValueByExpression(constantNode.Expression); value != nil {
+			v.symbolTable.MapExpressionToValue(designator, value)
+		}
+	}
+}
+
+// foldBinaryExpression computes the value of a binary expression with constant operands
+func (v *typeCheckVisitor) foldBinaryExpression(expr *node.BinaryExpressionNode) {
+	left := v.symbolTable.GetValueByExpression(expr.Left)
+	right := v.symbolTable.GetValueByExpression(expr.Right)
+
+	var value interface{}
+	switch l := left.(type) {
+	case *big.Int:
+		if r, ok := right.(*big.Int); ok {
+			value = foldIntOperation(expr.Operator, l, r)
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			value = foldBoolOperation(expr.Operator, l, r)
+		}
+	case string:
+		if r, ok := right.(string); ok && expr.Operator == token.Plus && len(l)+len(r) <= symbol.MaxPushLength {
+			value = l + r
+		}
+	}
+
+	if value != nil {
+		v.symbolTable.MapExpressionToValue(expr, value)
+	}
+}
+
+// foldUnaryExpression computes the value of a unary expression with a constant operand
+func (v *typeCheckVisitor) foldUnaryExpression(expr *node.UnaryExpressionNode) {
+	var value interface{}
+	switch operand := v.symbolTable.GetValueByExpression(expr.Expression).(type) {
+	case *big.Int:
+		if expr.Operator == token.Plus {
+			value = operand
+		} else if expr.Operator == token.Minus {
+			value = new(big.Int).Neg(operand)
+		}
+	case bool:
+		if expr.Operator == token.Not {
+			value = !operand
+		}
+	}
+
+	if value != nil {
+		v.symbolTable.MapExpressionToValue(expr, value)
+	}
+}
+
+// foldIntOperation returns the result of the operation or nil, if it cannot be folded or the result is too large
+func foldIntOperation(operator token.Symbol, left *big.Int, right *big.Int) interface{} {
+	value := foldIntOperationValue(operator, left, right)
+	if result, ok := value.(*big.Int); ok && result.BitLen() > maxFoldedBits {
+		return nil
+	}
+	return value
+}
+
+// foldIntOperationValue returns the result of the arithmetic or comparison operation or nil,
+// if it cannot be folded. Bitwise operations are not folded, since the VM applies them to its own integer
+// representation.
+func foldIntOperationValue(operator token.Symbol, left *big.Int, right *big.Int) interface{} {
+	result := new(big.Int)
+	switch operator {
+	case token.Plus:
+		return result.Add(left, right)
+	case token.Minus:
+		return result.Sub(left, right)
+	case token.Multiplication:
+		return result.Mul(left, right)
+	case token.Division:
+		if right.Sign() == 0 {
+			return nil
+		}
+		return result.Div(left, right)
+	case token.Modulo:
+		if right.Sign() == 0 {
+			return nil
+		}
+		return result.Mod(left, right)
+	case token.Exponent:
+		// A nonzero result has more than (left.BitLen() - 1) * right bits, which is checked before it is computed
+		minBits := new(big.Int).Mul(big.NewInt(int64(left.BitLen()-1)), right)
+		if right.Sign() < 0 || minBits.Cmp(big.NewInt(maxFoldedBits)) >= 0 {
+			return nil
+		}
+		return result.Exp(left, right, nil)
+	case token.Equal:
+		return left.Cmp(right) == 0
+	case token.Unequal:
+		return left.Cmp(right) != 0
+	case token.Less:
+		return left.Cmp(right) < 0
+	case token.LessEqual:
+		return left.Cmp(right) <= 0
+	case token.Greater:
+		return left.Cmp(right) > 0
+	case token.GreaterEqual:
+		return left.Cmp(right) >= 0
+	default:
+		return nil
+	}
+}
+
+// foldBoolOperation returns the result of the logic or equality operation or nil, if it cannot be folded
+func foldBoolOperation(operator token.Symbol, left bool, right bool) interface{} {
+	switch operator {
+	case token.And:
+		return left && right
+	case token.Or:
+		return left || right
+	case token.Equal:
+		return left == right
+	case token.Unequal:
+		return left != right
+	default:
+		return nil
+	}
+}
@@ -3,5 +3,6 @@
 // - Not Operator (!) can only be applied to boolean expressions
 // - Both sites of an assignment are of the same type
 // - If Condition always needs to be a boolean expression
+// - Implemented interface functions are public and have the same parameter and return types
 // - a.s.o.
 package typecheck
@@ -3,6 +3,7 @@ package typecheck
 import (
 	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 )
@@ -12,6 +13,7 @@ type typeCheckVisitor struct {
 	symbolTable     *symbol.SymbolTable
 	contractSymbol  *symbol.ContractSymbol
 	currentFunction *symbol.FunctionSymbol
+	loopVariables   []symbol.Symbol
 	Errors          []error
 }
 
@@ -24,12 +26,24 @@ func newTypeCheckVisitor(symbolTable *symbol.SymbolTable, contractSymbol *symbol
 	return v
 }
 
-// VisitContractNode visits the fields and functions of the contract
+// VisitContractNode visits the constants, fields, modifiers and functions of the contract.
+// The constants are visited first and in declaration order, so that their values are known when they are used.
 func (v *typeCheckVisitor) VisitContractNode(node *node.ContractNode) {
+	for _, constant := range node.Constants {
+		constant.Accept(v.ConcreteVisitor)
+	}
+
 	for _, variable := range node.Fields {
 		variable.Accept(v.ConcreteVisitor)
 	}
 
+	for _, modifier := range v.contractSymbol.Modifiers {
+		v.currentFunction = modifier
+		modifierNode := v.symbolTable.GetNodeBySymbol(modifier)
+		modifierNode.Accept(v)
+		v.currentFunction = nil
+	}
+
 	if node.Constructor != nil {
 		v.currentFunction = v.contractSymbol.Constructor
 		node.Constructor.Accept(v.ConcreteVisitor)
@@ -44,10 +58,63 @@ func (v *typeCheckVisitor) VisitContractNode(node *node.ContractNode) {
 	}
 }
 
+// VisitModifierNode checks whether the modifier body contains exactly one placeholder.
+// The placeholder is replaced by the function body, therefore it cannot be nested in other statements.
+func (v *typeCheckVisitor) VisitModifierNode(modifierNode *node.ModifierNode) {
+	totalPlaceholders := 0
+	for _, statement := range modifierNode.Body {
+		if _, ok := statement.(*node.PlaceholderStatementNode); ok {
+			totalPlaceholders++
+			if totalPlaceholders > 1 {
+				v.reportError(statement, diagnostic.InvalidPlaceholder, "Only one placeholder is allowed in a modifier")
+			}
+			continue
+		}
+		statement.Accept(v)
+	}
+
+	if totalPlaceholders == 0 {
+		v.reportError(modifierNode, diagnostic.InvalidPlaceholder,
+			fmt.Sprintf("Modifier %s must contain a placeholder '_'", modifierNode.Name))
+	}
+}
+
+// VisitModifierInvocationNode checks whether the arguments match the parameters of the modifier
+func (v *typeCheckVisitor) VisitModifierInvocationNode(node *node.ModifierInvocationNode) {
+	v.AbstractVisitor.VisitModifierInvocationNode(node)
+	modifier, ok := v.symbolTable.GetDeclByDesignator(node.Modifier).(*symbol.FunctionSymbol)
+	if !ok {
+		return
+	}
+	v.checkArguments(node, node.Args, modifier.Parameters)
+}
+
+// VisitConstantNode checks whether the constant type and value are of the same type
+// and whether the value can be computed at compile time
+func (v *typeCheckVisitor) VisitConstantNode(node *node.ConstantNode) {
+	v.AbstractVisitor.VisitConstantNode(node)
+	targetType := v.findType(node.Type)
+	if v.isErrorType(targetType) {
+		return
+	}
+
+	gs := v.symbolTable.GlobalScope
+	if !v.isAnyType(targetType, gs.IntType, gs.BoolType, gs.CharType, gs.StringType, gs.AddressType) {
+		v.reportError(node, diagnostic.InvalidConstant, fmt.Sprintf("Constants of type %s are not supported", targetType.Identifier()))
+		return
+	}
+
+	v.checkExpressionTypes(node.Expression, targetType)
+	if v.symbolTable.GetValueByExpression(node.Expression) == nil && !v.isErrorType(v.symbolTable.GetTypeByExpression(node.Expression)) {
+		v.reportError(node.Expression, diagnostic.InvalidConstant,
+			fmt.Sprintf("Value of constant %s cannot be computed at compile time", node.Identifier))
+	}
+}
+
 // VisitFieldNode checks whether the variable type and value are of the same type
 func (v *typeCheckVisitor) VisitFieldNode(node *node.FieldNode) {
 	v.AbstractVisitor.VisitFieldNode(node)
-	targetType := v.symbolTable.FindTypeByNode(node.Type)
+	targetType := v.findType(node.Type)
 
 	if node.Expression != nil {
 		v.checkExpressionTypes(node.Expression, targetType)
@@ -60,7 +127,7 @@ func (v *typeCheckVisitor) VisitFieldNode(node *node.FieldNode) {
 // VisitVariableNode checks whether the variable type and value are of the same type
 func (v *typeCheckVisitor) VisitVariableNode(node *node.VariableNode) {
 	v.AbstractVisitor.VisitVariableNode(node)
-	targetType := v.symbolTable.FindTypeByNode(node.Type)
+	targetType := v.findType(node.Type)
 
 	if node.Expression != nil {
 		v.checkExpressionTypes(node.Expression, targetType)
@@ -73,7 +140,7 @@ func (v *typeCheckVisitor) VisitMultiVariableNode(node *node.MultiVariableNode)
 	targetTypes := make([]symbol.TypeSymbol, len(node.Types))
 
 	for i, t := range node.Types {
-		targetTypes[i] = v.symbolTable.FindTypeByNode(t)
+		targetTypes[i] = v.findType(t)
 	}
 	v.checkExpressionTypes(node.FuncCall, targetTypes...)
 }
@@ -83,7 +150,13 @@ func (v *typeCheckVisitor) VisitReturnStatementNode(returnNode *node.ReturnState
 	v.AbstractVisitor.VisitReturnStatementNode(returnNode)
 
 	if v.contractSymbol.Constructor == v.currentFunction {
-		v.reportError(returnNode, "return is not allowed in constructor")
+		v.reportError(returnNode, diagnostic.InvalidReturn, "return is not allowed in constructor")
+		return
+	}
+
+	// The modifier statements are inlined into functions with different return types
+	if v.contractSymbol.IsModifier(v.currentFunction) {
+		v.reportError(returnNode, diagnostic.InvalidReturn, "return is not allowed in modifier")
 		return
 	}
 
@@ -99,7 +172,7 @@ func (v *typeCheckVisitor) VisitReturnStatementNode(returnNode *node.ReturnState
 	}
 
 	if len(returnSymbols) != len(returnNodeExpressions) {
-		v.reportError(returnNode,
+		v.reportError(returnNode, diagnostic.InvalidReturn,
 			fmt.Sprintf("Expected %d return values, given %d", len(returnSymbols), len(returnNodeExpressions)))
 		return
 	}
@@ -107,17 +180,17 @@ func (v *typeCheckVisitor) VisitReturnStatementNode(returnNode *node.ReturnState
 	if len(returnSymbols) > 0 {
 		for i, rtype := range returnSymbols {
 			if returnNodeExpressions[i].String() == symbol.This {
-				v.reportError(returnNode, "'this' cannot be returned")
+				v.reportError(returnNode, diagnostic.InvalidThis, "'this' cannot be returned")
 				return
 			}
 			nodeType := v.symbolTable.GetTypeByExpression(returnNodeExpressions[i])
-			if nodeType != rtype {
-				v.reportError(returnNode, fmt.Sprintf("Return type mismatch: expected %s, given %s",
+			if nodeType != rtype && !v.isErrorType(nodeType, rtype) {
+				v.reportError(returnNode, diagnostic.TypeMismatch, fmt.Sprintf("Return type mismatch: expected %s, given %s",
 					rtype.Identifier(), getTypeString(nodeType)))
 			}
 		}
 	} else if len(returnNodeExpressions) > 0 {
-		v.reportError(returnNode, "void method should not return expression")
+		v.reportError(returnNode, diagnostic.InvalidReturn, "void method should not return expression")
 	}
 }
 
@@ -126,20 +199,21 @@ func (v *typeCheckVisitor) VisitAssignmentStatementNode(node *node.AssignmentSta
 	v.AbstractVisitor.VisitAssignmentStatementNode(node)
 
 	if node.Left.String() == symbol.This {
-		v.reportError(node, "Assigning to 'this' is not allowed!")
+		v.reportError(node, diagnostic.InvalidThis, "Assigning to 'this' is not allowed!")
 		return
 	}
+	v.checkAssignable(node, node.Left)
 
 	if node.Right.String() == symbol.This {
-		v.reportError(node, "'this' cannot be assigned!")
+		v.reportError(node, diagnostic.InvalidThis, "'this' cannot be assigned!")
 		return
 	}
 
 	leftType := v.symbolTable.GetTypeByExpression(node.Left)
 	rightType := v.symbolTable.GetTypeByExpression(node.Right)
 
-	if leftType != rightType {
-		v.reportError(node,
+	if leftType != rightType && !v.isErrorType(leftType, rightType) {
+		v.reportError(node, diagnostic.TypeMismatch,
 			fmt.Sprintf("assignment of %s is not compatible with target %s",
 				getTypeString(rightType), getTypeString(leftType)))
 	}
@@ -152,8 +226,9 @@ func (v *typeCheckVisitor) VisitMultiAssignmentStatementNode(node *node.MultiAss
 	leftTypes := make([]symbol.TypeSymbol, len(node.Designators))
 	for i, designator := range node.Designators {
 		if designator.String() == symbol.This {
-			v.reportError(node, "Assigning to 'this' is not allowed!")
+			v.reportError(node, diagnostic.InvalidThis, "Assigning to 'this' is not allowed!")
 		}
+		v.checkAssignable(node, designator)
 		leftTypes[i] = v.symbolTable.GetTypeByExpression(designator)
 	}
 	v.checkExpressionTypes(node.FuncCall, leftTypes...)
@@ -162,7 +237,11 @@ func (v *typeCheckVisitor) VisitMultiAssignmentStatementNode(node *node.MultiAss
 func (v *typeCheckVisitor) VisitShorthandAssignmentNode(node *node.ShorthandAssignmentStatementNode) {
 	v.AbstractVisitor.VisitShorthandAssignmentNode(node)
 
+	v.checkAssignable(node, node.Designator)
 	designatorType := v.symbolTable.GetTypeByExpression(node.Designator)
+	if v.isErrorType(designatorType) {
+		return
+	}
 
 	// str += "hello"
 	if v.isString(designatorType) && node.Operator == token.Plus {
@@ -178,15 +257,80 @@ func (v *typeCheckVisitor) VisitShorthandAssignmentNode(node *node.ShorthandAssi
 // VisitIfStatementNode checks whether the condition is a boolean expression
 func (v *typeCheckVisitor) VisitIfStatementNode(node *node.IfStatementNode) {
 	v.AbstractVisitor.VisitIfStatementNode(node)
-	if !v.isBool(v.symbolTable.GetTypeByExpression(node.Condition)) {
-		v.reportError(node, "condition must return boolean")
+	conditionType := v.symbolTable.GetTypeByExpression(node.Condition)
+	if !v.isBool(conditionType) && !v.isErrorType(conditionType) {
+		v.reportError(node, diagnostic.InvalidCondition, "condition must return boolean")
+	}
+}
+
+// VisitForStatementNode checks whether the loop variable and the range bounds are integers
+func (v *typeCheckVisitor) VisitForStatementNode(node *node.ForStatementNode) {
+	node.From.Accept(v)
+	node.To.Accept(v)
+
+	intType := v.symbolTable.GlobalScope.IntType
+	if variableType := v.findType(node.Variable.Type); variableType != intType && !v.isErrorType(variableType) {
+		v.reportError(node.Variable, diagnostic.InvalidLoop, fmt.Sprintf("Loop variable %s must be of type int", node.Variable.Identifier))
+	}
+	v.checkType(node.From, intType)
+	v.checkType(node.To, intType)
+
+	v.visitLoopBody(node.Variable, node.Body)
+}
+
+// VisitForEachStatementNode checks whether the collection is an array and the loop variable has its element type
+func (v *typeCheckVisitor) VisitForEachStatementNode(node *node.ForEachStatementNode) {
+	node.Collection.Accept(v)
+
+	collectionType := v.symbolTable.GetTypeByExpression(node.Collection)
+	if arrayType, ok := collectionType.(*symbol.ArrayTypeSymbol); !ok {
+		// Without declared type, the designator resolution already reported that the loop variable type cannot be inferred
+		if node.Variable.Type != nil && !v.isErrorType(collectionType) {
+			v.reportError(node.Collection, diagnostic.InvalidLoop, fmt.Sprintf("foreach requires array type, given %s", getTypeString(collectionType)))
+		}
+	} else if node.Variable.Type != nil {
+		variableType := v.findType(node.Variable.Type)
+		if variableType != arrayType.ElementType && !v.isErrorType(variableType, arrayType.ElementType) {
+			v.reportError(node.Variable, diagnostic.TypeMismatch, fmt.Sprintf(typeErrorMsgTemplate, arrayType.ElementType, variableType))
+		}
+	}
+
+	v.visitLoopBody(node.Variable, node.Body)
+}
+
+// visitLoopBody visits the loop body, in which the loop variable is read-only.
+// Otherwise, the number of iterations could not be bounded.
+func (v *typeCheckVisitor) visitLoopBody(variable *node.VariableNode, body []node.StatementNode) {
+	loopVariable := v.symbolTable.Find(v.currentFunction, variable.Identifier)
+	v.loopVariables = append(v.loopVariables, loopVariable)
+	v.VisitStatementBlock(body)
+	v.loopVariables = v.loopVariables[:len(v.loopVariables)-1]
+}
+
+// checkAssignable reports assignments to loop variables and built-in fields, which are read-only
+func (v *typeCheckVisitor) checkAssignable(stmt node.StatementNode, designator node.DesignatorNode) {
+	decl := v.symbolTable.GetDeclByDesignator(designator)
+	for _, loopVariable := range v.loopVariables {
+		if decl == loopVariable {
+			v.reportError(stmt, diagnostic.InvalidLoop, fmt.Sprintf("Loop variable %s cannot be modified", designator))
+		}
+	}
+
+	if field, ok := decl.(*symbol.FieldSymbol); ok {
+		if _, isBuiltIn := field.Scope().(*symbol.NamespaceSymbol); isBuiltIn {
+			v.reportError(stmt, diagnostic.ReadOnlyAssignment, fmt.Sprintf("Built-in %s cannot be modified", designator))
+		}
+	}
+
+	if _, ok := decl.(*symbol.ConstantSymbol); ok {
+		v.reportError(stmt, diagnostic.ReadOnlyAssignment, fmt.Sprintf("Constant %s cannot be modified", designator))
 	}
 }
 
 func (v *typeCheckVisitor) VisitCallStatementNode(node *node.CallStatementNode) {
 	v.AbstractVisitor.VisitCallStatementNode(node)
-	if v.symbolTable.GetTypeByExpression(node.Call) != nil {
-		v.reportError(node, "function call as statement should be void")
+	if callType := v.symbolTable.GetTypeByExpression(node.Call); callType != nil && !v.isErrorType(callType) {
+		v.reportError(node, diagnostic.InvalidStatement, "function call as statement should be void")
 	}
 }
 
@@ -194,8 +338,69 @@ func (v *typeCheckVisitor) VisitDeleteStatementNode(node *node.DeleteStatementNo
 	v.AbstractVisitor.VisitDeleteStatementNode(node)
 
 	designatorType := v.symbolTable.GetTypeByExpression(node.Element.Designator)
-	if _, ok := designatorType.(*symbol.MapTypeSymbol); !ok {
-		v.reportError(node, "delete requires map type")
+	if _, ok := designatorType.(*symbol.MapTypeSymbol); !ok && !v.isErrorType(designatorType) {
+		v.reportError(node, diagnostic.InvalidStatement, "delete requires map type")
+	}
+}
+
+// VisitRequireStatementNode checks whether the condition is bool and the message is String
+func (v *typeCheckVisitor) VisitRequireStatementNode(node *node.RequireStatementNode) {
+	v.AbstractVisitor.VisitRequireStatementNode(node)
+	v.checkCondition(node.Condition)
+	v.checkExpressionTypes(node.Message, v.symbolTable.GlobalScope.StringType)
+}
+
+// VisitAssertStatementNode checks whether the condition is bool
+func (v *typeCheckVisitor) VisitAssertStatementNode(node *node.AssertStatementNode) {
+	v.AbstractVisitor.VisitAssertStatementNode(node)
+	v.checkCondition(node.Condition)
+}
+
+// VisitRevertStatementNode checks whether the message is String
+func (v *typeCheckVisitor) VisitRevertStatementNode(node *node.RevertStatementNode) {
+	v.AbstractVisitor.VisitRevertStatementNode(node)
+	v.checkExpressionTypes(node.Message, v.symbolTable.GlobalScope.StringType)
+}
+
+// VisitEmitStatementNode checks whether the arguments match the parameters of the event
+func (v *typeCheckVisitor) VisitEmitStatementNode(node *node.EmitStatementNode) {
+	v.AbstractVisitor.VisitEmitStatementNode(node)
+	event, ok := v.symbolTable.GetDeclByDesignator(node.Event).(*symbol.EventSymbol)
+	if !ok {
+		return
+	}
+
+	v.checkArguments(node, node.Args, event.Parameters)
+}
+
+// VisitPlaceholderStatementNode reports placeholders, which are nested in other statements or used outside of modifiers.
+// Valid placeholders are skipped by VisitModifierNode.
+func (v *typeCheckVisitor) VisitPlaceholderStatementNode(node *node.PlaceholderStatementNode) {
+	v.reportError(node, diagnostic.InvalidPlaceholder, "Placeholder '_' can only be used in the top level of a modifier body")
+}
+
+// checkArguments checks whether the arguments of an emit statement or a modifier invocation match the parameters
+func (v *typeCheckVisitor) checkArguments(n node.Node, args []node.ExpressionNode, params []*symbol.ParameterSymbol) {
+	totalParams := len(params)
+	totalArgs := len(args)
+	if totalParams != totalArgs {
+		v.reportError(n, diagnostic.ArgumentCount, fmt.Sprintf("expected %d args, got %d", totalParams, totalArgs))
+		return
+	}
+
+	for i, arg := range args {
+		if arg.String() == symbol.This {
+			v.reportError(arg, diagnostic.InvalidThis, "'this' cannot be used as an argument")
+			continue
+		}
+		v.checkType(arg, params[i].Type)
+	}
+}
+
+func (v *typeCheckVisitor) checkCondition(condition node.ExpressionNode) {
+	conditionType := v.symbolTable.GetTypeByExpression(condition)
+	if !v.isBool(conditionType) && !v.isErrorType(conditionType) {
+		v.reportError(condition, diagnostic.InvalidCondition, "condition must return boolean")
 	}
 }
 
@@ -206,14 +411,16 @@ func (v *typeCheckVisitor) VisitTernaryExpressionNode(node *node.TernaryExpressi
 	v.AbstractVisitor.VisitTernaryExpressionNode(node)
 
 	conditionType := v.symbolTable.GetTypeByExpression(node.Condition)
-	if !v.isBool(conditionType) {
-		v.reportError(node.Condition, "condition should be bool type")
+	if !v.isBool(conditionType) && !v.isErrorType(conditionType) {
+		v.reportError(node.Condition, diagnostic.InvalidCondition, "condition should be bool type")
 	}
 
 	trueExprType := v.symbolTable.GetTypeByExpression(node.Then)
 	falseExprType := v.symbolTable.GetTypeByExpression(node.Else)
-	if trueExprType != falseExprType {
-		v.reportError(node, "ternary expression should return same type")
+	if v.isErrorType(trueExprType, falseExprType) {
+		v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.ErrorType)
+	} else if trueExprType != falseExprType {
+		v.reportError(node, diagnostic.TypeMismatch, "ternary expression should return same type")
 	} else {
 		v.symbolTable.MapExpressionToType(node, trueExprType)
 	}
@@ -227,6 +434,10 @@ func (v *typeCheckVisitor) VisitBinaryExpressionNode(node *node.BinaryExpression
 	right := node.Right
 	leftType := v.symbolTable.GetTypeByExpression(left)
 	rightType := v.symbolTable.GetTypeByExpression(right)
+	if v.isErrorType(leftType, rightType) {
+		v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.ErrorType)
+		return
+	}
 
 	switch node.Operator {
 	case token.And, token.Or:
@@ -246,13 +457,14 @@ func (v *typeCheckVisitor) VisitBinaryExpressionNode(node *node.BinaryExpression
 	default:
 		panic(fmt.Sprintf("Illegal binary operator %s", token.SymbolLexeme[node.Operator]))
 	}
+	v.foldBinaryExpression(node)
 }
 
 // visitBinaryLogicalOperator checks && and || operators.
 func (v *typeCheckVisitor) visitBinaryLogicalOperator(node *node.BinaryExpressionNode,
 	leftType symbol.TypeSymbol, rightType symbol.TypeSymbol) {
 	if !v.isBool(leftType) || !v.isBool(rightType) {
-		v.reportError(node, "Logic operators can only be applied to bool types")
+		v.reportError(node, diagnostic.InvalidOperator, "Logic operators can only be applied to bool types")
 	}
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.BoolType)
 }
@@ -261,7 +473,7 @@ func (v *typeCheckVisitor) visitBinaryLogicalOperator(node *node.BinaryExpressio
 func (v *typeCheckVisitor) visitBinaryBitwiseLogicalOperator(node *node.BinaryExpressionNode,
 	leftType symbol.TypeSymbol, rightType symbol.TypeSymbol) {
 	if !v.isInt(leftType) || !v.isInt(rightType) {
-		v.reportError(node, "Bitwise logic operators can only be applied to int types")
+		v.reportError(node, diagnostic.InvalidOperator, "Bitwise logic operators can only be applied to int types")
 	}
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.IntType)
 }
@@ -274,7 +486,7 @@ func (v *typeCheckVisitor) visitBinaryPlusOperator(node *node.BinaryExpressionNo
 		return
 	}
 	if !v.isInt(leftType) || !v.isInt(rightType) {
-		v.reportError(node, "+ operator can only be applied to int/string types")
+		v.reportError(node, diagnostic.InvalidOperator, "+ operator can only be applied to int/string types")
 	}
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.IntType)
 }
@@ -283,7 +495,7 @@ func (v *typeCheckVisitor) visitBinaryPlusOperator(node *node.BinaryExpressionNo
 func (v *typeCheckVisitor) visitBinaryArithmeticOperator(node *node.BinaryExpressionNode,
 	leftType symbol.TypeSymbol, rightType symbol.TypeSymbol) {
 	if !v.isInt(leftType) || !v.isInt(rightType) {
-		v.reportError(node, "Arithmetic operators can only be applied to int types")
+		v.reportError(node, diagnostic.InvalidOperator, "Arithmetic operators can only be applied to int types")
 	}
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.IntType)
 }
@@ -292,7 +504,7 @@ func (v *typeCheckVisitor) visitBinaryArithmeticOperator(node *node.BinaryExpres
 func (v *typeCheckVisitor) visitBinaryEqualityComparisonOperator(node *node.BinaryExpressionNode,
 	leftType symbol.TypeSymbol, rightType symbol.TypeSymbol) {
 	if leftType != rightType {
-		v.reportError(node, fmt.Sprintf("Equality comparison should have the same type, given %s and %s",
+		v.reportError(node, diagnostic.TypeMismatch, fmt.Sprintf("Equality comparison should have the same type, given %s and %s",
 			leftType, rightType))
 	}
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.BoolType)
@@ -302,11 +514,15 @@ func (v *typeCheckVisitor) visitBinaryEqualityComparisonOperator(node *node.Bina
 func (v *typeCheckVisitor) visitBinaryRelationalComparisonOperator(node *node.BinaryExpressionNode,
 	leftType symbol.TypeSymbol, rightType symbol.TypeSymbol) {
 	if leftType != rightType {
-		v.reportError(node,
+		v.reportError(node, diagnostic.InvalidOperator,
 			fmt.Sprintf("Both sides of a compare operation need to have the same type, given %s and %s",
 				leftType, rightType))
+	} else if enumType, ok := leftType.(*symbol.EnumTypeSymbol); ok {
+		// Enum members have no order, although they are lowered to integers
+		v.reportError(node, diagnostic.InvalidOperator,
+			fmt.Sprintf("Enum %s can only be compared with == and !=", enumType.Identifier()))
 	} else if !(v.isInt(leftType) || v.isChar(leftType)) {
-		v.reportError(node, fmt.Sprintf("Relational comparison is not supported for %s", leftType))
+		v.reportError(node, diagnostic.InvalidOperator, fmt.Sprintf("Relational comparison is not supported for %s", leftType))
 	}
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.BoolType)
 }
@@ -315,7 +531,7 @@ func (v *typeCheckVisitor) visitBinaryRelationalComparisonOperator(node *node.Bi
 func (v *typeCheckVisitor) visitBinaryShiftOperator(node *node.BinaryExpressionNode,
 	leftType symbol.TypeSymbol, rightType symbol.TypeSymbol) {
 	if !v.isInt(leftType) || !v.isInt(rightType) {
-		v.reportError(node, "Bitwise shift operators can only be applied to int types")
+		v.reportError(node, diagnostic.InvalidOperator, "Bitwise shift operators can only be applied to int types")
 	}
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.IntType)
 }
@@ -326,26 +542,31 @@ func (v *typeCheckVisitor) VisitUnaryExpressionNode(node *node.UnaryExpressionNo
 	v.AbstractVisitor.VisitUnaryExpressionNode(node)
 	operand := node.Expression
 	operandType := v.symbolTable.GetTypeByExpression(operand)
+	if v.isErrorType(operandType) {
+		v.symbolTable.MapExpressionToType(node, operandType)
+		return
+	}
 
 	switch node.Operator {
 	case token.Plus, token.Minus:
 		if !v.isInt(operandType) {
-			v.reportError(node, "+ and - unary operators can only be applied to expressions of type int")
+			v.reportError(node, diagnostic.InvalidOperator, "+ and - unary operators can only be applied to expressions of type int")
 		}
 		v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.IntType)
 	case token.Not:
 		if !v.isBool(operandType) {
-			v.reportError(node, "! unary operator can only be applied to expressions of type bool")
+			v.reportError(node, diagnostic.InvalidOperator, "! unary operator can only be applied to expressions of type bool")
 		}
 		v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.BoolType)
 	case token.BitwiseNot:
 		if !v.isInt(operandType) {
-			v.reportError(node, "~ unary operator can only be applied to int type")
+			v.reportError(node, diagnostic.InvalidOperator, "~ unary operator can only be applied to int type")
 		}
 		v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.IntType)
 	default:
 		panic(fmt.Sprintf("Illegal unary operator %s", token.SymbolLexeme[node.Operator]))
 	}
+	v.foldUnaryExpression(node)
 }
 
 func (v *typeCheckVisitor) VisitTypeCastNode(typeCastNode *node.TypeCastNode) {
@@ -355,16 +576,30 @@ func (v *typeCheckVisitor) VisitTypeCastNode(typeCastNode *node.TypeCastNode) {
 	exprType := v.symbolTable.GetTypeByExpression(typeCastNode.Expression)
 
 	gs := v.symbolTable.GlobalScope
-	if v.isString(castType) {
-		if v.isAnyType(exprType, gs.IntType, gs.CharType, gs.BoolType, gs.StringType) {
-			v.symbolTable.MapExpressionToType(typeCastNode, gs.StringType)
-		} else {
-			v.reportError(typeCastNode, fmt.Sprintf("String type cast is not supported for %s", exprType))
-		}
+	if v.isErrorType(exprType) {
+		v.symbolTable.MapExpressionToType(typeCastNode, exprType)
 		return
 	}
 
-	v.reportError(typeCastNode, fmt.Sprintf("Unsupported type cast to %s", castType))
+	var supportedTypes []symbol.TypeSymbol
+	switch castType {
+	case gs.StringType:
+		supportedTypes = []symbol.TypeSymbol{gs.IntType, gs.CharType, gs.BoolType, gs.StringType}
+	case gs.IntType:
+		supportedTypes = []symbol.TypeSymbol{gs.IntType, gs.CharType, gs.BoolType, gs.StringType}
+	case gs.CharType:
+		supportedTypes = []symbol.TypeSymbol{gs.CharType, gs.IntType}
+	default:
+		v.reportError(typeCastNode, diagnostic.InvalidCast, fmt.Sprintf("Unsupported type cast to %s", castType))
+		return
+	}
+
+	if !v.isAnyType(exprType, supportedTypes...) {
+		v.reportError(typeCastNode, diagnostic.InvalidCast,
+			fmt.Sprintf("%s type cast is not supported for %s", castType.Identifier(), exprType))
+		return
+	}
+	v.symbolTable.MapExpressionToType(typeCastNode, castType)
 }
 
 // VisitFuncCallNode checks the types of passed arguments and declared return types.
@@ -372,8 +607,12 @@ func (v *typeCheckVisitor) VisitFuncCallNode(funcCallNode *node.FuncCallNode) {
 	v.AbstractVisitor.VisitFuncCallNode(funcCallNode)
 	funcSym, ok := v.symbolTable.GetDeclByDesignator(funcCallNode.Designator).(*symbol.FunctionSymbol)
 
+	if v.isErrorType(v.symbolTable.GetTypeByExpression(funcCallNode.Designator)) {
+		v.symbolTable.MapExpressionToType(funcCallNode, v.symbolTable.GlobalScope.ErrorType)
+		return
+	}
 	if !ok {
-		v.reportError(funcCallNode, fmt.Sprintf("%s is not a function", funcCallNode.Designator))
+		v.reportError(funcCallNode, diagnostic.NotAFunction, fmt.Sprintf("%s is not a function", funcCallNode.Designator))
 		return
 	}
 
@@ -387,7 +626,7 @@ func (v *typeCheckVisitor) VisitFuncCallNode(funcCallNode *node.FuncCallNode) {
 	totalParams := len(funcSym.Parameters)
 	totalArgs := len(funcCallNode.Args)
 	if totalParams != totalArgs {
-		v.reportError(funcCallNode, fmt.Sprintf("expected %d args, got %d", totalParams, totalArgs))
+		v.reportError(funcCallNode, diagnostic.ArgumentCount, fmt.Sprintf("expected %d args, got %d", totalParams, totalArgs))
 		return
 	}
 
@@ -399,9 +638,23 @@ func (v *typeCheckVisitor) VisitFuncCallNode(funcCallNode *node.FuncCallNode) {
 		return
 	}
 
+	// Check generic array element type
+	if funcSym == v.symbolTable.GlobalScope.ArrayMemberFunctions[funcSym.Identifier()] {
+		targetArray := funcCallNode.Designator.(*node.MemberAccessNode).Designator
+		targetArrayType := v.symbolTable.GetTypeByExpression(targetArray).(*symbol.ArrayTypeSymbol)
+		for i, param := range funcSym.Parameters {
+			if param.Type == nil {
+				v.checkType(funcCallNode.Args[i], targetArrayType.ElementType)
+			} else {
+				v.checkType(funcCallNode.Args[i], param.Type)
+			}
+		}
+		return
+	}
+
 	for i, arg := range funcCallNode.Args {
 		if arg.String() == symbol.This {
-			v.reportError(funcCallNode, "'this' cannot be used as an argument")
+			v.reportError(funcCallNode, diagnostic.InvalidThis, "'this' cannot be used as an argument")
 			return
 		}
 		v.checkType(arg, funcSym.Parameters[i].Type)
@@ -418,14 +671,15 @@ func (v *typeCheckVisitor) VisitArrayLengthCreationNode(node *node.ArrayLengthCr
 		typeSymbol = v.symbolTable.AddArrayType(node.ElementType)
 	}
 	if typeSymbol == nil {
-		v.reportError(node, "Invalid array type")
+		v.reportError(node, diagnostic.InvalidArray, "Invalid array type")
+	} else {
+		v.symbolTable.MapExpressionToType(node, typeSymbol)
 	}
 
-	v.symbolTable.MapExpressionToType(node, typeSymbol)
 	for i, length := range node.Lengths {
 		exprType := v.symbolTable.GetTypeByExpression(length)
-		if exprType != v.symbolTable.GlobalScope.IntType {
-			v.reportError(node.Lengths[i], "Only integer expressions are allowed as array length argument")
+		if exprType != v.symbolTable.GlobalScope.IntType && !v.isErrorType(exprType) {
+			v.reportError(node.Lengths[i], diagnostic.InvalidArray, "Only integer expressions are allowed as array length argument")
 		}
 	}
 }
@@ -434,7 +688,7 @@ func (v *typeCheckVisitor) VisitArrayLengthCreationNode(node *node.ArrayLengthCr
 func (v *typeCheckVisitor) VisitArrayValueCreationNode(node *node.ArrayValueCreationNode) {
 	typeSymbol := v.symbolTable.FindTypeByNode(node.Type)
 	if typeSymbol == nil {
-		v.reportError(node, "Invalid array type")
+		v.reportError(node, diagnostic.InvalidArray, "Invalid array type")
 		return
 	}
 	v.symbolTable.MapExpressionToType(node, typeSymbol)
@@ -469,12 +723,13 @@ func (v *typeCheckVisitor) VisitElementAccessNode(node *node.ElementAccessNode)
 
 	designatorType := v.symbolTable.GetTypeByExpression(node.Designator)
 	if _, ok := designatorType.(*symbol.ArrayTypeSymbol); ok {
-		if v.symbolTable.GetTypeByExpression(node.Expression) != v.symbolTable.GlobalScope.IntType {
-			v.reportError(node, "Array index must be of type int")
+		indexType := v.symbolTable.GetTypeByExpression(node.Expression)
+		if indexType != v.symbolTable.GlobalScope.IntType && !v.isErrorType(indexType) {
+			v.reportError(node, diagnostic.InvalidArray, "Array index must be of type int")
 		}
 	} else if mapType, ok := designatorType.(*symbol.MapTypeSymbol); ok {
 		v.checkType(node.Expression, mapType.KeyType)
-	} else {
+	} else if !v.isErrorType(designatorType, v.symbolTable.GetTypeByExpression(node)) {
 		panic("Unsupported element access designator")
 	}
 }
@@ -485,13 +740,13 @@ func (v *typeCheckVisitor) VisitStructCreationNode(node *node.StructCreationNode
 
 	structType, ok := v.symbolTable.GlobalScope.Structs[node.Name]
 	if !ok {
-		v.reportError(node, fmt.Sprintf("Struct %s is undefined", node.Name))
+		v.reportError(node, diagnostic.InvalidStructCreation, fmt.Sprintf("Struct %s is undefined", node.Name))
 		return
 	}
 	v.symbolTable.MapExpressionToType(node, structType)
 
 	if len(node.FieldValues) > len(structType.Fields) {
-		v.reportError(node, fmt.Sprintf("Struct %s has only %d field(s), got %d value(s)",
+		v.reportError(node, diagnostic.InvalidStructCreation, fmt.Sprintf("Struct %s has only %d field(s), got %d value(s)",
 			node.Name, len(node.FieldValues), len(structType.Fields)))
 		return
 	}
@@ -499,8 +754,8 @@ func (v *typeCheckVisitor) VisitStructCreationNode(node *node.StructCreationNode
 	for i, fieldValue := range node.FieldValues {
 		exprType := v.symbolTable.GetTypeByExpression(fieldValue)
 		expectedType := structType.Fields[i].Type
-		if exprType != expectedType {
-			v.reportError(fieldValue, fmt.Sprintf(typeErrorMsgTemplate, expectedType, exprType))
+		if exprType != expectedType && !v.isErrorType(exprType, expectedType) {
+			v.reportError(fieldValue, diagnostic.TypeMismatch, fmt.Sprintf(typeErrorMsgTemplate, expectedType, exprType))
 		}
 	}
 }
@@ -510,13 +765,13 @@ func (v *typeCheckVisitor) VisitStructNamedCreationNode(node *node.StructNamedCr
 
 	structType, ok := v.symbolTable.GlobalScope.Structs[node.Name]
 	if !ok {
-		v.reportError(node, fmt.Sprintf("Struct %s is undefined", node.Name))
+		v.reportError(node, diagnostic.InvalidStructCreation, fmt.Sprintf("Struct %s is undefined", node.Name))
 		return
 	}
 	v.symbolTable.MapExpressionToType(node, structType)
 
 	if len(node.FieldValues) > len(structType.Fields) {
-		v.reportError(node, fmt.Sprintf("Struct %s has only %d field(s), got %d value(s)",
+		v.reportError(node, diagnostic.InvalidStructCreation, fmt.Sprintf("Struct %s has only %d field(s), got %d value(s)",
 			node.Name, len(node.FieldValues), len(structType.Fields)))
 		return
 	}
@@ -525,9 +780,9 @@ func (v *typeCheckVisitor) VisitStructNamedCreationNode(node *node.StructNamedCr
 		exprType := v.symbolTable.GetTypeByExpression(fieldValue)
 		fieldSymbol := structType.GetField(fieldValue.Name)
 		if fieldSymbol == nil {
-			v.reportError(fieldValue, fmt.Sprintf("Field %s not found", fieldValue.Name))
-		} else if exprType != fieldSymbol.Type {
-			v.reportError(fieldValue, fmt.Sprintf(typeErrorMsgTemplate, fieldSymbol.Type, exprType))
+			v.reportError(fieldValue, diagnostic.InvalidStructCreation, fmt.Sprintf("Field %s not found", fieldValue.Name))
+		} else if exprType != fieldSymbol.Type && !v.isErrorType(exprType, fieldSymbol.Type) {
+			v.reportError(fieldValue, diagnostic.TypeMismatch, fmt.Sprintf(typeErrorMsgTemplate, fieldSymbol.Type, exprType))
 		}
 	}
 }
@@ -539,24 +794,34 @@ func (v *typeCheckVisitor) VisitStructFieldAssignmentNode(node *node.StructField
 	v.symbolTable.MapExpressionToType(node, exprType)
 }
 
-// VisitIntegerLiteralNode maps the integer literal node to its type
+// VisitIntegerLiteralNode maps the integer literal node to its type and value
 func (v *typeCheckVisitor) VisitIntegerLiteralNode(node *node.IntegerLiteralNode) {
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.IntType)
+	v.symbolTable.MapExpressionToValue(node, node.Value)
 }
 
-// VisitBoolLiteralNode maps the bool literal node to its type
+// VisitAddressLiteralNode maps the address literal node to its type and value
+func (v *typeCheckVisitor) VisitAddressLiteralNode(node *node.AddressLiteralNode) {
+	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.AddressType)
+	v.symbolTable.MapExpressionToValue(node, node.Value)
+}
+
+// VisitBoolLiteralNode maps the bool literal node to its type and value
 func (v *typeCheckVisitor) VisitBoolLiteralNode(node *node.BoolLiteralNode) {
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.BoolType)
+	v.symbolTable.MapExpressionToValue(node, node.Value)
 }
 
-// VisitStringLiteralNode maps the string literal to its type
+// VisitStringLiteralNode maps the string literal to its type and value
 func (v *typeCheckVisitor) VisitStringLiteralNode(node *node.StringLiteralNode) {
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.StringType)
+	v.symbolTable.MapExpressionToValue(node, node.Value)
 }
 
-// VisitCharacterLiteralNode maps the character literal to its type
+// VisitCharacterLiteralNode maps the character literal to its type and value
 func (v *typeCheckVisitor) VisitCharacterLiteralNode(node *node.CharacterLiteralNode) {
 	v.symbolTable.MapExpressionToType(node, v.symbolTable.GlobalScope.CharType)
+	v.symbolTable.MapExpressionToValue(node, node.Value)
 }
 
 // Helper Functions
@@ -589,8 +854,8 @@ func (v *typeCheckVisitor) isAnyType(symbol symbol.TypeSymbol, expectedTypes ...
 
 func (v *typeCheckVisitor) checkType(expr node.ExpressionNode, expectedType symbol.TypeSymbol) {
 	actualType := v.symbolTable.GetTypeByExpression(expr)
-	if expectedType != actualType {
-		v.reportError(expr, fmt.Sprintf(typeErrorMsgTemplate, expectedType, actualType))
+	if expectedType != actualType && !v.isErrorType(expectedType, actualType) {
+		v.reportError(expr, diagnostic.TypeMismatch, fmt.Sprintf(typeErrorMsgTemplate, expectedType, actualType))
 	}
 }
 
@@ -599,21 +864,24 @@ func (v *typeCheckVisitor) checkExpressionTypes(expr node.ExpressionNode, expect
 	if fc, ok := expr.(*node.FuncCallNode); ok {
 		calledFuncSym, ok := v.symbolTable.GetDeclByDesignator(fc.Designator).(*symbol.FunctionSymbol)
 
+		if v.isErrorType(v.symbolTable.GetTypeByExpression(fc)) {
+			return
+		}
 		if !ok {
-			v.reportError(fc, fmt.Sprintf("%s is not a function", fc.Designator))
+			v.reportError(fc, diagnostic.NotAFunction, fmt.Sprintf("%s is not a function", fc.Designator))
 			return
 		}
 
 		if len(calledFuncSym.ReturnTypes) != len(expectedTypes) {
-			v.reportError(expr,
+			v.reportError(expr, diagnostic.ArgumentCount,
 				fmt.Sprintf("expected %d return value(s), but function returns %d",
 					len(expectedTypes), len(calledFuncSym.ReturnTypes)))
 			return
 		}
 
 		for i, returnType := range calledFuncSym.ReturnTypes {
-			if expectedTypes[i] != returnType {
-				v.reportError(expr, fmt.Sprintf("Return type mismatch: expected %s, given %s",
+			if expectedTypes[i] != returnType && !v.isErrorType(expectedTypes[i], returnType) {
+				v.reportError(expr, diagnostic.TypeMismatch, fmt.Sprintf("Return type mismatch: expected %s, given %s",
 					returnType.Identifier(), expectedTypes[i].Identifier()))
 			}
 		}
@@ -621,21 +889,45 @@ func (v *typeCheckVisitor) checkExpressionTypes(expr node.ExpressionNode, expect
 	}
 
 	if len(expectedTypes) > 1 {
-		v.reportError(expr, "only single type is allowed")
+		v.reportError(expr, diagnostic.TypeMismatch, "only single type is allowed")
 		return
 	}
 
 	exprType := v.symbolTable.GetTypeByExpression(expr)
-	if exprType != expectedTypes[0] {
-		v.reportError(expr, fmt.Sprintf("Type mismatch: expected %s, given %s",
+	if exprType != expectedTypes[0] && !v.isErrorType(exprType, expectedTypes[0]) {
+		v.reportError(expr, diagnostic.TypeMismatch, fmt.Sprintf("Type mismatch: expected %s, given %s",
 			expectedTypes[0].Identifier(), getTypeString(exprType)))
 	}
 }
 
 const typeErrorMsgTemplate = "expected %s, got %s"
 
-func (v *typeCheckVisitor) reportError(node node.Node, msg string) {
-	v.Errors = append(v.Errors, fmt.Errorf("[%s] %s", node.Pos(), msg))
+// isErrorType returns true, if one of the types is the error type of a previously reported error
+func (v *typeCheckVisitor) isErrorType(types ...symbol.TypeSymbol) bool {
+	for _, t := range types {
+		if t == v.symbolTable.GlobalScope.ErrorType {
+			return true
+		}
+	}
+	return false
+}
+
+// findType returns the type of a declaration, which has already been resolved in the type resolution.
+// Invalid types have been reported there and result in the error type.
+func (v *typeCheckVisitor) findType(typeNode node.TypeNode) symbol.TypeSymbol {
+	if typeSymbol := v.symbolTable.FindTypeByNode(typeNode); typeSymbol != nil {
+		return typeSymbol
+	}
+	return v.symbolTable.GlobalScope.ErrorType
+}
+
+// reportError reports the error and sets the type of an erroneous expression to the error type,
+// so that the enclosing expressions do not report follow-up errors
+func (v *typeCheckVisitor) reportError(n node.Node, code diagnostic.Code, msg string) {
+	v.Errors = append(v.Errors, diagnostic.New(code, n.Pos(), n.End(), msg))
+	if expression, ok := n.(node.ExpressionNode); ok {
+		v.symbolTable.MapExpressionToType(expression, v.symbolTable.GlobalScope.ErrorType)
+	}
 }
 
 func getTypeString(t symbol.TypeSymbol) string {
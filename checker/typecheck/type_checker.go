@@ -1,7 +1,9 @@
 package typecheck
 
 import (
+	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/parser/node"
 )
 
@@ -17,6 +19,7 @@ func Run(symTable *symbol.SymbolTable) []error {
 		symTable: symTable,
 	}
 	check.checkTypes()
+	check.checkImplementedInterfaces()
 	return check.errors
 }
 
@@ -28,3 +31,70 @@ func (tc *typeChecker) checkTypes() {
 	contractNode.Accept(v)
 	tc.errors = v.Errors
 }
+
+// checkImplementedInterfaces checks whether the contract provides a public function with the same parameter and
+// return types for every function of its interfaces. Undefined interfaces are already reported in the type resolution.
+func (tc *typeChecker) checkImplementedInterfaces() {
+	contractSymbol := tc.symTable.GlobalScope.Contract
+	contractNode := tc.symTable.GetNodeBySymbol(contractSymbol).(*node.ContractNode)
+
+	for _, interfaceNode := range contractNode.Implements {
+		interfaceSymbol, ok := tc.symTable.GlobalScope.Interfaces[interfaceNode.Identifier]
+		if !ok {
+			continue
+		}
+
+		for _, expected := range interfaceSymbol.Functions {
+			function := contractSymbol.GetFunction(expected.Identifier())
+			if function == nil {
+				tc.reportError(interfaceNode, diagnostic.InterfaceMismatch,
+					fmt.Sprintf("Contract %s does not implement function %s of interface %s",
+						contractSymbol.Identifier(), expected.Identifier(), interfaceSymbol.Identifier()))
+				continue
+			}
+
+			functionNode := tc.symTable.GetNodeBySymbol(function)
+			if !function.IsPublic() {
+				tc.reportError(functionNode, diagnostic.InterfaceMismatch,
+					fmt.Sprintf("Function %s must be public to implement interface %s",
+						function.Identifier(), interfaceSymbol.Identifier()))
+			}
+			if !tc.hasSameTypes(function, expected) {
+				tc.reportError(functionNode, diagnostic.InterfaceMismatch,
+					fmt.Sprintf("Function %s does not match the signature in interface %s",
+						function.Identifier(), interfaceSymbol.Identifier()))
+			}
+		}
+	}
+}
+
+// hasSameTypes compares the parameter and return types of the functions.
+// Functions with an erroneous type are considered as equal, so that the type error does not cause a follow-up error.
+func (tc *typeChecker) hasSameTypes(function *symbol.FunctionSymbol, expected *symbol.FunctionSymbol) bool {
+	if len(function.Parameters) != len(expected.Parameters) || len(function.ReturnTypes) != len(expected.ReturnTypes) {
+		return false
+	}
+
+	var types, expectedTypes []symbol.TypeSymbol
+	for i, param := range function.Parameters {
+		types = append(types, param.Type)
+		expectedTypes = append(expectedTypes, expected.Parameters[i].Type)
+	}
+	types = append(types, function.ReturnTypes...)
+	expectedTypes = append(expectedTypes, expected.ReturnTypes...)
+
+	errorType := tc.symTable.GlobalScope.ErrorType
+	for i, t := range types {
+		if t == errorType || expectedTypes[i] == errorType {
+			continue
+		}
+		if t.Identifier() != expectedTypes[i].Identifier() {
+			return false
+		}
+	}
+	return true
+}
+
+func (tc *typeChecker) reportError(n node.Node, code diagnostic.Code, msg string) {
+	tc.errors = append(tc.errors, diagnostic.New(code, n.Pos(), n.End(), msg))
+}
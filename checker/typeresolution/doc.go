@@ -1,4 +1,4 @@
 // Package typeresolution encapsulates the type resolution phase of the checker.
 // It resolves the types of all fields, functions (parameters, return types a.s.o.) within
-// the contract.
+// the contract and its interfaces.
 package typeresolution
@@ -3,6 +3,7 @@ package typeresolution
 import (
 	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/parser/node"
 )
 
@@ -12,8 +13,14 @@ type typeResolution struct {
 }
 
 // Run performs type resolution
+// The types of the interfaces are resolved as well, but their errors are reported once by RunInterfaces.
 // Returns errors that occurred during type resolution
 func Run(symTable *symbol.SymbolTable) []error {
+	interfaces := typeResolution{
+		symTable: symTable,
+	}
+	interfaces.resolveTypesInInterfaces()
+
 	tr := typeResolution{
 		symTable: symTable,
 	}
@@ -22,12 +29,45 @@ func Run(symTable *symbol.SymbolTable) []error {
 	return tr.errors
 }
 
+// RunInterfaces performs type resolution for the interfaces of a symbol table without contract
+// Returns errors that occurred during type resolution
+func RunInterfaces(symTable *symbol.SymbolTable) []error {
+	tr := typeResolution{
+		symTable: symTable,
+	}
+	tr.resolveTypesInInterfaces()
+	return tr.errors
+}
+
+func (tr *typeResolution) resolveTypesInInterfaces() {
+	for _, interfaceSymbol := range tr.symTable.GlobalScope.Interfaces {
+		for _, function := range interfaceSymbol.Functions {
+			tr.resolveTypeInFunctionSymbol(function)
+		}
+	}
+}
+
 func (tr *typeResolution) resolveTypesInContractSymbol() {
 	contractSymbol := tr.symTable.GlobalScope.Contract
+	tr.resolveImplementedInterfaces(contractSymbol)
+
+	for _, constant := range contractSymbol.Constants {
+		constantNode := tr.symTable.GetNodeBySymbol(constant).(*node.ConstantNode)
+		constant.Type = tr.resolveType(constantNode.Type)
+	}
+
 	for _, field := range contractSymbol.Fields {
 		tr.resolveTypeInFieldSymbol(field)
 	}
 
+	for _, event := range contractSymbol.Events {
+		tr.resolveTypeInParameters(event.Parameters)
+	}
+
+	for _, modifier := range contractSymbol.Modifiers {
+		tr.resolveTypeInFunctionSymbol(modifier)
+	}
+
 	if contractSymbol.Constructor != nil {
 		tr.resolveTypeInFunctionSymbol(contractSymbol.Constructor)
 	}
@@ -37,6 +77,20 @@ func (tr *typeResolution) resolveTypesInContractSymbol() {
 	}
 }
 
+// resolveImplementedInterfaces links the contract with the interfaces listed after 'implements'
+func (tr *typeResolution) resolveImplementedInterfaces(contractSymbol *symbol.ContractSymbol) {
+	contractNode := tr.symTable.GetNodeBySymbol(contractSymbol).(*node.ContractNode)
+	for _, interfaceNode := range contractNode.Implements {
+		interfaceSymbol, ok := tr.symTable.GlobalScope.Interfaces[interfaceNode.Identifier]
+		if !ok {
+			tr.reportError(interfaceNode, diagnostic.UndefinedType,
+				fmt.Sprintf("Interface '%s' is undefined", interfaceNode.Identifier))
+			continue
+		}
+		contractSymbol.Interfaces = append(contractSymbol.Interfaces, interfaceSymbol)
+	}
+}
+
 func (tr *typeResolution) resolveTypesInStruct() {
 	for _, structType := range tr.symTable.GlobalScope.Structs {
 		for _, fieldSym := range structType.Fields {
@@ -56,34 +110,41 @@ func (tr *typeResolution) resolveTypeInFunctionSymbol(sym *symbol.FunctionSymbol
 		tr.resolveReturnTypes(sym, functionNode)
 	}
 
-	for _, param := range sym.Parameters {
-		paramNode := tr.symTable.GetNodeBySymbol(param).(*node.ParameterNode)
-		param.Type = tr.resolveType(paramNode.Type)
-	}
+	tr.resolveTypeInParameters(sym.Parameters)
 
 	for _, locSym := range sym.LocalVariables {
 		locVarNode := tr.symTable.GetNodeBySymbol(locSym)
 
 		if varNode, ok := locVarNode.(*node.VariableNode); ok {
-			locSym.Type = tr.resolveType(varNode.Type)
+			// The type of a foreach loop variable can be omitted and is inferred in the designator resolution
+			if varNode.Type != nil {
+				locSym.Type = tr.resolveType(varNode.Type)
+			}
 		} else if multiVarNode, ok := locVarNode.(*node.MultiVariableNode); ok {
 			locSym.Type = tr.resolveType(multiVarNode.GetType(locSym.ID))
 		} else {
-			tr.reportError(locVarNode, fmt.Sprintf("Unsupported local variable node type"))
+			tr.reportError(locVarNode, diagnostic.Internal, fmt.Sprintf("Unsupported local variable node type"))
 		}
 	}
 }
 
+func (tr *typeResolution) resolveTypeInParameters(params []*symbol.ParameterSymbol) {
+	for _, param := range params {
+		paramNode := tr.symTable.GetNodeBySymbol(param).(*node.ParameterNode)
+		param.Type = tr.resolveType(paramNode.Type)
+	}
+}
+
 func (tr *typeResolution) resolveReturnTypes(sym *symbol.FunctionSymbol, functionNode *node.FunctionNode) {
 	total := len(functionNode.ReturnTypes)
 	if total > 3 {
-		tr.reportError(functionNode, "More than 3 return types are not allowed")
+		tr.reportError(functionNode, diagnostic.InvalidReturnTypes, "More than 3 return types are not allowed")
 	}
 
 	for _, rtype := range functionNode.ReturnTypes {
 		if rtype.String() == "void" {
 			if total > 1 {
-				tr.reportError(rtype, "'void' is invalid with multiple return types")
+				tr.reportError(rtype, diagnostic.InvalidReturnTypes, "'void' is invalid with multiple return types")
 			}
 		} else {
 			sym.ReturnTypes = append(sym.ReturnTypes, tr.resolveType(rtype))
@@ -91,14 +152,16 @@ func (tr *typeResolution) resolveReturnTypes(sym *symbol.FunctionSymbol, functio
 	}
 }
 
+// resolveType returns the type symbol of the type node or the error type, if the type does not exist
 func (tr *typeResolution) resolveType(node node.TypeNode) symbol.TypeSymbol {
 	result := tr.symTable.FindTypeByNode(node)
 	if result == nil {
-		tr.reportError(node, fmt.Sprintf("Invalid type '%s'", node.String()))
+		tr.reportError(node, diagnostic.UndefinedType, fmt.Sprintf("Invalid type '%s'", node.String()))
+		return tr.symTable.GlobalScope.ErrorType
 	}
 	return result
 }
 
-func (tr *typeResolution) reportError(node node.Node, msg string) {
-	tr.errors = append(tr.errors, fmt.Errorf("[%s] %s", node.Pos(), msg))
+func (tr *typeResolution) reportError(node node.Node, code diagnostic.Code, msg string) {
+	tr.errors = append(tr.errors, diagnostic.New(code, node.Pos(), node.End(), msg))
 }
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/bazo-blockchain/lazo/generator/lasm"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var asmOutput string
+
+func init() {
+	rootCmd.AddCommand(asmCommand)
+
+	asmCommand.Flags().StringVarP(
+		&asmOutput,
+		"output",
+		"o",
+		"",
+		"Output file for the byte code. \nDefaults to the source file name with the extension .bin")
+}
+
+var asmCommand = &cobra.Command{
+	Use:     "asm [IL assembly file]",
+	Short:   "Assemble the IL assembly (.lasm) into Bazo byte code",
+	Example: "  lazo asm contract.lasm\n  lazo asm contract.lasm -o contract.bin",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			_ = cmd.Help()
+		} else {
+			assemble(args[0], asmOutput)
+		}
+	},
+}
+
+func assemble(sourceFile string, outputFile string) {
+	file, err := os.Open(sourceFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	code, errors := lasm.Assemble(file)
+	if len(errors) > 0 {
+		fmt.Fprintln(os.Stderr, errors)
+		os.Exit(1)
+	}
+
+	if outputFile == "" {
+		outputFile = strings.TrimSuffix(sourceFile, filepath.Ext(sourceFile)) + ".bin"
+	}
+	if err := ioutil.WriteFile(outputFile, code, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
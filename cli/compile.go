@@ -2,20 +2,32 @@ package cli
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/bazo-blockchain/lazo/checker"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
 	"github.com/bazo-blockchain/lazo/generator"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"github.com/bazo-blockchain/lazo/generator/lasm"
 	"github.com/bazo-blockchain/lazo/lexer"
 	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser"
 	"github.com/bazo-blockchain/lazo/parser/node"
 	"github.com/spf13/cobra"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 var stage string
+var output string
+var listing bool
+var abi bool
+var emit string
+var contractName string
 
 func init() {
 	rootCmd.AddCommand(compileCommand)
@@ -26,32 +38,95 @@ func init() {
 		"s",
 		"g",
 		"Compilation stage. \nAvailable stages: l=lexer, p=parser, c=checker, g=generator")
+
+	compileCommand.Flags().StringVarP(
+		&output,
+		"output",
+		"o",
+		"",
+		"Output file for the byte code. \nA companion JSON file with the contract metadata is written next to it")
+
+	compileCommand.Flags().BoolVar(
+		&listing,
+		"listing",
+		false,
+		"Print the byte code listing with the position of every instruction")
+
+	compileCommand.Flags().BoolVar(
+		&abi,
+		"abi",
+		false,
+		"Write the contract ABI as JSON. \nIt is written next to the output file (e.g. contract.abi.json) or printed otherwise")
+
+	compileCommand.Flags().StringVar(
+		&emit,
+		"emit",
+		"",
+		"Additional output format. \nAvailable formats: lasm=IL assembly, written next to the output file (e.g. contract.lasm) "+
+			"or printed otherwise")
+
+	addContractFlag(compileCommand)
 }
 
+// addContractFlag adds the flag to select the compiled contract of a program with multiple contracts
+func addContractFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&contractName,
+		"contract",
+		"",
+		"Name of the compiled contract. \nIt is required, if the source file declares multiple contracts")
+}
+
+const compileExample = `  lazo compile program.lazo --stage=l
+  lazo compile program.lazo -o contract.bin --abi
+  lazo compile program.lazo --emit=lasm
+  lazo compile program.lazo --contract Token`
+
 var compileCommand = &cobra.Command{
 	Use:     "compile [source file]",
 	Short:   "Compile the Lazo source code",
-	Example: "  lazo compile program.lazo --stage=l",
+	Example: compileExample,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
 			_ = cmd.Help()
 		} else {
-			compile(args[0])
+			if emit != "" && emit != "lasm" {
+				fmt.Fprintf(os.Stderr, "Unknown output format %s\n", emit)
+				os.Exit(1)
+			}
+
+			metadata := compile(args[0])
+			if listing {
+				metadata.WriteListing(os.Stdout)
+			}
+			if output != "" {
+				writeArtifact(metadata, output)
+			}
+			if abi {
+				writeABI(metadata, output)
+			}
+			if emit == "lasm" {
+				writeAssembly(metadata, output)
+			}
 		}
 	},
 }
 
-// compile compiles the given Lazo source code into Bazo byte code.
-func compile(sourceFile string) ([]byte, [][]byte) {
+// compile compiles the given Lazo source code into the contract metadata, which contains the Bazo byte code.
+func compile(sourceFile string) *data.Metadata {
+	return generate(checkSource(sourceFile), sourceFile)
+}
+
+// checkSource compiles the given Lazo source code until the checker stage and returns the symbol table
+func checkSource(sourceFile string) *symbol.SymbolTable {
 	file, err := os.Open(sourceFile)
 	if err != nil {
 		panic(err)
 	}
 
 	lexer := scan(file)
-	syntaxTree := parse(lexer)
-	symbolTable := check(syntaxTree)
-	return generate(symbolTable)
+	syntaxTree := parse(lexer, sourceFile)
+	return check(syntaxTree, sourceFile)
 }
 
 func scan(file io.Reader) *lexer.Lexer {
@@ -70,14 +145,15 @@ func scan(file io.Reader) *lexer.Lexer {
 	return lexer
 }
 
-func parse(l *lexer.Lexer) *node.ProgramNode {
+func parse(l *lexer.Lexer, sourceFile string) *node.ProgramNode {
 	parser := parser.New(l)
 	syntaxTree, errors := parser.ParseProgram()
 
 	if len(errors) > 0 {
-		fmt.Fprintln(os.Stderr, errors)
-		fmt.Println(syntaxTree)
-		os.Exit(1)
+		if format == textFormat {
+			fmt.Println(syntaxTree)
+		}
+		exitWithErrors(errors, sourceFile)
 	}
 
 	if stage == "p" {
@@ -88,13 +164,13 @@ func parse(l *lexer.Lexer) *node.ProgramNode {
 	return syntaxTree
 }
 
-func check(syntaxTree *node.ProgramNode) *symbol.SymbolTable {
+func check(syntaxTree *node.ProgramNode, sourceFile string) *symbol.SymbolTable {
 	checker := checker.New(syntaxTree)
+	checker.Contract = contractName
 	symbolTable, errors := checker.Run()
 
 	if len(errors) > 0 {
-		fmt.Fprintln(os.Stderr, errors)
-		os.Exit(1)
+		exitWithErrors(errors, sourceFile)
 	}
 
 	if stage == "c" {
@@ -105,14 +181,91 @@ func check(syntaxTree *node.ProgramNode) *symbol.SymbolTable {
 	return symbolTable
 }
 
-func generate(symbolTable *symbol.SymbolTable) ([]byte, [][]byte) {
+func generate(symbolTable *symbol.SymbolTable, sourceFile string) *data.Metadata {
 	generator := generator.New(symbolTable)
 	metadata, errors := generator.Run()
 
 	if len(errors) > 0 {
-		fmt.Fprintln(os.Stderr, errors)
+		exitWithErrors(errors, sourceFile)
+	}
+
+	return metadata
+}
+
+// writeArtifact writes the byte code into the output file and the contract metadata into a JSON file
+// with the same name, e.g. contract.bin and contract.meta.json
+func writeArtifact(metadata *data.Metadata, outputFile string) {
+	artifactFile := companionFile(outputFile, ".meta.json")
+
+	code, _ := metadata.CreateContract()
+	if err := ioutil.WriteFile(outputFile, code, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 
-	return metadata.CreateContract()
+	artifact := marshalJSON(metadata.CreateArtifact())
+	if err := ioutil.WriteFile(artifactFile, artifact, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// writeABI writes the contract ABI into a JSON file next to the output file, e.g. contract.abi.json.
+// Without an output file, the ABI is printed to the standard output.
+func writeABI(metadata *data.Metadata, outputFile string) {
+	abi := marshalJSON(metadata.CreateABI())
+	if outputFile == "" {
+		fmt.Print(string(abi))
+		return
+	}
+
+	abiFile := companionFile(outputFile, ".abi.json")
+	if err := ioutil.WriteFile(abiFile, abi, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// writeAssembly writes the IL assembly into a file next to the output file, e.g. contract.lasm.
+// Without an output file, the IL assembly is printed to the standard output.
+func writeAssembly(metadata *data.Metadata, outputFile string) {
+	var buffer bytes.Buffer
+	if err := lasm.WriteContract(&buffer, metadata); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if outputFile == "" {
+		fmt.Print(buffer.String())
+		return
+	}
+
+	assemblyFile := companionFile(outputFile, ".lasm")
+	if err := ioutil.WriteFile(assemblyFile, buffer.Bytes(), 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// companionFile returns the name of a file written next to the output file, e.g. contract.lasm for contract.bin.
+// It exits, if the companion file would overwrite the output file.
+func companionFile(outputFile string, suffix string) string {
+	file := strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + suffix
+	if file == outputFile {
+		fmt.Fprintf(os.Stderr, "Output file %s collides with the %s file written next to it\n", outputFile, suffix)
+		os.Exit(1)
+	}
+	return file
+}
+
+// marshalJSON returns the indented JSON encoding of v without escaping type names like Map<int,int>
+func marshalJSON(v interface{}) []byte {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		panic(err)
+	}
+	return buffer.Bytes()
 }
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/bazo-blockchain/lazo/diagnostic"
+	"io/ioutil"
+	"os"
+)
+
+// Supported output formats of the diagnostics
+const (
+	textFormat = "text"
+	jsonFormat = "json"
+)
+
+var format string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(
+		&format,
+		"format",
+		textFormat,
+		"Output format of the compiler errors. \nAvailable formats: text=source lines with underlined errors, "+
+			"json=JSON array for editor integration")
+}
+
+// exitWithErrors prints the errors of a compiler phase as diagnostics in source order and exits.
+// Text diagnostics are printed to the standard error, JSON diagnostics to the standard output.
+func exitWithErrors(errors []error, sourceFile string) {
+	diagnostics := diagnostic.FromErrors(errors, sourceFile)
+	diagnostic.Sort(diagnostics)
+	if format == jsonFormat {
+		if err := diagnostic.WriteJSON(os.Stdout, diagnostics); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	} else {
+		source, _ := ioutil.ReadFile(sourceFile)
+		diagnostic.Write(os.Stderr, diagnostics, string(source))
+	}
+	os.Exit(1)
+}
+
+// checkFormat exits, if the output format of the diagnostics is not supported
+func checkFormat() {
+	if format != textFormat && format != jsonFormat {
+		fmt.Fprintf(os.Stderr, "Unknown diagnostic format %s\n", format)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"github.com/bazo-blockchain/lazo/generator/disasm"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+)
+
+var abiFile string
+
+func init() {
+	rootCmd.AddCommand(disasmCommand)
+
+	disasmCommand.Flags().StringVar(
+		&abiFile,
+		"abi",
+		"",
+		"ABI file written by 'lazo compile --abi'. \nIt is used to name the functions, otherwise they are named by their hash")
+}
+
+var disasmCommand = &cobra.Command{
+	Use:     "disasm [byte code file]",
+	Short:   "Disassemble the Bazo byte code into a readable listing",
+	Example: "  lazo disasm contract.bin\n  lazo disasm contract.bin --abi contract.abi.json",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			_ = cmd.Help()
+		} else {
+			disassemble(args[0], abiFile)
+		}
+	},
+}
+
+func disassemble(byteCodeFile string, abiFile string) {
+	code, err := ioutil.ReadFile(byteCodeFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var abi *data.ABI
+	if abiFile != "" {
+		abi = readABI(abiFile)
+	}
+
+	program, err := disasm.Disassemble(code, abi)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	program.Write(os.Stdout)
+}
+
+// readABI reads the contract ABI from a JSON file written by 'lazo compile --abi'
+func readABI(abiFile string) *data.ABI {
+	content, err := ioutil.ReadFile(abiFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	abi := &data.ABI{}
+	if err := json.Unmarshal(content, abi); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid ABI file %s: %s\n", abiFile, err)
+		os.Exit(1)
+	}
+	return abi
+}
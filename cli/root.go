@@ -11,6 +11,9 @@ var rootCmd = &cobra.Command{
 	Use:   "lazo",
 	Short: "Lazo is a tool for managing Lazo source code",
 	Long:  `Lazo is a tool for managing Lazo source code on the Bazo Blockchain`,
+	PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+		checkFormat()
+	},
 	Run: func(cmd *cobra.Command, _ []string) {
 		_ = cmd.Help()
 	},
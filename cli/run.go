@@ -2,18 +2,70 @@ package cli
 
 import (
 	"fmt"
-	"github.com/bazo-blockchain/bazo-vm/vm"
+	"github.com/bazo-blockchain/lazo/runner"
 	"github.com/spf13/cobra"
+	"os"
 )
 
+var call string
+var callArgs []string
+var initArgs []string
+var stateFile string
+var trace bool
+var value uint64
+
 func init() {
 	rootCmd.AddCommand(runCommand)
+
+	runCommand.Flags().StringVar(
+		&call,
+		"call",
+		"",
+		"Signature of the function to call, e.g. \"transfer(int,int)\". \nWithout it, only the constructor is executed")
+
+	runCommand.Flags().StringSliceVar(
+		&callArgs,
+		"args",
+		nil,
+		"Comma separated arguments of the called function or the constructor")
+
+	runCommand.Flags().StringSliceVar(
+		&initArgs,
+		"init-args",
+		nil,
+		"Comma separated constructor arguments, if the constructor is executed before the called function")
+
+	runCommand.Flags().StringVar(
+		&stateFile,
+		"state",
+		"",
+		"State file with the contract variables. \nIf it exists, the contract variables are loaded from it instead of "+
+			"executing the constructor.\nThe changed contract variables are written back")
+
+	runCommand.Flags().Uint64Var(
+		&value,
+		"value",
+		0,
+		"Amount of Bazo coins sent to the called function or the constructor")
+
+	runCommand.Flags().BoolVar(
+		&trace,
+		"trace",
+		false,
+		"Print the execution trace of the Bazo VM")
+
+	addContractFlag(runCommand)
 }
 
+const runExample = `  lazo run program.lazo
+  lazo run program.lazo --call "transfer(int,int)" --args 1,5
+  lazo run program.lazo --call "transfer(int,int)" --args 1,5 --state state.json
+  lazo run program.lazo --call "deposit()" --value 100`
+
 var runCommand = &cobra.Command{
 	Use:     "run [source file]",
-	Short:   "compile and run the lazo source code on Bazo VM",
-	Example: "lazo run program.lazo",
+	Short:   "Compile and run the lazo source code on Bazo VM",
+	Example: runExample,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
 			_ = cmd.Help()
@@ -24,21 +76,50 @@ var runCommand = &cobra.Command{
 }
 
 func execute(sourceFile string) {
-	code, variables := compile(sourceFile)
-	context := vm.NewMockContext(code)
-	context.ContractVariables = variables
-	context.Fee += (uint64(len(variables)))*1000*10 + 1000
-	context.Data = []byte{
-		1, // total bytes
-		0, // Contract Init Flag
+	contract := runner.New(compile(sourceFile))
+	contract.Trace = trace
+
+	if stateFile != "" && fileExists(stateFile) {
+		exitOnError(contract.LoadState(stateFile))
+	} else if call == "" {
+		contract.Value = value
+		exitOnError(contract.Deploy(callArgs))
+		printEvents(contract)
+	} else {
+		exitOnError(contract.Deploy(initArgs))
+		printEvents(contract)
 	}
 
-	vm := vm.NewVM(context)
-	isSuccess := vm.Exec(true)
-	result, _ := vm.PeekResult()
-	if !isSuccess {
-		panic(fmt.Sprintf("Runtime Error: %s", result))
+	if call != "" {
+		contract.Value = value
+		values, err := contract.Call(call, callArgs)
+		exitOnError(err)
+		printEvents(contract)
+		for _, value := range values {
+			fmt.Println(value)
+		}
+	}
+
+	if stateFile != "" {
+		exitOnError(contract.SaveState(stateFile))
+	}
+}
+
+// printEvents prints the events of the last transaction
+func printEvents(contract *runner.Runner) {
+	for _, event := range contract.Events {
+		fmt.Println(event)
 	}
+}
 
-	fmt.Printf("%d", result) // [0, 7] => +7
+func fileExists(file string) bool {
+	_, err := os.Stat(file)
+	return err == nil
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"github.com/bazo-blockchain/lazo/runner"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var showStateFile string
+
+func init() {
+	rootCmd.AddCommand(stateCommand)
+	stateCommand.AddCommand(stateShowCommand)
+
+	stateShowCommand.Flags().StringVar(
+		&showStateFile,
+		"state",
+		"state.json",
+		"State file written by 'lazo run --state'")
+
+	addContractFlag(stateShowCommand)
+}
+
+var stateCommand = &cobra.Command{
+	Use:   "state",
+	Short: "Manage the local contract state of lazo run",
+}
+
+var stateShowCommand = &cobra.Command{
+	Use:     "show [source file]",
+	Short:   "Show the contract variables of the state file decoded by their declared types",
+	Example: "  lazo state show program.lazo --state state.json",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			_ = cmd.Help()
+		} else {
+			showState(args[0], showStateFile)
+		}
+	},
+}
+
+func showState(sourceFile string, stateFile string) {
+	symbolTable := checkSource(sourceFile)
+	contract := runner.New(generate(symbolTable, sourceFile))
+	exitOnError(contract.LoadState(stateFile))
+	contract.WriteFields(os.Stdout, symbolTable.GlobalScope.Contract)
+}
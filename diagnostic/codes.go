@@ -0,0 +1,69 @@
+package diagnostic
+
+// Code identifies the kind of a diagnostic. Codes are stable and must not be reused for other kinds.
+// The prefix denotes the compiler phase: P=parser, S=symbol construction, R=type resolution,
+// D=designator resolution, T=type check, G=code generation.
+type Code string
+
+// Internal is used for unexpected errors, which are not caused by the source code
+const Internal Code = "E000"
+
+// Syntax errors
+const (
+	SyntaxError          Code = "P001"
+	IdentifierExpected   Code = "P002"
+	InvalidToken         Code = "P003"
+	DuplicateConstructor Code = "P004"
+	InvalidTypeSyntax    Code = "P005"
+	InvalidDelete        Code = "P006"
+)
+
+// Declaration errors
+const (
+	MissingContract      Code = "S001"
+	DuplicateDeclaration Code = "S002"
+	ReservedIdentifier   Code = "S003"
+	InvalidModifier      Code = "S004"
+	EmptyEnum            Code = "S005"
+)
+
+// Type resolution errors
+const (
+	UndefinedType      Code = "R001"
+	InvalidReturnTypes Code = "R002"
+)
+
+// Designator resolution errors
+const (
+	UndefinedDesignator Code = "D001"
+	NotVisible          Code = "D002"
+	UndefinedMember     Code = "D003"
+	InvalidMemberAccess Code = "D004"
+	TypeNotInferable    Code = "D005"
+	UnsupportedBuiltIn  Code = "D006"
+)
+
+// Type check errors
+const (
+	TypeMismatch          Code = "T001"
+	InvalidOperator       Code = "T002"
+	InvalidCondition      Code = "T003"
+	InvalidReturn         Code = "T004"
+	InvalidThis           Code = "T005"
+	NotAFunction          Code = "T006"
+	ArgumentCount         Code = "T007"
+	InvalidLoop           Code = "T008"
+	InvalidCast           Code = "T009"
+	InvalidStructCreation Code = "T010"
+	InvalidArray          Code = "T011"
+	InvalidStatement      Code = "T012"
+	ReadOnlyAssignment    Code = "T013"
+	InvalidPlaceholder    Code = "T014"
+	InvalidConstant       Code = "T015"
+	InterfaceMismatch     Code = "T016"
+)
+
+// Code generation errors
+const (
+	Unsupported Code = "G001"
+)
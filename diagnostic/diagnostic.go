@@ -0,0 +1,77 @@
+// Package diagnostic contains the errors reported by the compiler phases.
+// Every diagnostic has a stable error code, a severity and the source span of the offending construct,
+// so that it can be rendered with the source line or consumed by editors as JSON.
+package diagnostic
+
+import (
+	"fmt"
+	"github.com/bazo-blockchain/lazo/lexer/token"
+	"sort"
+)
+
+// Severity indicates whether a diagnostic prevents the compilation
+type Severity string
+
+// Supported severities
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Diagnostic is a compiler message located in the source code.
+// The end position points to the column after the last character of the offending construct.
+// If it is unknown, the end position is zero.
+type Diagnostic struct {
+	Code     Code           `json:"code"`
+	Severity Severity       `json:"severity"`
+	Message  string         `json:"message"`
+	File     string         `json:"file,omitempty"`
+	Start    token.Position `json:"start"`
+	End      token.Position `json:"end"`
+}
+
+// New creates an error diagnostic with the given code, source span and message
+func New(code Code, start token.Position, end token.Position, msg string) *Diagnostic {
+	return &Diagnostic{
+		Code:     code,
+		Severity: Error,
+		Message:  msg,
+		Start:    start,
+		End:      end,
+	}
+}
+
+// Error returns the message prefixed with the start position
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("[%s] %s", d.Start, d.Message)
+}
+
+// FromErrors converts the errors of the compiler phases into diagnostics of the given source file.
+// Errors, which are not diagnostics, are reported as internal errors without position.
+func FromErrors(errors []error, file string) []*Diagnostic {
+	diagnostics := make([]*Diagnostic, len(errors))
+	for i, err := range errors {
+		d, ok := err.(*Diagnostic)
+		if !ok {
+			d = New(Internal, token.Position{}, token.Position{}, err.Error())
+		}
+		d.File = file
+		diagnostics[i] = d
+	}
+	return diagnostics
+}
+
+// Sort orders the diagnostics by their start position (file, line, column), so that they are reported in source order
+// instead of the order of the compiler phases. Diagnostics with the same position keep their order.
+func Sort(diagnostics []*Diagnostic) {
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Start.Line != b.Start.Line {
+			return a.Start.Line < b.Start.Line
+		}
+		return a.Start.Column < b.Start.Column
+	})
+}
@@ -0,0 +1,119 @@
+package diagnostic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/bazo-blockchain/lazo/lexer/token"
+	"gotest.tools/assert"
+	"testing"
+)
+
+const source = "contract Test {\n\tint x = true\n}\n"
+
+func pos(line int, column int) token.Position {
+	return token.Position{Line: line, Column: column}
+}
+
+func render(d *Diagnostic) string {
+	var buffer bytes.Buffer
+	Write(&buffer, []*Diagnostic{d}, source)
+	return buffer.String()
+}
+
+func TestError(t *testing.T) {
+	d := New(TypeMismatch, pos(2, 10), pos(2, 14), "expected int, got bool")
+	assert.Equal(t, d.Error(), "[2:10] expected int, got bool")
+	assert.Equal(t, d.Severity, Error)
+}
+
+func TestFromErrors(t *testing.T) {
+	d := New(TypeMismatch, pos(2, 10), pos(2, 14), "expected int, got bool")
+	diagnostics := FromErrors([]error{d, errors.New("unexpected")}, "test.lazo")
+
+	assert.Equal(t, len(diagnostics), 2)
+	assert.Equal(t, diagnostics[0], d)
+	assert.Equal(t, diagnostics[0].File, "test.lazo")
+	assert.Equal(t, diagnostics[1].Code, Internal)
+	assert.Equal(t, diagnostics[1].Message, "unexpected")
+	assert.Equal(t, diagnostics[1].File, "test.lazo")
+}
+
+func TestSort(t *testing.T) {
+	typeError := New(TypeMismatch, pos(2, 10), pos(2, 14), "type")
+	designatorError := New(UndefinedDesignator, pos(2, 3), pos(2, 4), "designator")
+	symbolError := New(DuplicateDeclaration, pos(5, 1), pos(5, 4), "symbol")
+	otherFileError := New(SyntaxError, pos(1, 1), pos(1, 2), "other file")
+	sameStartError := New(InvalidOperator, pos(2, 10), pos(2, 12), "same start")
+	diagnostics := FromErrors([]error{symbolError, typeError, designatorError, sameStartError}, "b.lazo")
+	otherFileError.File = "a.lazo"
+	diagnostics = append(diagnostics, otherFileError)
+
+	Sort(diagnostics)
+	expected := []*Diagnostic{otherFileError, designatorError, typeError, sameStartError, symbolError}
+	assert.DeepEqual(t, diagnostics, expected)
+}
+
+func TestWrite(t *testing.T) {
+	d := New(TypeMismatch, pos(2, 10), pos(2, 14), "expected int, got bool")
+	d.File = "test.lazo"
+
+	assert.Equal(t, render(d), "test.lazo:2:10: error[T001]: expected int, got bool\n"+
+		"   2 | \tint x = true\n"+
+		"     | \t        ^^^^\n")
+}
+
+func TestWriteWithoutEnd(t *testing.T) {
+	d := New(SyntaxError, pos(1, 10), token.Position{}, "Symbol { expected")
+
+	assert.Equal(t, render(d), "1:10: error[P001]: Symbol { expected\n"+
+		"   1 | contract Test {\n"+
+		"     |          ^\n")
+}
+
+func TestWriteMultiLineSpan(t *testing.T) {
+	d := New(InvalidReturn, pos(2, 6), pos(3, 2), "multi line")
+
+	assert.Equal(t, render(d), "2:6: error[T004]: multi line\n"+
+		"   2 | \tint x = true\n"+
+		"     | \t    ^^^^^^^^\n")
+}
+
+func TestWriteWithoutPosition(t *testing.T) {
+	d := New(MissingContract, token.Position{}, token.Position{}, "Program has no contract")
+	d.File = "test.lazo"
+
+	assert.Equal(t, render(d), "test.lazo: error[S001]: Program has no contract\n")
+}
+
+func TestWriteWithoutSource(t *testing.T) {
+	var buffer bytes.Buffer
+	Write(&buffer, []*Diagnostic{New(TypeMismatch, pos(2, 10), pos(2, 14), "msg")}, "")
+
+	assert.Equal(t, buffer.String(), "2:10: error[T001]: msg\n")
+}
+
+func TestWriteJSON(t *testing.T) {
+	d := New(TypeMismatch, pos(2, 10), pos(2, 14), "expected Map<int,int>")
+	d.File = "test.lazo"
+
+	var buffer bytes.Buffer
+	assert.NilError(t, WriteJSON(&buffer, []*Diagnostic{d}))
+
+	var result []map[string]interface{}
+	assert.NilError(t, json.Unmarshal(buffer.Bytes(), &result))
+	assert.Equal(t, len(result), 1)
+	assert.Equal(t, result[0]["code"], "T001")
+	assert.Equal(t, result[0]["severity"], "error")
+	assert.Equal(t, result[0]["message"], "expected Map<int,int>")
+	assert.Equal(t, result[0]["file"], "test.lazo")
+	assert.DeepEqual(t, result[0]["start"], map[string]interface{}{"line": 2.0, "column": 10.0})
+	assert.DeepEqual(t, result[0]["end"], map[string]interface{}{"line": 2.0, "column": 14.0})
+	assert.Assert(t, bytes.Contains(buffer.Bytes(), []byte("Map<int,int>")))
+}
+
+func TestWriteJSONWithoutDiagnostics(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.NilError(t, WriteJSON(&buffer, nil))
+	assert.Equal(t, buffer.String(), "[]\n")
+}
@@ -0,0 +1,88 @@
+package diagnostic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Write renders the diagnostics in a human readable format. If the source code is given,
+// the offending source line is printed below the message with the source span underlined:
+//
+//	program.lazo:3:13: error[T001]: expected int, got bool
+//	   3 | 	int x = true
+//	     | 	        ^^^^
+func Write(w io.Writer, diagnostics []*Diagnostic, source string) {
+	lines := strings.Split(source, "\n")
+	for _, d := range diagnostics {
+		fmt.Fprintf(w, "%s: %s[%s]: %s\n", d.location(), d.Severity, d.Code, d.Message)
+
+		if d.Start.Line < 1 || d.Start.Line > len(lines) || source == "" {
+			continue
+		}
+		line := []rune(strings.TrimRight(lines[d.Start.Line-1], "\r"))
+		gutter := fmt.Sprintf("%4d", d.Start.Line)
+		fmt.Fprintf(w, "%s | %s\n", gutter, string(line))
+		fmt.Fprintf(w, "%s | %s\n", strings.Repeat(" ", len(gutter)), underline(line, d.Start.Column, d.endColumn(len(line))))
+	}
+}
+
+// WriteJSON writes the diagnostics as JSON array, which can be consumed by editors
+func WriteJSON(w io.Writer, diagnostics []*Diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []*Diagnostic{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(diagnostics)
+}
+
+func (d *Diagnostic) location() string {
+	location := d.File
+	if d.Start.Line > 0 {
+		if location != "" {
+			location += ":"
+		}
+		location += fmt.Sprintf("%d:%d", d.Start.Line, d.Start.Column)
+	}
+	if location == "" {
+		return "lazo"
+	}
+	return location
+}
+
+// endColumn returns the column after the last underlined character. Spans across multiple lines
+// are underlined until the end of the start line, unknown spans only at the start column.
+func (d *Diagnostic) endColumn(lineLength int) int {
+	end := d.Start.Column + 1
+	if d.End.Line > d.Start.Line {
+		end = lineLength + 1
+	} else if d.End.Line == d.Start.Line && d.End.Column > d.Start.Column {
+		end = d.End.Column
+	}
+
+	if end > lineLength+1 {
+		end = lineLength + 1
+	}
+	if end <= d.Start.Column {
+		end = d.Start.Column + 1
+	}
+	return end
+}
+
+// underline returns the carets from the start column (1-based) to the end column (exclusive).
+// Tabs before the start column are kept, so that the carets are aligned with the source line.
+func underline(line []rune, start int, end int) string {
+	var builder strings.Builder
+	for i := 1; i < start; i++ {
+		if i <= len(line) && line[i-1] == '\t' {
+			builder.WriteRune('\t')
+		} else {
+			builder.WriteRune(' ')
+		}
+	}
+	builder.WriteString(strings.Repeat("^", end-start))
+	return builder.String()
+}
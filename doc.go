@@ -7,9 +7,12 @@
 //  lazo [command]
 //
 // Available Commands:
+//  asm         Assemble the IL assembly (.lasm) into Bazo byte code
 //  compile     Compile the Lazo source code
+//  disasm      Disassemble the Bazo byte code into a readable listing
 //  help        Help about any command
 //  run         Compile and run the lazo source code on Bazo VM
+//  state       Manage the local contract state of lazo run
 //  version     Print the version number of Lazo
 //
 // Use "lazo [command] --help" for more information about a command.
@@ -0,0 +1,136 @@
+package data
+
+import "encoding/hex"
+
+// ABI describes the interface of a compiled contract, so that clients can call its functions
+// without recomputing the function signatures and hashes.
+type ABI struct {
+	Contract    string         `json:"contract"`
+	Constructor []*VariableABI `json:"constructor"`
+	Functions   []*FunctionABI `json:"functions"`
+	Fields      []*FieldABI    `json:"fields"`
+	Structs     []*StructABI   `json:"structs"`
+	Enums       []*EnumABI     `json:"enums"`
+	Events      []*EventABI    `json:"events"`
+}
+
+// FunctionABI contains the name, parameters, return types, signature, the hex encoded hash of a function and
+// whether the function accepts Bazo coins
+type FunctionABI struct {
+	Name        string         `json:"name"`
+	Parameters  []*VariableABI `json:"parameters"`
+	ReturnTypes []string       `json:"returnTypes"`
+	Signature   string         `json:"signature"`
+	Hash        string         `json:"hash"`
+	Payable     bool           `json:"payable"`
+}
+
+// FieldABI contains the storage index, name and type of a contract field
+type FieldABI struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+}
+
+// StructABI contains the name and the field layout of a struct type
+type StructABI struct {
+	Name   string      `json:"name"`
+	Fields []*FieldABI `json:"fields"`
+}
+
+// EnumABI contains the name and the member names of an enum type.
+// Enum values are encoded as the index of the member.
+type EnumABI struct {
+	Name    string   `json:"name"`
+	Members []string `json:"members"`
+}
+
+// EventABI contains the name, parameters, signature and the hex encoded hash of an event.
+// The events emitted by a transaction are stored in the contract variable after the fields.
+type EventABI struct {
+	Name       string         `json:"name"`
+	Parameters []*VariableABI `json:"parameters"`
+	Signature  string         `json:"signature"`
+	Hash       string         `json:"hash"`
+}
+
+// VariableABI contains the name and type of a parameter
+type VariableABI struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// CreateABI returns the ABI of the contract. Private and internal functions are not part of the ABI.
+func (d *Metadata) CreateABI() *ABI {
+	contract := d.Contract
+	abi := &ABI{
+		Contract:    contract.Identifier,
+		Constructor: createVariableABIs(contract.ConstructorParameters),
+		Functions:   []*FunctionABI{},
+		Fields:      createFieldABIs(contract.Fields),
+		Structs:     []*StructABI{},
+		Enums:       []*EnumABI{},
+		Events:      []*EventABI{},
+	}
+
+	for _, function := range contract.Functions {
+		if !function.Public {
+			continue
+		}
+		abi.Functions = append(abi.Functions, &FunctionABI{
+			Name:        function.Identifier,
+			Parameters:  createVariableABIs(function.Parameters),
+			ReturnTypes: append([]string{}, function.ReturnTypes...),
+			Signature:   function.Signature,
+			Hash:        "0x" + hex.EncodeToString(function.Hash[:]),
+			Payable:     function.Payable,
+		})
+	}
+
+	for _, structData := range contract.Structs {
+		abi.Structs = append(abi.Structs, &StructABI{
+			Name:   structData.Identifier,
+			Fields: createFieldABIs(structData.Fields),
+		})
+	}
+
+	for _, enum := range contract.Enums {
+		abi.Enums = append(abi.Enums, &EnumABI{
+			Name:    enum.Identifier,
+			Members: append([]string{}, enum.Members...),
+		})
+	}
+
+	for _, event := range contract.Events {
+		abi.Events = append(abi.Events, &EventABI{
+			Name:       event.Identifier,
+			Parameters: createVariableABIs(event.Parameters),
+			Signature:  event.Signature,
+			Hash:       "0x" + hex.EncodeToString(event.Hash[:]),
+		})
+	}
+	return abi
+}
+
+func createVariableABIs(variables []*VariableData) []*VariableABI {
+	abis := []*VariableABI{}
+	for _, variable := range variables {
+		abis = append(abis, &VariableABI{
+			Name: variable.Identifier,
+			Type: variable.Type,
+		})
+	}
+	return abis
+}
+
+func createFieldABIs(fields []*VariableData) []*FieldABI {
+	abis := []*FieldABI{}
+	for i, field := range fields {
+		abis = append(abis, &FieldABI{
+			Index: i,
+			Name:  field.Identifier,
+			Type:  field.Type,
+		})
+	}
+	return abis
+}
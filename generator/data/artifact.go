@@ -0,0 +1,37 @@
+package data
+
+import "encoding/hex"
+
+// Artifact describes a compiled contract. It is written next to the byte code so that deployment tools
+// know the total fields and the function hashes of the contract without compiling it again.
+type Artifact struct {
+	Contract    string              `json:"contract"`
+	TotalFields uint16              `json:"totalFields"`
+	Functions   []*ArtifactFunction `json:"functions"`
+}
+
+// ArtifactFunction contains the identifier and the hex encoded hash of a public contract function
+type ArtifactFunction struct {
+	Identifier string `json:"identifier"`
+	Hash       string `json:"hash"`
+}
+
+// CreateArtifact returns the artifact description of the contract
+func (d *Metadata) CreateArtifact() *Artifact {
+	artifact := &Artifact{
+		Contract:    d.Contract.Identifier,
+		TotalFields: d.Contract.TotalFields,
+		Functions:   []*ArtifactFunction{},
+	}
+
+	for _, function := range d.Contract.Functions {
+		if !function.Public {
+			continue
+		}
+		artifact.Functions = append(artifact.Functions, &ArtifactFunction{
+			Identifier: function.Identifier,
+			Hash:       "0x" + hex.EncodeToString(function.Hash[:]),
+		})
+	}
+	return artifact
+}
@@ -3,18 +3,72 @@ package data
 
 import "github.com/bazo-blockchain/lazo/generator/il"
 
-// ContractData contains the identifier, total fields, functions and instructions
+// ContractData contains the identifier, total fields, field layout, structs, enums, events, functions and instructions.
 type ContractData struct {
-	Identifier   string
-	TotalFields  uint16
-	Functions    []*FunctionData
-	Instructions []*il.Instruction
+	Identifier            string
+	TotalFields           uint16
+	Fields                []*VariableData
+	Structs               []*StructData
+	Enums                 []*EnumData
+	Events                []*EventData
+	ConstructorParameters []*VariableData
+	Functions             []*FunctionData
+	Instructions          []*il.Instruction
 }
 
-// FunctionData contains an the identifier, return types, parameter types, local variable types, instructions and the
-// function hash
+// FunctionData contains an the identifier, signature, parameters, return types, instructions, the
+// function hash, whether the function can be called by transactions and whether it accepts Bazo coins
 type FunctionData struct {
 	Identifier   string
+	Signature    string
+	Parameters   []*VariableData
+	ReturnTypes  []string
 	Instructions []*il.Instruction
 	Hash         [4]byte
+	Public       bool
+	Payable      bool
+}
+
+// VariableData contains the identifier and the type of a field or parameter
+type VariableData struct {
+	Identifier string
+	Type       string
+}
+
+// StructData contains the identifier and the field layout of a struct type
+type StructData struct {
+	Identifier string
+	Fields     []*VariableData
+}
+
+// EnumData contains the identifier and the members of an enum type.
+// The value of a member is its index.
+type EnumData struct {
+	Identifier string
+	Members    []string
+}
+
+// GetEnum returns the enum type with the given identifier or nil, if the type is not an enum
+func (d *ContractData) GetEnum(identifier string) *EnumData {
+	for _, enum := range d.Enums {
+		if enum.Identifier == identifier {
+			return enum
+		}
+	}
+	return nil
+}
+
+// EventLogIndex returns the index of the contract variable, which contains the events emitted by the last transaction.
+// It is placed after the contract fields and only exists, if the contract declares events.
+func (d *ContractData) EventLogIndex() int {
+	return len(d.Fields)
+}
+
+// EventData contains the identifier, signature, parameters and the hash of an event.
+// The hash identifies the event in the event log.
+type EventData struct {
+	Identifier string
+	Signature  string
+	Parameters []*VariableData
+	Hash       [4]byte
 }
@@ -2,8 +2,8 @@ package data
 
 import (
 	"fmt"
-	"github.com/bazo-blockchain/bazo-vm/vm"
 	"github.com/bazo-blockchain/lazo/generator/il"
+	"io"
 )
 
 // Metadata contains the ContractData
@@ -13,23 +13,30 @@ type Metadata struct {
 
 // CreateContract returns the byte code for the contract together with the contract fields
 func (d *Metadata) CreateContract() ([]byte, [][]byte) {
-	return d.getByteCode(), d.getVariables()
+	return d.getByteCode(nil), d.getVariables()
 }
 
-func (d *Metadata) getByteCode() []byte {
+// WriteListing writes the byte code listing with the byte position of every instruction to the given writer
+func (d *Metadata) WriteListing(w io.Writer) {
+	d.getByteCode(w)
+}
+
+func (d *Metadata) getByteCode(listing io.Writer) []byte {
 	var byteCode []byte
 	bytePos := 0
 
 	for _, code := range d.Contract.Instructions {
-		bytes := generateByteCode(code, bytePos)
+		bytes := generateByteCode(code, bytePos, listing)
 		byteCode = append(byteCode, bytes...)
 		bytePos += len(bytes)
 	}
 
 	for _, function := range d.Contract.Functions {
-		fmt.Printf("%s: \n", function.Identifier)
+		if listing != nil {
+			fmt.Fprintf(listing, "%s: \n", function.Identifier)
+		}
 		for _, code := range function.Instructions {
-			bytes := generateByteCode(code, bytePos)
+			bytes := generateByteCode(code, bytePos, listing)
 			byteCode = append(byteCode, bytes...)
 			bytePos += len(bytes)
 		}
@@ -41,11 +48,13 @@ func (d *Metadata) getVariables() [][]byte {
 	return make([][]byte, d.Contract.TotalFields)
 }
 
-func generateByteCode(code *il.Instruction, bytePos int) []byte {
+func generateByteCode(code *il.Instruction, bytePos int, listing io.Writer) []byte {
 	bytes := []byte{byte(code.OpCode)}
 	if code.Operand != nil {
 		bytes = append(bytes, code.Operand.([]byte)...)
 	}
-	fmt.Printf("%d: %s %v \n", bytePos, vm.OpCodes[code.OpCode].Name, bytes)
+	if listing != nil {
+		fmt.Fprintf(listing, "%d: %s %v \n", bytePos, code.OpCode, bytes)
+	}
 	return bytes
 }
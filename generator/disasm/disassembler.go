@@ -0,0 +1,354 @@
+package disasm
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"github.com/bazo-blockchain/lazo/generator/il"
+	"math/big"
+	"sort"
+)
+
+// Instruction is a decoded instruction at a certain byte position
+type Instruction struct {
+	Pos     uint16
+	OpCode  il.OpCode
+	Operand []byte
+}
+
+// Program contains the decoded instructions and the names of the recovered functions and jump targets
+type Program struct {
+	Instructions []*Instruction
+	Functions    map[uint16]string  // entry position -> function name
+	Labels       map[uint16]string  // jump target position -> label name
+	signatures   map[[4]byte]string // function hash -> signature, known from the ABI
+	abiNames     map[uint16]bool    // entry positions of the functions named by the ABI
+	end          uint16
+}
+
+// Disassemble decodes the byte code into instructions. Function entry points are recovered from the
+// dispatcher at the beginning of the byte code. Without an ABI, the functions are named by their hash.
+func Disassemble(code []byte, abi *data.ABI) (*Program, error) {
+	program := &Program{
+		Functions:  map[uint16]string{},
+		Labels:     map[uint16]string{},
+		signatures: map[[4]byte]string{},
+		abiNames:   map[uint16]bool{},
+	}
+
+	for pos := 0; pos < len(code); {
+		instruction, err := decodeInstruction(code, pos)
+		if err != nil {
+			return program, err
+		}
+		program.Instructions = append(program.Instructions, instruction)
+		pos += len(instruction.Operand) + 1
+	}
+
+	program.end = uint16(len(code))
+	names, err := program.readABI(abi)
+	if err != nil {
+		return program, err
+	}
+	program.recoverFunctions(names)
+	program.recoverLabels()
+	program.escapeABINames()
+	return program, nil
+}
+
+func decodeInstruction(code []byte, pos int) (*Instruction, error) {
+	opCode := il.OpCode(code[pos])
+	if !opCode.IsValid() {
+		return nil, fmt.Errorf("[%d] Invalid op code %d", pos, code[pos])
+	}
+
+	size, err := operandSize(opCode, code[pos+1:])
+	if err != nil {
+		return nil, fmt.Errorf("[%d] %s: %s", pos, opCode, err)
+	}
+	end := pos + 1 + size
+	return &Instruction{
+		Pos:     uint16(pos),
+		OpCode:  opCode,
+		Operand: code[pos+1 : end],
+	}, nil
+}
+
+func operandSize(opCode il.OpCode, code []byte) (int, error) {
+	var size int
+	switch opCode.OperandKind() {
+	case il.NoOperand:
+		return 0, nil
+	case il.BoolOperand, il.CharOperand, il.ByteOperand:
+		size = 1
+	case il.UInt16Operand, il.LabelOperand:
+		size = 2
+	case il.CallOperand:
+		size = 4
+	case il.CallExtOperand:
+		size = 37
+	case il.IntOperand:
+		if len(code) > 0 && code[0] == 0 {
+			return 1, nil
+		}
+		if len(code) > 0 {
+			size = int(code[0]) + 2 // length, sign byte and value bytes
+		}
+	case il.StringOperand, il.BytesOperand:
+		if len(code) > 0 {
+			size = int(code[0]) + 1 // length and bytes
+		}
+	}
+
+	if size == 0 || size > len(code) {
+		return 0, fmt.Errorf("operand exceeds the byte code")
+	}
+	return size, nil
+}
+
+// readABI returns the function names by hash and remembers the function signatures
+func (p *Program) readABI(abi *data.ABI) (map[[4]byte]string, error) {
+	names := map[[4]byte]string{}
+	if abi == nil {
+		return names, nil
+	}
+
+	for _, function := range abi.Functions {
+		bytes, err := hex.DecodeString(trimHexPrefix(function.Hash))
+		if err != nil || len(bytes) != 4 {
+			return names, fmt.Errorf("invalid hash %s of function %s in ABI", function.Hash, function.Name)
+		}
+		var hash [4]byte
+		copy(hash[:], bytes)
+		names[hash] = function.Name
+		p.signatures[hash] = function.Signature
+	}
+	return names, nil
+}
+
+// recoverFunctions reads the dispatcher, which resets the event log and compares the function hash
+// of the call data with every public function. Functions, which are not payable, reject the call value first
+// by jumping to the shared error after the dispatcher:
+//
+//	[PushInt 0, NewArr, StoreSt log], CallData,
+//	(Dup, Push hash, NotEq, JmpTrue next, Pop, [CallVal, Push 0, NotEq, JmpTrue error],
+//	Call function, Halt)*,
+//	PushInt 0, Eq, JmpTrue constructor, Halt, PushStr, ErrHalt
+func (p *Program) recoverFunctions(names map[[4]byte]string) {
+	code := p.Instructions
+	i := 0
+	if len(code) >= 3 && matches(code, il.PushInt, il.NewArr, il.StoreSt) {
+		i = 3
+	}
+	if i >= len(code) || code[i].OpCode != il.CallData {
+		return
+	}
+	p.Functions[0] = "dispatcher"
+
+	dispatchCode := []il.OpCode{il.Dup, il.Push, il.NotEq, il.JmpTrue, il.Pop}
+	callValueCheck := []il.OpCode{il.CallVal, il.Push, il.NotEq, il.JmpTrue}
+	for i++; i+len(dispatchCode) <= len(code) && matches(code[i:], dispatchCode...); {
+		hashOperand := code[i+1].Operand
+		if len(hashOperand) != 5 {
+			break
+		}
+		var hash [4]byte
+		copy(hash[:], hashOperand[1:])
+
+		i += len(dispatchCode)
+		if i+len(callValueCheck) <= len(code) && matches(code[i:], callValueCheck...) {
+			i += len(callValueCheck)
+		}
+		if i+2 > len(code) || !matches(code[i:], il.Call, il.Halt) {
+			break
+		}
+
+		name, isABIName := names[hash]
+		if !isABIName {
+			name = "func_" + hex.EncodeToString(hash[:])
+		}
+		if address := readUInt16(code[i].Operand); p.isTarget(address) {
+			p.Functions[address] = name
+			p.abiNames[address] = isABIName
+		}
+		i += 2
+	}
+
+	if i+3 <= len(code) && matches(code[i:], il.PushInt, il.Eq, il.JmpTrue) {
+		if address := readUInt16(code[i+2].Operand); p.isTarget(address) {
+			p.Functions[address] = "constructor"
+		}
+	}
+
+	// Functions which are not part of the dispatcher. The constructor body directly follows its call.
+	for _, instruction := range code {
+		if instruction.OpCode.OperandKind() != il.CallOperand {
+			continue
+		}
+		address := readUInt16(instruction.Operand)
+		if _, ok := p.Functions[address]; ok || !p.isTarget(address) {
+			continue
+		}
+		if int(address) == int(instruction.Pos)+len(instruction.Operand)+1 {
+			p.Functions[address] = "constructor_body"
+		} else {
+			p.Functions[address] = fmt.Sprintf("func_%d", address)
+		}
+	}
+}
+
+// recoverLabels names all jump targets, which are not function entry points, in the order of their position
+func (p *Program) recoverLabels() {
+	var targets []int
+	for _, instruction := range p.Instructions {
+		if instruction.OpCode.OperandKind() != il.LabelOperand {
+			continue
+		}
+		address := readUInt16(instruction.Operand)
+		_, isFunction := p.Functions[address]
+		_, isLabel := p.Labels[address]
+		if !isFunction && !isLabel && p.isTarget(address) {
+			p.Labels[address] = ""
+			targets = append(targets, int(address))
+		}
+	}
+
+	sort.Ints(targets)
+	for i, target := range targets {
+		p.Labels[uint16(target)] = fmt.Sprintf("L%d", i)
+	}
+}
+
+// escapeABINames appends underscores to the function names of the ABI, which collide with a generated function or
+// label name (e.g. a contract function named dispatcher or L0), so that every name in the listing is unique.
+func (p *Program) escapeABINames() {
+	used := map[string]bool{}
+	var positions []int
+	for pos, name := range p.Functions {
+		if p.abiNames[pos] {
+			positions = append(positions, int(pos))
+		} else {
+			used[name] = true
+		}
+	}
+	for _, name := range p.Labels {
+		used[name] = true
+	}
+
+	sort.Ints(positions)
+	for _, pos := range positions {
+		name := p.Functions[uint16(pos)]
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+		p.Functions[uint16(pos)] = name
+	}
+}
+
+// isTarget returns true if the address is the start of an instruction or the end of the byte code.
+// Other addresses are not named, as they cannot be labelled in the listing.
+func (p *Program) isTarget(address uint16) bool {
+	if address == p.end {
+		return true
+	}
+	for _, instruction := range p.Instructions {
+		if instruction.Pos == address {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatOperand returns the human readable operand of the instruction
+func (p *Program) FormatOperand(instruction *Instruction) string {
+	operand := instruction.Operand
+	switch instruction.OpCode.OperandKind() {
+	case il.IntOperand:
+		return formatInt(operand)
+	case il.BoolOperand:
+		return fmt.Sprintf("%t", operand[0] == 1)
+	case il.CharOperand:
+		return fmt.Sprintf("%q", rune(operand[0]))
+	case il.StringOperand:
+		return fmt.Sprintf("%q", string(operand[1:]))
+	case il.BytesOperand:
+		return p.formatBytes(operand[1:])
+	case il.ByteOperand:
+		return fmt.Sprintf("%d", operand[0])
+	case il.UInt16Operand:
+		return fmt.Sprintf("%d", readUInt16(operand))
+	case il.LabelOperand:
+		return p.targetName(readUInt16(operand))
+	case il.CallOperand:
+		return fmt.Sprintf("%s %d %d", p.targetName(readUInt16(operand)), operand[2], operand[3])
+	case il.CallExtOperand:
+		return fmt.Sprintf("0x%s 0x%s %d",
+			hex.EncodeToString(operand[:32]), hex.EncodeToString(operand[32:36]), operand[36])
+	default:
+		return ""
+	}
+}
+
+func (p *Program) formatBytes(bytes []byte) string {
+	result := "0x" + hex.EncodeToString(bytes)
+	if len(bytes) == 4 {
+		var hash [4]byte
+		copy(hash[:], bytes)
+		if signature, ok := p.signatures[hash]; ok {
+			result += " ; " + signature
+		}
+	}
+	return result
+}
+
+func (p *Program) targetName(address uint16) string {
+	if name, ok := p.Functions[address]; ok {
+		return name
+	}
+	if name, ok := p.Labels[address]; ok {
+		return name
+	}
+	return fmt.Sprintf("%d", address)
+}
+
+func formatInt(operand []byte) string {
+	if len(operand) == 1 {
+		return "0"
+	}
+	value := new(big.Int).SetBytes(operand[2:])
+	if operand[1] == 1 {
+		value.Neg(value)
+	}
+	return value.String()
+}
+
+func matches(code []*Instruction, opCodes ...il.OpCode) bool {
+	for i, opCode := range opCodes {
+		if code[i].OpCode != opCode {
+			return false
+		}
+	}
+	return true
+}
+
+func readUInt16(bytes []byte) uint16 {
+	return binary.BigEndian.Uint16(bytes[:2])
+}
+
+func trimHexPrefix(value string) string {
+	if len(value) > 1 && value[0] == '0' && (value[1] == 'x' || value[1] == 'X') {
+		return value[2:]
+	}
+	return value
+}
+
+// EndLabel returns the name of a jump target at the end of the byte code, which has no instruction
+func (p *Program) EndLabel() (string, bool) {
+	if name, ok := p.Functions[p.end]; ok {
+		return name, true
+	}
+	name, ok := p.Labels[p.end]
+	return name, ok
+}
@@ -0,0 +1,239 @@
+package disasm
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/bazo-blockchain/lazo/checker"
+	"github.com/bazo-blockchain/lazo/generator"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"github.com/bazo-blockchain/lazo/generator/il"
+	"github.com/bazo-blockchain/lazo/lexer"
+	"github.com/bazo-blockchain/lazo/parser"
+	"gotest.tools/assert"
+	"strings"
+	"testing"
+)
+
+const testContract = `contract Test {
+	int x
+
+	constructor() {
+		x = 1
+	}
+
+	function int add(int a, int b) {
+		if (a > b) {
+			return a
+		}
+		return b
+	}
+
+	function void set(String s, char c, bool f) payable {
+		x = -300
+	}
+}`
+
+func compile(t *testing.T, code string) *data.Metadata {
+	p := parser.New(lexer.New(bufio.NewReader(strings.NewReader(code))))
+	program, errors := p.ParseProgram()
+	assert.Equal(t, len(errors), 0, errors)
+
+	symbolTable, errors := checker.New(program).Run()
+	assert.Equal(t, len(errors), 0, errors)
+
+	metadata, errors := generator.New(symbolTable).Run()
+	assert.Equal(t, len(errors), 0, errors)
+	return metadata
+}
+
+func disassembleContract(t *testing.T, abi *data.ABI) (*data.Metadata, *Program) {
+	metadata := compile(t, testContract)
+	code, _ := metadata.CreateContract()
+
+	program, err := Disassemble(code, abi)
+	assert.NilError(t, err)
+	return metadata, program
+}
+
+func findFunction(program *Program, name string) (uint16, bool) {
+	for pos, function := range program.Functions {
+		if function == name {
+			return pos, true
+		}
+	}
+	return 0, false
+}
+
+// Instructions
+// ------------
+
+func TestDisassembleInstructions(t *testing.T) {
+	code := []byte{
+		byte(il.PushInt), 1, 1, 5,
+		byte(il.PushInt), 0,
+		byte(il.PushBool), 1,
+		byte(il.PushChar), 'c',
+		byte(il.PushStr), 2, 'h', 'i',
+		byte(il.StoreLoc), 3,
+		byte(il.LoadFld), 1, 2,
+		byte(il.Add),
+		byte(il.Halt),
+	}
+
+	program, err := Disassemble(code, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(program.Instructions), 9)
+
+	expected := []string{"-5", "0", "true", "'c'", `"hi"`, "3", "258", "", ""}
+	for i, instruction := range program.Instructions {
+		assert.Equal(t, program.FormatOperand(instruction), expected[i])
+	}
+	assert.Equal(t, program.Instructions[6].Pos, uint16(16))
+	assert.Equal(t, program.Instructions[6].OpCode, il.LoadFld)
+}
+
+func TestDisassembleInvalidOpCode(t *testing.T) {
+	_, err := Disassemble([]byte{byte(il.Add), 200}, nil)
+	assert.Error(t, err, "[1] Invalid op code 200")
+}
+
+func TestDisassembleTruncatedOperand(t *testing.T) {
+	_, err := Disassemble([]byte{byte(il.PushStr), 5, 'a'}, nil)
+	assert.Error(t, err, "[0] pushstr: operand exceeds the byte code")
+}
+
+func TestDisassembleMissingOperand(t *testing.T) {
+	_, err := Disassemble([]byte{byte(il.Jmp), 0}, nil)
+	assert.Error(t, err, "[0] jmp: operand exceeds the byte code")
+}
+
+// Functions and labels
+// --------------------
+
+func TestDisassembleFunctionsWithoutABI(t *testing.T) {
+	metadata, program := disassembleContract(t, nil)
+
+	assert.Equal(t, program.Functions[0], "dispatcher")
+	for _, name := range []string{"constructor", "constructor_body"} {
+		_, ok := findFunction(program, name)
+		assert.Assert(t, ok, name)
+	}
+
+	for _, function := range metadata.CreateABI().Functions {
+		name := "func_" + strings.TrimPrefix(function.Hash, "0x")
+		_, ok := findFunction(program, name)
+		assert.Assert(t, ok, name)
+	}
+}
+
+func TestDisassembleFunctionsWithABI(t *testing.T) {
+	metadata := compile(t, testContract)
+	_, program := disassembleContract(t, metadata.CreateABI())
+
+	addPos, ok := findFunction(program, "add")
+	assert.Assert(t, ok)
+	_, ok = findFunction(program, "set")
+	assert.Assert(t, ok)
+
+	// Call add 2 1 in the dispatcher after rejecting the call value
+	assert.Equal(t, program.Instructions[6].OpCode, il.CallVal)
+	call := program.Instructions[10]
+	assert.Equal(t, call.OpCode, il.Call)
+	assert.Equal(t, program.FormatOperand(call), "add 2 1")
+	assert.Equal(t, program.FormatOperand(program.Instructions[2]), "0xff3da22b ; (int)add(int,int)")
+
+	// The first instruction of add
+	for _, instruction := range program.Instructions {
+		if instruction.Pos == addPos {
+			assert.Equal(t, instruction.OpCode, il.LoadLoc)
+		}
+	}
+}
+
+func TestDisassembleDispatcherWithEventLog(t *testing.T) {
+	metadata := compile(t, `contract Test {
+		event Paid(address to, int amount)
+
+		function void pay(address to, int amount) {
+			emit Paid(to, amount)
+		}
+	}`)
+	code, _ := metadata.CreateContract()
+
+	program, err := Disassemble(code, metadata.CreateABI())
+	assert.NilError(t, err)
+	assert.Equal(t, program.Functions[0], "dispatcher")
+	_, ok := findFunction(program, "pay")
+	assert.Assert(t, ok)
+	_, ok = findFunction(program, "constructor")
+	assert.Assert(t, ok)
+}
+
+func TestDisassembleCollidingFunctionNames(t *testing.T) {
+	metadata := compile(t, `contract Test {
+		function int dispatcher(int a) {
+			if (a > 0) {
+				return 1
+			}
+			return 0
+		}
+
+		function void L0() {
+		}
+
+		function void constructor_body() {
+		}
+	}`)
+	code, _ := metadata.CreateContract()
+
+	program, err := Disassemble(code, metadata.CreateABI())
+	assert.NilError(t, err)
+	assert.Equal(t, program.Functions[0], "dispatcher")
+	_, ok := findFunction(program, "dispatcher_")
+	assert.Assert(t, ok)
+	_, ok = findFunction(program, "L0_")
+	assert.Assert(t, ok)
+
+	// Without a constructor, there is no generated constructor_body function to collide with
+	_, ok = findFunction(program, "constructor_body")
+	assert.Assert(t, ok)
+}
+
+func TestDisassembleLabels(t *testing.T) {
+	_, program := disassembleContract(t, nil)
+
+	// L0 and L1 are the next function checks in the dispatcher, L2 is the shared error, to which add and the
+	// constructor jump, if they receive Bazo coins. L3 is the end of the if statement in add.
+	assert.Equal(t, len(program.Labels), 4)
+	assert.Equal(t, program.FormatOperand(program.Instructions[4]), "L0")
+	assert.Equal(t, program.FormatOperand(program.Instructions[9]), "L2")
+	assert.Equal(t, program.Labels[program.Instructions[12].Pos], "L0")
+	assert.Equal(t, program.Labels[program.Instructions[19].Pos], "L1")
+	assert.Equal(t, program.Labels[program.Instructions[23].Pos], "L2")
+	assert.Equal(t, program.Instructions[23].OpCode, il.PushStr)
+}
+
+func TestDisassembleInvalidABI(t *testing.T) {
+	abi := &data.ABI{Functions: []*data.FunctionABI{{Name: "test", Hash: "0x12"}}}
+	_, err := Disassemble([]byte{byte(il.Halt)}, abi)
+	assert.Error(t, err, "invalid hash 0x12 of function test in ABI")
+}
+
+// Listing
+// -------
+
+func TestListing(t *testing.T) {
+	metadata := compile(t, testContract)
+	_, program := disassembleContract(t, metadata.CreateABI())
+
+	var buffer bytes.Buffer
+	program.Write(&buffer)
+	listing := buffer.String()
+
+	assert.Assert(t, strings.HasPrefix(listing, "dispatcher:\n     0  calldata\n"))
+	assert.Assert(t, strings.Contains(listing, "\nadd:\n"))
+	assert.Assert(t, strings.Contains(listing, "\nset:\n"))
+	assert.Assert(t, strings.Contains(listing, "\nL3:\n"))
+	assert.Assert(t, strings.Contains(listing, "jmptrue    constructor\n"))
+	assert.Assert(t, strings.Contains(listing, "pushint    -300\n"))
+}
@@ -0,0 +1,2 @@
+// Package disasm decodes Bazo byte code back into a labelled instruction listing.
+package disasm
@@ -0,0 +1,32 @@
+package disasm
+
+import (
+	"fmt"
+	"io"
+)
+
+// Write writes the listing with the byte position, the mnemonic and the decoded operand of every instruction.
+// Function entry points and jump targets are preceded by their name.
+func (p *Program) Write(w io.Writer) {
+	for i, instruction := range p.Instructions {
+		if name, ok := p.Functions[instruction.Pos]; ok {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s:\n", name)
+		} else if name, ok := p.Labels[instruction.Pos]; ok {
+			fmt.Fprintf(w, "%s:\n", name)
+		}
+
+		operand := p.FormatOperand(instruction)
+		if operand == "" {
+			fmt.Fprintf(w, "%6d  %s\n", instruction.Pos, instruction.OpCode)
+		} else {
+			fmt.Fprintf(w, "%6d  %-10s %s\n", instruction.Pos, instruction.OpCode, operand)
+		}
+	}
+
+	if name, ok := p.EndLabel(); ok {
+		fmt.Fprintf(w, "%s:\n", name)
+	}
+}
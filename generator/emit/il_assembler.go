@@ -1,6 +1,7 @@
 package emit
 
 import (
+	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
 	"github.com/bazo-blockchain/lazo/generator/il"
 	"github.com/bazo-blockchain/lazo/generator/util"
@@ -69,9 +70,13 @@ func (a *ILAssembler) Emit(opCode il.OpCode) {
 // --------------------------------------------------
 
 // PushInt is a helper function that emits byte code to push an integer to the stack.
-func (a *ILAssembler) PushInt(value *big.Int) {
+// Returns an error, if the integer is too large for the instruction.
+func (a *ILAssembler) PushInt(value *big.Int) error {
 	sign := util.GetSignByte(value)
 	bytes := value.Bytes()
+	if len(bytes) > symbol.MaxPushLength {
+		return fmt.Errorf("Integer with %d bytes exceeds the maximum of %d bytes", len(bytes), symbol.MaxPushLength)
+	}
 	total := byte(len(bytes))
 
 	var operand []byte
@@ -81,7 +86,8 @@ func (a *ILAssembler) PushInt(value *big.Int) {
 		operand = append([]byte{total, sign}, bytes...)
 	}
 
-	a.addInstruction(il.PushInt, operand, byte(len(operand)))
+	a.addInstruction(il.PushInt, operand, len(operand))
+	return nil
 }
 
 // PushBool is a helper function that emits byte code to push a boolean to the stack.
@@ -95,12 +101,17 @@ func (a *ILAssembler) PushBool(value bool) {
 	a.addInstruction(il.PushBool, []byte{byteVal}, 1)
 }
 
-// PushString is a helper that emits byte code to push a string to the stack
-func (a *ILAssembler) PushString(value string) {
+// PushString is a helper that emits byte code to push a string to the stack.
+// Returns an error, if the string is too long for the instruction.
+func (a *ILAssembler) PushString(value string) error {
 	bytes := []byte(value)
+	if len(bytes) > symbol.MaxPushLength {
+		return fmt.Errorf("String with %d bytes exceeds the maximum of %d bytes", len(bytes), symbol.MaxPushLength)
+	}
 	total := byte(len(bytes))
 	operand := append([]byte{total}, bytes...)
-	a.addInstruction(il.PushStr, operand, byte(len(operand)))
+	a.addInstruction(il.PushStr, operand, len(operand))
+	return nil
 }
 
 // PushCharacter is a helper that emits byte code to push a character to the stack
@@ -109,6 +120,17 @@ func (a *ILAssembler) PushCharacter(value rune) {
 	a.addInstruction(il.PushChar, operand, 1)
 }
 
+// PushBytes is a helper that emits byte code to push the raw bytes to the stack
+func (a *ILAssembler) PushBytes(value []byte) {
+	operand := append([]byte{byte(len(value))}, value...)
+	a.addInstruction(il.Push, operand, len(operand))
+}
+
+// Roll moves the element at the given depth + 1 to the top of the stack (e.g. Roll 0 is equal to Swap)
+func (a *ILAssembler) Roll(depth byte) {
+	a.addInstruction(il.Roll, []byte{depth}, 1)
+}
+
 // PushNil pushes empty bytes slice
 func (a *ILAssembler) PushNil() {
 	a.addInstruction(il.Push, []byte{0}, 1)
@@ -120,7 +142,7 @@ func (a *ILAssembler) PushFuncHash(hash [4]byte) {
 	operand[0] = 4
 	copy(operand[1:], hash[:])
 
-	a.addInstruction(il.Push, operand, byte(len(operand)))
+	a.addInstruction(il.Push, operand, len(operand))
 }
 
 // Jmp is a helper that adds a JMP instruction to the byte code
@@ -192,7 +214,7 @@ func (a *ILAssembler) LoadField(index uint16) {
 	a.addInstruction(il.LoadFld, util.GetBytesFromUInt16(index), 2)
 }
 
-func (a *ILAssembler) addInstruction(opCode il.OpCode, operand interface{}, operandSize byte) {
+func (a *ILAssembler) addInstruction(opCode il.OpCode, operand interface{}, operandSize int) {
 	a.instructions = append(a.instructions, &il.Instruction{
 		OpCode:  opCode,
 		Operand: operand,
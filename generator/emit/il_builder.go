@@ -6,6 +6,7 @@ import (
 	"github.com/bazo-blockchain/lazo/generator/data"
 	"github.com/bazo-blockchain/lazo/generator/il"
 	"github.com/bazo-blockchain/lazo/generator/util"
+	"github.com/bazo-blockchain/lazo/parser/node"
 	"strings"
 )
 
@@ -15,6 +16,7 @@ type ILBuilder struct {
 	symbolTable       *symbol.SymbolTable
 	Metadata          *data.Metadata
 	functionData      map[*symbol.FunctionSymbol]*data.FunctionData
+	eventData         map[*symbol.EventSymbol]*data.EventData
 	functionPositions map[*symbol.FunctionSymbol]uint16
 	Errors            []error
 }
@@ -25,6 +27,7 @@ func NewILBuilder(symbolTable *symbol.SymbolTable) *ILBuilder {
 		symbolTable:       symbolTable,
 		Metadata:          &data.Metadata{},
 		functionData:      map[*symbol.FunctionSymbol]*data.FunctionData{},
+		eventData:         map[*symbol.EventSymbol]*data.EventData{},
 		functionPositions: map[*symbol.FunctionSymbol]uint16{},
 	}
 	builder.generateMetadata()
@@ -51,6 +54,11 @@ func (b *ILBuilder) GetFunctionData(function *symbol.FunctionSymbol) *data.Funct
 	return b.functionData[function]
 }
 
+// GetEventData returns the event data for a given event
+func (b *ILBuilder) GetEventData(event *symbol.EventSymbol) *data.EventData {
+	return b.eventData[event]
+}
+
 // SetFunctionPos sets the functions position
 func (b *ILBuilder) SetFunctionPos(symbol *symbol.FunctionSymbol, pos uint16) {
 	b.functionPositions[symbol] = pos
@@ -78,9 +86,17 @@ func (b *ILBuilder) registerContract(contract *symbol.ContractSymbol) {
 }
 
 func (b *ILBuilder) registerFunction(function *symbol.FunctionSymbol) {
+	signature := createFuncSignature(function)
 	functionData := &data.FunctionData{
 		Identifier: function.Identifier(),
-		Hash:       util.CreateFuncHash(createFuncSignature(function)),
+		Signature:  signature,
+		Parameters: createParameterData(function.Parameters),
+		Hash:       util.CreateFuncHash(signature),
+		Public:     function.IsPublic(),
+		Payable:    function.Payable,
+	}
+	for _, returnType := range function.ReturnTypes {
+		functionData.ReturnTypes = append(functionData.ReturnTypes, returnType.Identifier())
 	}
 	b.Metadata.Contract.Functions = append(b.Metadata.Contract.Functions, functionData)
 	b.functionData[function] = functionData
@@ -89,11 +105,72 @@ func (b *ILBuilder) registerFunction(function *symbol.FunctionSymbol) {
 func (b *ILBuilder) fixContract(contract *symbol.ContractSymbol) {
 	contractData := b.Metadata.Contract
 	contractData.TotalFields = uint16(len(contract.Fields))
+	contractData.Fields = createFieldData(contract.Fields)
+
+	if contract.Constructor != nil {
+		contractData.ConstructorParameters = createParameterData(contract.Constructor.Parameters)
+	}
+
+	// Keep the declaration order of the structs and enums
+	contractNode := b.symbolTable.GetNodeBySymbol(contract).(*node.ContractNode)
+	for _, structNode := range contractNode.Structs {
+		structType := b.symbolTable.GlobalScope.Structs[structNode.Name]
+		contractData.Structs = append(contractData.Structs, &data.StructData{
+			Identifier: structType.Identifier(),
+			Fields:     createFieldData(structType.Fields),
+		})
+	}
+
+	for _, enumNode := range contractNode.Enums {
+		enumType := b.symbolTable.GlobalScope.Enums[enumNode.Name]
+		enumData := &data.EnumData{Identifier: enumType.Identifier()}
+		for _, member := range enumType.Members {
+			enumData.Members = append(enumData.Members, member.Identifier())
+		}
+		contractData.Enums = append(contractData.Enums, enumData)
+	}
+
+	for _, event := range contract.Events {
+		signature := createEventSignature(event)
+		eventData := &data.EventData{
+			Identifier: event.Identifier(),
+			Signature:  signature,
+			Parameters: createParameterData(event.Parameters),
+			Hash:       util.CreateFuncHash(signature),
+		}
+		contractData.Events = append(contractData.Events, eventData)
+		b.eventData[event] = eventData
+	}
+	if len(contractData.Events) > 0 {
+		contractData.TotalFields++
+	}
 }
 
 // Helper Functions
 // ----------------
 
+func createFieldData(fields []*symbol.FieldSymbol) []*data.VariableData {
+	var fieldData []*data.VariableData
+	for _, field := range fields {
+		fieldData = append(fieldData, &data.VariableData{
+			Identifier: field.Identifier(),
+			Type:       field.Type.Identifier(),
+		})
+	}
+	return fieldData
+}
+
+func createParameterData(parameters []*symbol.ParameterSymbol) []*data.VariableData {
+	var parameterData []*data.VariableData
+	for _, parameter := range parameters {
+		parameterData = append(parameterData, &data.VariableData{
+			Identifier: parameter.Identifier(),
+			Type:       parameter.Type.Identifier(),
+		})
+	}
+	return parameterData
+}
+
 func createFuncSignature(function *symbol.FunctionSymbol) string {
 	var sb strings.Builder
 
@@ -119,3 +196,20 @@ func createFuncSignature(function *symbol.FunctionSymbol) string {
 
 	return sb.String()
 }
+
+// createEventSignature returns the event name with the parameter types, e.g. Transfer(address,address,int)
+func createEventSignature(event *symbol.EventSymbol) string {
+	var sb strings.Builder
+
+	sb.WriteString(event.ID)
+	sb.WriteRune('(')
+	for i, p := range event.Parameters {
+		if i > 0 {
+			sb.WriteRune(',')
+		}
+		sb.WriteString(p.Type.Identifier())
+	}
+	sb.WriteRune(')')
+
+	return sb.String()
+}
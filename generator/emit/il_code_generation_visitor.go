@@ -3,6 +3,7 @@ package emit
 import (
 	"fmt"
 	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/generator/data"
 	"github.com/bazo-blockchain/lazo/generator/il"
 	"github.com/bazo-blockchain/lazo/lexer/token"
@@ -13,12 +14,20 @@ import (
 // ILCodeGenerationVisitor generates the IL Code
 type ILCodeGenerationVisitor struct {
 	node.AbstractVisitor
-	symbolTable *symbol.SymbolTable
-	ilBuilder   *ILBuilder
-	function    *symbol.FunctionSymbol
-	assembler   *ILAssembler
-	bytePos     uint16
-	Errors      []error
+	symbolTable  *symbol.SymbolTable
+	ilBuilder    *ILBuilder
+	function     *symbol.FunctionSymbol
+	tempVars     int
+	modifier     *symbol.FunctionSymbol
+	modifierBase int
+	isModified   bool
+	returnLabel  Label
+	returnVars   []byte
+	targetKeys   map[*node.ElementAccessNode]byte
+	notPayable   Label
+	assembler    *ILAssembler
+	bytePos      uint16
+	Errors       []error
 }
 
 // NewCodeGenerationVisitor creates a new CodeGenerationVisitor
@@ -39,6 +48,7 @@ func (v *ILCodeGenerationVisitor) VisitContractNode(node *node.ContractNode) {
 	contractData := v.ilBuilder.Metadata.Contract
 
 	v.assembler = NewILAssembler(&v.bytePos)
+	v.notPayable = v.assembler.CreateLabel()
 	v.generateABI(contractSymbol, contractData)
 	v.generateConstructorIL(node, contractSymbol, contractData)
 	v.generateFunctionIL(node, contractSymbol, contractData)
@@ -46,9 +56,20 @@ func (v *ILCodeGenerationVisitor) VisitContractNode(node *node.ContractNode) {
 
 func (v *ILCodeGenerationVisitor) generateABI(contractSymbol *symbol.ContractSymbol,
 	contractData *data.ContractData) {
+	// The event log only contains the events of the current transaction
+	if len(contractData.Events) > 0 {
+		v.assembler.PushInt(big.NewInt(0))
+		v.assembler.Emit(il.NewArr)
+		v.assembler.StoreState(byte(contractData.EventLogIndex()))
+	}
+
 	v.assembler.Emit(il.CallData)
 
 	for i, functionData := range contractData.Functions {
+		// Private and internal functions cannot be called by transactions
+		if !functionData.Public {
+			continue
+		}
 		v.assembler.Emit(il.Dup)
 		v.assembler.PushFuncHash(functionData.Hash)
 		v.assembler.Emit(il.NotEq)
@@ -56,6 +77,9 @@ func (v *ILCodeGenerationVisitor) generateABI(contractSymbol *symbol.ContractSym
 		checkNextFuncLabel := v.assembler.CreateLabel()
 		v.assembler.JmpTrue(checkNextFuncLabel)
 		v.assembler.Emit(il.Pop) // Remove function hash from top of call stack
+		if !functionData.Payable {
+			v.emitRejectCallValue()
+		}
 		v.assembler.CallFunc(contractSymbol.Functions[i])
 		v.assembler.Emit(il.Halt)
 
@@ -72,13 +96,20 @@ func (v *ILCodeGenerationVisitor) generateConstructorIL(node *node.ContractNode,
 	v.assembler.JmpTrue(constructorLabel)
 	v.assembler.Emit(il.Halt)
 
+	// The entry points, which are not payable, jump to the shared error behind the dispatcher
+	v.emitNotPayableError()
+
 	v.assembler.SetLabel(constructorLabel)
+	if contractSymbol.Constructor == nil || !contractSymbol.Constructor.Payable {
+		v.emitRejectCallValue()
+	}
 	for _, variable := range node.Fields {
 		variable.Accept(v.ConcreteVisitor)
 	}
 
 	if node.Constructor != nil {
 		v.function = contractSymbol.Constructor
+		v.tempVars = 0
 		v.assembler.CallFunc(contractSymbol.Constructor)
 		v.ilBuilder.SetFunctionPos(v.function, v.bytePos)
 		node.Constructor.Accept(v)
@@ -92,13 +123,6 @@ func (v *ILCodeGenerationVisitor) VisitFieldNode(node *node.FieldNode) {
 	v.AbstractVisitor.VisitFieldNode(node)
 	targetType := v.symbolTable.FindTypeByNode(node.Type)
 
-	if arrayType, ok := targetType.(*symbol.ArrayTypeSymbol); ok {
-		if _, ok := arrayType.ElementType.(*symbol.BasicTypeSymbol); !ok {
-			v.reportError(node, unsupportedArrayNestingMsg)
-			return
-		}
-	}
-
 	if node.Expression == nil {
 		v.pushDefault(targetType)
 	}
@@ -111,6 +135,7 @@ func (v *ILCodeGenerationVisitor) generateFunctionIL(node *node.ContractNode, co
 	contractData *data.ContractData) {
 	for i, function := range node.Functions {
 		v.function = contractSymbol.Functions[i]
+		v.tempVars = 0
 		funcData := contractData.Functions[i]
 
 		v.ilBuilder.SetFunctionPos(v.function, v.bytePos)
@@ -134,7 +159,7 @@ func (v *ILCodeGenerationVisitor) VisitVariableNode(node *node.VariableNode) {
 		v.pushDefault(targetType)
 	}
 
-	index := v.function.GetVarIndex(node.Identifier)
+	index := v.getLocalIndex(node.Identifier)
 	v.assembler.StoreLocal(byte(index))
 }
 
@@ -143,38 +168,15 @@ func (v *ILCodeGenerationVisitor) VisitMultiVariableNode(node *node.MultiVariabl
 	v.AbstractVisitor.VisitMultiVariableNode(node)
 
 	for i := len(node.Identifiers) - 1; i >= 0; i-- {
-		index := v.function.GetVarIndex(node.Identifiers[i])
+		index := v.getLocalIndex(node.Identifiers[i])
 		v.assembler.StoreLocal(byte(index))
 	}
 }
 
 // VisitAssignmentStatementNode generates the IL Code for an assignment
 func (v *ILCodeGenerationVisitor) VisitAssignmentStatementNode(assignNode *node.AssignmentStatementNode) {
-	switch assignNode.Left.(type) {
-	case *node.BasicDesignatorNode:
-		assignNode.Right.Accept(v)
-		decl := v.symbolTable.GetDeclByDesignator(assignNode.Left)
-		v.storeVariable(decl)
-
-	case *node.ElementAccessNode: // arr[0] or map["key"]
-		elementAccess, _ := assignNode.Left.(*node.ElementAccessNode)
-		assignNode.Right.Accept(v)
-		v.visitArrayElementAssignment(elementAccess)
-
-	case *node.MemberAccessNode: // this.field or struct.field
-		memberAccessNode := assignNode.Left.(*node.MemberAccessNode)
-		memberAccessNode.Designator.Accept(v)
-		assignNode.Right.Accept(v)
-		fieldSymbol := v.symbolTable.GetDeclByDesignator(memberAccessNode)
-		v.storeVariable(fieldSymbol)
-
-		// Struct is a value type, therefore update struct explicitly.
-		if _, ok := fieldSymbol.Scope().(*symbol.StructTypeSymbol); ok {
-			v.updateStruct(memberAccessNode.Designator)
-		}
-	default:
-		v.reportError(assignNode, fmt.Sprintf("Invalid assignment %v", assignNode.Left))
-	}
+	assignNode.Right.Accept(v)
+	v.storeDesignator(assignNode.Left, assignNode)
 }
 
 // VisitMultiAssignmentStatementNode generates the IL Code for a multi-assignment
@@ -182,88 +184,141 @@ func (v *ILCodeGenerationVisitor) VisitMultiAssignmentStatementNode(assignNode *
 	assignNode.FuncCall.Accept(v)
 
 	for i := len(assignNode.Designators) - 1; i >= 0; i-- {
-		switch assignNode.Designators[i].(type) {
-		case *node.BasicDesignatorNode:
-			decl := v.symbolTable.GetDeclByDesignator(assignNode.Designators[i])
-			v.storeVariable(decl)
-
-		case *node.ElementAccessNode:
-			elementAccess, _ := assignNode.Designators[i].(*node.ElementAccessNode)
-			v.visitArrayElementAssignment(elementAccess)
-
-		case *node.MemberAccessNode:
-			memberAccessNode := assignNode.Designators[i].(*node.MemberAccessNode)
-			memberAccessNode.Designator.Accept(v)
-
-			fieldSymbol := v.symbolTable.GetDeclByDesignator(memberAccessNode)
-			_, isStructField := fieldSymbol.Scope().(*symbol.StructTypeSymbol)
-			if isStructField {
-				v.assembler.Emit(il.Swap) // Swap field value and struct to match the StoreFld opcode
-			}
-			v.storeVariable(fieldSymbol)
-
-			// Struct is a value type in VM. Therefore, struct variable should be updated explicitly.
-			if isStructField {
-				v.updateStruct(memberAccessNode.Designator)
-			}
-		default:
-			v.reportError(assignNode, fmt.Sprintf("Invalid assignment %v", assignNode.Designators[i]))
-		}
+		v.storeDesignator(assignNode.Designators[i], assignNode)
 	}
 }
 
-func (v *ILCodeGenerationVisitor) visitArrayElementAssignment(elementAccess *node.ElementAccessNode) {
-	elementAccess.Expression.Accept(v)
-	elementAccess.Designator.Accept(v)
+// storeDesignator stores the value on top of the stack in the variable, element or field of the designator.
+// Arrays, maps and structs are value types in the VM. Therefore, the updated container is stored
+// in its own designator again until a variable is reached (e.g. a[0].b[1] = x updates a[0].b, a[0] and a).
+// The keys of the containers are evaluated once beforehand, since each container is loaded and stored again.
+func (v *ILCodeGenerationVisitor) storeDesignator(designator node.DesignatorNode, errorNode node.Node) {
+	switch designator.(type) {
+	case *node.ElementAccessNode:
+		v.storeTargetKeys(designator.(*node.ElementAccessNode).Designator)
+	case *node.MemberAccessNode:
+		v.storeTargetKeys(designator.(*node.MemberAccessNode).Designator)
+	}
+	v.storeTarget(designator, errorNode)
+	v.targetKeys = nil
+}
 
-	designatorType := v.symbolTable.GetTypeByExpression(elementAccess.Designator)
-	if v.isArrayType(designatorType) {
-		v.assembler.Emit(il.ArrInsert)
-	} else if v.isMapType(designatorType) {
-		v.assembler.Emit(il.MapSetVal)
-	} else {
-		panic(unsupportedElementAccessMsg)
+// storeTargetKeys evaluates the keys of the element accesses in the designator (e.g. a and b in m[a][b].c)
+// into temporary variables, which are loaded instead of evaluating the keys again.
+func (v *ILCodeGenerationVisitor) storeTargetKeys(designator node.DesignatorNode) {
+	switch designator.(type) {
+	case *node.ElementAccessNode:
+		elementAccess := designator.(*node.ElementAccessNode)
+		v.storeTargetKeys(elementAccess.Designator)
+		if _, ok := v.targetKeys[elementAccess]; ok {
+			return
+		}
+		if v.targetKeys == nil {
+			v.targetKeys = map[*node.ElementAccessNode]byte{}
+		}
+		index := v.createTempVariable()
+		elementAccess.Expression.Accept(v)
+		v.assembler.StoreLocal(index)
+		v.targetKeys[elementAccess] = index
+	case *node.MemberAccessNode:
+		v.storeTargetKeys(designator.(*node.MemberAccessNode).Designator)
 	}
+}
 
-	targetDecl := v.symbolTable.GetDeclByDesignator(elementAccess.Designator)
-	if v.isArrayType(targetDecl) || v.isMapType(targetDecl) || v.isStructType(targetDecl.Scope()) {
-		v.reportError(elementAccess, "Multiple dereferences on value types are not supported")
+// loadKey pushes the key of the element access, which is either evaluated or loaded from its temporary variable.
+func (v *ILCodeGenerationVisitor) loadKey(elementAccess *node.ElementAccessNode) {
+	if index, ok := v.targetKeys[elementAccess]; ok {
+		v.assembler.LoadLocal(index)
 		return
 	}
-
-	// Workaround for single dereference
-	// e.g. a[0] = 2 --> returns a new array (value type). It should be stored in the variable a.
-	v.storeVariable(targetDecl)
+	elementAccess.Expression.Accept(v)
 }
 
-// Struct is a value type in VM.
-// Therefore, every struct field assignment should update its parent explicitly (e.g. struct.field = x)
-func (v *ILCodeGenerationVisitor) updateStruct(targetStruct node.DesignatorNode) {
-	// e.g. this.targetStruct.field or grandParent.targetStruct.field
-	if targetStructMemberAccessNode, ok := targetStruct.(*node.MemberAccessNode); ok {
-		if targetStructMemberAccessNode.Designator.String() != symbol.This {
-			targetStructMemberAccessNode.Designator.Accept(v) // load grand parent struct on stack
-			v.assembler.Emit(il.Swap)                         // Swap grand parent struct and element value
+// storeTarget stores the value on top of the stack in the designator and the updated containers in their parents.
+func (v *ILCodeGenerationVisitor) storeTarget(designator node.DesignatorNode, errorNode node.Node) {
+	switch designator.(type) {
+	case *node.BasicDesignatorNode:
+		decl := v.symbolTable.GetDeclByDesignator(designator)
+		v.storeVariable(decl)
+
+	case *node.ElementAccessNode: // arr[0] or map["key"]
+		elementAccess := designator.(*node.ElementAccessNode)
+		v.loadKey(elementAccess)
+		v.loadTarget(elementAccess.Designator)
+
+		designatorType := v.symbolTable.GetTypeByExpression(elementAccess.Designator)
+		if v.isArrayType(designatorType) {
+			v.assembler.Emit(il.ArrInsert)
+		} else if v.isMapType(designatorType) {
+			v.assembler.Emit(il.MapSetVal)
+		} else {
+			panic(unsupportedElementAccessMsg)
 		}
+		v.storeTarget(elementAccess.Designator, errorNode)
 
-		targetStructFieldSymbol := v.symbolTable.GetDeclByDesignator(targetStructMemberAccessNode)
-		v.storeVariable(targetStructFieldSymbol)
+	case *node.MemberAccessNode: // this.field or struct.field
+		memberAccessNode := designator.(*node.MemberAccessNode)
+		fieldSymbol := v.symbolTable.GetDeclByDesignator(memberAccessNode)
 
-		// Struct is a value type, therefore update struct explicitly.
-		if _, ok := targetStructFieldSymbol.Scope().(*symbol.StructTypeSymbol); ok {
-			v.updateStruct(targetStructMemberAccessNode.Designator)
+		if _, isStructField := fieldSymbol.Scope().(*symbol.StructTypeSymbol); isStructField {
+			v.loadTarget(memberAccessNode.Designator)
+			v.assembler.Emit(il.Swap) // Swap field value and struct to match the StoreFld opcode
+			v.storeVariable(fieldSymbol)
+			v.storeTarget(memberAccessNode.Designator, errorNode)
+			return
 		}
-		return
-	} else if _, ok := targetStruct.(*node.ElementAccessNode); ok {
-		// Since array and map are value types, they are not updated automatically.
-		// 		m['a'] = new Person(1000)
-		//		m['a'].balance = 1001
-		v.reportError(targetStruct, "Updating struct value type in array/map is not supported")
-		return
+		v.storeVariable(fieldSymbol)
+	default:
+		v.reportError(errorNode, fmt.Sprintf("Invalid assignment %v", designator))
 	}
+}
 
-	// targetStruct.field = x
-	v.storeVariable(v.symbolTable.GetDeclByDesignator(targetStruct))
+// loadTarget pushes the array, map or struct of the designator, which is about to be updated.
+// Unlike a read access, a missing map entry is default initialized, so that nested collections
+// can be filled with an assignment (e.g. m[1][2] = 3).
+func (v *ILCodeGenerationVisitor) loadTarget(designator node.DesignatorNode) {
+	switch designator.(type) {
+	case *node.ElementAccessNode:
+		elementAccess := designator.(*node.ElementAccessNode)
+		if !v.isMapType(v.symbolTable.GetTypeByExpression(elementAccess.Designator)) {
+			v.loadKey(elementAccess)
+			v.loadTarget(elementAccess.Designator)
+			v.assembler.Emit(il.ArrAt)
+			return
+		}
+
+		defaultLabel := v.assembler.CreateLabel()
+		endLabel := v.assembler.CreateLabel()
+
+		// The map is loaded once and duplicated for the lookup
+		v.loadTarget(elementAccess.Designator)
+		v.assembler.Emit(il.Dup)
+		v.loadKey(elementAccess)
+		v.assembler.Emit(il.Swap)
+		v.assembler.Emit(il.MapHasKey)
+		v.assembler.JmpFalse(defaultLabel)
+
+		v.loadKey(elementAccess)
+		v.assembler.Emit(il.Swap)
+		v.assembler.Emit(il.MapGetVal)
+		v.assembler.Jmp(endLabel)
+
+		v.assembler.SetLabel(defaultLabel)
+		v.assembler.Emit(il.Pop)
+		v.pushDefault(v.symbolTable.GetTypeByExpression(elementAccess))
+		v.assembler.SetLabel(endLabel)
+		return
+
+	case *node.MemberAccessNode:
+		memberAccessNode := designator.(*node.MemberAccessNode)
+		fieldSymbol := v.symbolTable.GetDeclByDesignator(memberAccessNode)
+		if _, isStructField := fieldSymbol.Scope().(*symbol.StructTypeSymbol); isStructField {
+			v.loadTarget(memberAccessNode.Designator)
+			v.loadVariable(fieldSymbol)
+			return
+		}
+	}
+	designator.Accept(v)
 }
 
 // VisitShorthandAssignmentNode generates IL code for a shorthand assignment
@@ -281,9 +336,18 @@ func (v *ILCodeGenerationVisitor) VisitShorthandAssignmentNode(shorthandAssignme
 			Right:        shorthandAssignment.Expression,
 		},
 	}
+	// The designator is read and written, but its keys are evaluated only once
+	v.storeTargetKeys(shorthandAssignment.Designator)
 	v.VisitAssignmentStatementNode(assignment)
 }
 
+// builtInFieldOpCodes contains the OpCodes, which push the built-in fields provided by the VM
+var builtInFieldOpCodes = map[string]il.OpCode{
+	symbol.Msg + ".sender":                il.Caller,
+	symbol.ContractNamespace + ".address": il.Address,
+	symbol.ContractNamespace + ".owner":   il.Issuer,
+}
+
 // VisitMemberAccessNode generates the IL Code for a member access node
 func (v *ILCodeGenerationVisitor) VisitMemberAccessNode(node *node.MemberAccessNode) {
 	if node.Designator.String() == symbol.This {
@@ -292,6 +356,17 @@ func (v *ILCodeGenerationVisitor) VisitMemberAccessNode(node *node.MemberAccessN
 		return
 	}
 
+	if namespace, ok := v.symbolTable.GetDeclByDesignator(node.Designator).(*symbol.NamespaceSymbol); ok {
+		v.visitBuiltInField(node, namespace)
+		return
+	}
+
+	// Enum members are lowered to their index in the enum declaration
+	if enumType, ok := v.symbolTable.GetDeclByDesignator(node.Designator).(*symbol.EnumTypeSymbol); ok {
+		v.assembler.PushInt(big.NewInt(int64(enumType.GetMemberIndex(node.Identifier))))
+		return
+	}
+
 	node.Designator.Accept(v)
 
 	designatorDecl := v.symbolTable.GetTypeByExpression(node.Designator)
@@ -305,9 +380,26 @@ func (v *ILCodeGenerationVisitor) VisitMemberAccessNode(node *node.MemberAccessN
 	v.loadVariable(decl)
 }
 
+// visitBuiltInField pushes the built-in field (e.g. msg.sender) provided by the VM.
+func (v *ILCodeGenerationVisitor) visitBuiltInField(node *node.MemberAccessNode, namespace *symbol.NamespaceSymbol) {
+	field := namespace.Identifier() + "." + node.Identifier
+	if opCode, ok := builtInAmountOpCodes[field]; ok {
+		v.assembler.Emit(opCode)
+		v.emitAmountToInt()
+		return
+	}
+
+	opCode, ok := builtInFieldOpCodes[field]
+	if !ok {
+		v.reportError(node, fmt.Sprintf("%s is not supported by the VM", node))
+		return
+	}
+	v.assembler.Emit(opCode)
+}
+
 // VisitElementAccessNode generates the il code for an array element access
 func (v *ILCodeGenerationVisitor) VisitElementAccessNode(node *node.ElementAccessNode) {
-	node.Expression.Accept(v)
+	v.loadKey(node)
 	node.Designator.Accept(v)
 
 	designatorType := v.symbolTable.GetTypeByExpression(node.Designator)
@@ -322,18 +414,81 @@ func (v *ILCodeGenerationVisitor) VisitElementAccessNode(node *node.ElementAcces
 
 // VisitDeleteStatementNode generates the il code for deleting a map entry
 func (v *ILCodeGenerationVisitor) VisitDeleteStatementNode(node *node.DeleteStatementNode) {
-	node.Element.Expression.Accept(v) // load key
-	node.Element.Designator.Accept(v) // load map
+	v.storeTargetKeys(node.Element.Designator)
+	node.Element.Expression.Accept(v)     // load key
+	v.loadTarget(node.Element.Designator) // load map
 	v.assembler.Emit(il.MapRemove)
 
-	// Update designator variable with the updated map
-	designatorVar := v.symbolTable.GetDeclByDesignator(node.Element.Designator)
-	v.storeVariable(designatorVar)
+	// Update designator with the updated map
+	v.storeDesignator(node.Element.Designator, node)
+}
+
+// VisitRequireStatementNode generates the IL Code, which aborts the transaction with the message
+// if the condition is false
+func (v *ILCodeGenerationVisitor) VisitRequireStatementNode(node *node.RequireStatementNode) {
+	v.abortUnless(node.Condition, func() {
+		node.Message.Accept(v)
+	})
+}
+
+// VisitAssertStatementNode generates the IL Code, which aborts the transaction if the condition is false.
+// The error message contains the position of the assertion.
+func (v *ILCodeGenerationVisitor) VisitAssertStatementNode(node *node.AssertStatementNode) {
+	v.abortUnless(node.Condition, func() {
+		v.assembler.PushString(fmt.Sprintf("assertion failed at %s", node.Pos()))
+	})
+}
+
+// VisitRevertStatementNode generates the IL Code, which aborts the transaction with the message
+func (v *ILCodeGenerationVisitor) VisitRevertStatementNode(node *node.RevertStatementNode) {
+	node.Message.Accept(v)
+	v.assembler.Emit(il.ErrHalt)
+}
+
+// VisitEmitStatementNode appends the event to the event log.
+// An event is stored as array, which contains the event hash followed by the arguments.
+func (v *ILCodeGenerationVisitor) VisitEmitStatementNode(node *node.EmitStatementNode) {
+	contractData := v.ilBuilder.Metadata.Contract
+	event := v.symbolTable.GetDeclByDesignator(node.Event).(*symbol.EventSymbol)
+	eventHash := v.ilBuilder.GetEventData(event).Hash
+
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.NewArr)
+	v.assembler.PushBytes(eventHash[:])
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.ArrAppend)
+
+	for _, arg := range node.Args {
+		arg.Accept(v)
+		v.assembler.Emit(il.Swap)
+		v.assembler.Emit(il.ArrAppend)
+	}
+
+	v.assembler.LoadState(byte(contractData.EventLogIndex()))
+	v.assembler.Emit(il.ArrAppend)
+	v.assembler.StoreState(byte(contractData.EventLogIndex()))
+}
+
+// abortUnless halts the VM with an error if the condition is false.
+// The error message is pushed last, so that it is on top of the stack.
+func (v *ILCodeGenerationVisitor) abortUnless(condition node.ExpressionNode, pushMessage func()) {
+	endLabel := v.assembler.CreateLabel()
+
+	condition.Accept(v)
+	v.assembler.JmpTrue(endLabel)
+	pushMessage()
+	v.assembler.Emit(il.ErrHalt)
+
+	v.assembler.SetLabel(endLabel)
 }
 
 // VisitReturnStatementNode generates the IL Code for returning within a function
 func (v *ILCodeGenerationVisitor) VisitReturnStatementNode(node *node.ReturnStatementNode) {
 	v.AbstractVisitor.VisitReturnStatementNode(node)
+	if v.isModified {
+		v.emitModifiedReturn()
+		return
+	}
 	v.assembler.Emit(il.Ret)
 }
 
@@ -357,6 +512,71 @@ func (v *ILCodeGenerationVisitor) VisitIfStatementNode(node *node.IfStatementNod
 	v.assembler.SetLabel(endLabel)
 }
 
+// VisitForStatementNode generates the IL Code for a loop over an integer range.
+// The upper bound is evaluated only once before the first iteration. Together with the read-only loop variable,
+// the number of iterations is therefore fixed when the loop is entered.
+func (v *ILCodeGenerationVisitor) VisitForStatementNode(node *node.ForStatementNode) {
+	conditionLabel := v.assembler.CreateLabel()
+	endLabel := v.assembler.CreateLabel()
+
+	varIndex := byte(v.getLocalIndex(node.Variable.Identifier))
+	toIndex := v.createTempVariable()
+
+	node.From.Accept(v)
+	v.assembler.StoreLocal(varIndex)
+	node.To.Accept(v)
+	v.assembler.StoreLocal(toIndex)
+
+	// Condition: i < to
+	v.assembler.SetLabel(conditionLabel)
+	v.assembler.LoadLocal(varIndex)
+	v.assembler.LoadLocal(toIndex)
+	v.assembler.Emit(il.Lt)
+	v.assembler.JmpFalse(endLabel)
+
+	v.VisitStatementBlock(node.Body)
+	v.incrementLocal(varIndex)
+	v.assembler.Jmp(conditionLabel)
+
+	v.assembler.SetLabel(endLabel)
+}
+
+// VisitForEachStatementNode generates the IL Code for a loop over the array elements.
+// The array is copied before the first iteration, thus the number of iterations is fixed to the array length.
+func (v *ILCodeGenerationVisitor) VisitForEachStatementNode(node *node.ForEachStatementNode) {
+	conditionLabel := v.assembler.CreateLabel()
+	endLabel := v.assembler.CreateLabel()
+
+	varIndex := byte(v.getLocalIndex(node.Variable.Identifier))
+	arrayIndex := v.createTempVariable()
+	counterIndex := v.createTempVariable()
+
+	node.Collection.Accept(v)
+	v.assembler.StoreLocal(arrayIndex)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.StoreLocal(counterIndex)
+
+	// Condition: counter < array.length
+	v.assembler.SetLabel(conditionLabel)
+	v.assembler.LoadLocal(counterIndex)
+	v.assembler.LoadLocal(arrayIndex)
+	v.assembler.Emit(il.ArrLen)
+	v.assembler.Emit(il.Lt)
+	v.assembler.JmpFalse(endLabel)
+
+	// Loop variable: x = array[counter]
+	v.assembler.LoadLocal(counterIndex)
+	v.assembler.LoadLocal(arrayIndex)
+	v.assembler.Emit(il.ArrAt)
+	v.assembler.StoreLocal(varIndex)
+
+	v.VisitStatementBlock(node.Body)
+	v.incrementLocal(counterIndex)
+	v.assembler.Jmp(conditionLabel)
+
+	v.assembler.SetLabel(endLabel)
+}
+
 // Expressions
 // -----------
 
@@ -401,8 +621,13 @@ var binaryOpCodes = map[token.Symbol]il.OpCode{
 
 // VisitBinaryExpressionNode generates the IL Code for all binary expressions
 func (v *ILCodeGenerationVisitor) VisitBinaryExpressionNode(expNode *node.BinaryExpressionNode) {
+	if v.pushConstantValue(expNode) {
+		return
+	}
+
 	if expNode.Operator == token.Plus && v.isStringType(v.symbolTable.GetTypeByExpression(expNode.Left)) {
-		v.reportError(expNode, "String concatenation is not supported")
+		v.AbstractVisitor.VisitBinaryExpressionNode(expNode)
+		v.emitConcatenation()
 		return
 	}
 
@@ -463,6 +688,10 @@ var unaryOpCodes = map[token.Symbol]il.OpCode{
 
 // VisitUnaryExpressionNode generates the IL Code for all unary expressions
 func (v *ILCodeGenerationVisitor) VisitUnaryExpressionNode(expNode *node.UnaryExpressionNode) {
+	if v.pushConstantValue(expNode) {
+		return
+	}
+
 	if op, ok := unaryOpCodes[expNode.Operator]; ok {
 		v.AbstractVisitor.VisitUnaryExpressionNode(expNode)
 		v.assembler.Emit(op)
@@ -477,9 +706,40 @@ func (v *ILCodeGenerationVisitor) VisitUnaryExpressionNode(expNode *node.UnaryEx
 	v.reportError(expNode, fmt.Sprintf("unary operator %s not supported", token.SymbolLexeme[expNode.Operator]))
 }
 
-// VisitTypeCastNode generates the IL code type cast expression
+// VisitTypeCastNode generates the IL code for the type cast expression
 func (v *ILCodeGenerationVisitor) VisitTypeCastNode(node *node.TypeCastNode) {
-	v.reportError(node, "VM currently does not support types")
+	node.Expression.Accept(v.ConcreteVisitor)
+
+	castType := v.symbolTable.GetTypeByExpression(node)
+	exprType := v.symbolTable.GetTypeByExpression(node.Expression)
+	if castType == exprType {
+		return
+	}
+
+	gs := v.symbolTable.GlobalScope
+	switch castType {
+	case gs.StringType:
+		switch exprType {
+		case gs.IntType:
+			v.emitIntToString()
+		case gs.BoolType:
+			v.emitBoolToString()
+		}
+		// A character has the same bytes as the string with the single character
+	case gs.IntType:
+		switch exprType {
+		case gs.CharType:
+			v.emitCharToInt()
+		case gs.BoolType:
+			v.emitBoolToInt()
+		case gs.StringType:
+			v.emitStringToInt()
+		}
+	case gs.CharType:
+		v.emitIntToChar()
+	default:
+		v.reportError(node, fmt.Sprintf("Unsupported type cast to %s", castType))
+	}
 }
 
 // VisitFuncCallNode generates the IL Code for the function call
@@ -495,9 +755,107 @@ func (v *ILCodeGenerationVisitor) VisitFuncCallNode(funcCallNode *node.FuncCallN
 		v.assembler.Emit(il.MapHasKey)
 		return
 	}
+	if funcSym == v.symbolTable.GlobalScope.ArrayMemberFunctions[funcSym.Identifier()] {
+		v.visitArrayMemberFunction(funcCallNode, funcSym)
+		return
+	}
+	if funcSym == v.symbolTable.GlobalScope.StringMemberFunctions[funcSym.Identifier()] {
+		funcCallNode.Designator.(*node.MemberAccessNode).Designator.Accept(v) // load string
+		v.visitStringMemberFunction(funcSym)
+		return
+	}
+	if opCode, ok := builtInFunctionOpCodes[funcSym.Identifier()]; ok && funcSym.Scope() == v.symbolTable.GlobalScope {
+		v.assembler.Emit(opCode)
+		return
+	}
 	v.assembler.CallFunc(funcSym)
 }
 
+// visitArrayMemberFunction generates the IL Code for append, insert and remove.
+// The arguments are already on the stack. Since arrays are value types in the VM,
+// the modified array is stored back in the designator.
+func (v *ILCodeGenerationVisitor) visitArrayMemberFunction(funcCallNode *node.FuncCallNode, funcSym *symbol.FunctionSymbol) {
+	targetArray := funcCallNode.Designator.(*node.MemberAccessNode).Designator
+	v.storeTargetKeys(targetArray)
+
+	switch funcSym.Identifier() {
+	case symbol.Append:
+		v.loadTarget(targetArray)
+		v.assembler.Emit(il.ArrAppend)
+	case symbol.Remove:
+		v.loadTarget(targetArray)
+		v.assembler.Emit(il.ArrRemove)
+	case symbol.Insert:
+		v.insertArrayElement(targetArray)
+	}
+
+	v.storeDesignator(targetArray, funcCallNode)
+}
+
+// insertArrayElement inserts the element at the index, which are both on the stack, and pushes the new array.
+// The VM only supports replacing an element (ArrInsert). Therefore, the element is appended first
+// and the elements after the index are shifted by one, before the element is stored at the index.
+func (v *ILCodeGenerationVisitor) insertArrayElement(targetArray node.DesignatorNode) {
+	conditionLabel := v.assembler.CreateLabel()
+	endLabel := v.assembler.CreateLabel()
+
+	elementIndex := v.createTempVariable()
+	indexIndex := v.createTempVariable()
+	arrayIndex := v.createTempVariable()
+	counterIndex := v.createTempVariable()
+
+	v.assembler.StoreLocal(elementIndex)
+	v.assembler.StoreLocal(indexIndex)
+
+	// array.append(element)
+	v.assembler.LoadLocal(elementIndex)
+	v.loadTarget(targetArray)
+	v.assembler.Emit(il.ArrAppend)
+	v.assembler.StoreLocal(arrayIndex)
+
+	// counter = array.length - 1
+	v.assembler.LoadLocal(arrayIndex)
+	v.assembler.Emit(il.ArrLen)
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Sub)
+	v.assembler.StoreLocal(counterIndex)
+
+	// Condition: index < counter
+	v.assembler.SetLabel(conditionLabel)
+	v.assembler.LoadLocal(indexIndex)
+	v.assembler.LoadLocal(counterIndex)
+	v.assembler.Emit(il.Lt)
+	v.assembler.JmpFalse(endLabel)
+
+	// array[counter] = array[counter - 1]
+	v.assembler.LoadLocal(counterIndex)
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Sub)
+	v.assembler.LoadLocal(arrayIndex)
+	v.assembler.Emit(il.ArrAt)
+	v.assembler.LoadLocal(counterIndex)
+	v.assembler.LoadLocal(arrayIndex)
+	v.assembler.Emit(il.ArrInsert)
+	v.assembler.StoreLocal(arrayIndex)
+
+	v.decrementLocal(counterIndex)
+	v.assembler.Jmp(conditionLabel)
+
+	// array[index] = element
+	v.assembler.SetLabel(endLabel)
+	v.assembler.LoadLocal(elementIndex)
+	v.assembler.LoadLocal(indexIndex)
+	v.assembler.LoadLocal(arrayIndex)
+	v.assembler.Emit(il.ArrInsert)
+}
+
+// builtInFunctionOpCodes contains the OpCodes, which implement the built-in functions.
+// The arguments are already pushed in the order expected by the VM.
+var builtInFunctionOpCodes = map[string]il.OpCode{
+	symbol.Sha3:     il.SHA3,
+	symbol.CheckSig: il.CheckSig,
+}
+
 // VisitStructCreationNode generates the IL code for creating a new struct.
 func (v *ILCodeGenerationVisitor) VisitStructCreationNode(node *node.StructCreationNode) {
 	structType := v.symbolTable.GetTypeByExpression(node).(*symbol.StructTypeSymbol)
@@ -539,37 +897,75 @@ func (v *ILCodeGenerationVisitor) VisitStructNamedCreationNode(node *node.Struct
 
 // VisitArrayLengthCreationNode generates the IL Code for the array length creation
 func (v *ILCodeGenerationVisitor) VisitArrayLengthCreationNode(node *node.ArrayLengthCreationNode) {
-	if len(node.Lengths) > 1 {
-		v.reportError(node, unsupportedArrayNestingMsg)
+	arrayType := v.symbolTable.GetTypeByExpression(node).(*symbol.ArrayTypeSymbol)
+	v.pushNewArray(node.Lengths, arrayType)
+}
+
+// pushNewArray creates an array with the first length. The VM initializes the elements with a zero byte,
+// which is sufficient for basic types. Otherwise, the elements are default initialized or created
+// with the remaining lengths (e.g. new int[2][3]). Since field initializers have no local variables,
+// the loop counter is kept on the stack.
+func (v *ILCodeGenerationVisitor) pushNewArray(lengths []node.ExpressionNode, arrayType *symbol.ArrayTypeSymbol) {
+	lengths[0].Accept(v)
+	if _, isBasic := arrayType.ElementType.(*symbol.BasicTypeSymbol); isBasic && len(lengths) == 1 {
+		v.assembler.Emit(il.NewArr)
 		return
 	}
 
-	node.Lengths[0].Accept(v)
-	v.assembler.Emit(il.NewArr) // Pass Array length as parameter
+	conditionLabel := v.assembler.CreateLabel()
+	endLabel := v.assembler.CreateLabel()
+
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.NewArr) // Stack: counter, array
+
+	v.assembler.SetLabel(conditionLabel)
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Gt)
+	v.assembler.JmpFalse(endLabel) // Stack: array, counter
+
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Sub)
+	v.assembler.Emit(il.Swap)
+	if len(lengths) > 1 {
+		v.pushNewArray(lengths[1:], arrayType.ElementType.(*symbol.ArrayTypeSymbol))
+	} else {
+		v.pushDefault(arrayType.ElementType)
+	}
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.ArrAppend)
+	v.assembler.Jmp(conditionLabel)
+
+	v.assembler.SetLabel(endLabel)
+	v.assembler.Emit(il.Pop)
 }
 
 // VisitArrayValueCreationNode generates the IL Code for the array value creation
 func (v *ILCodeGenerationVisitor) VisitArrayValueCreationNode(n *node.ArrayValueCreationNode) {
-	if _, isNested := n.Elements.Values[0].(*node.ArrayInitializationNode); isNested {
-		v.reportError(n, unsupportedArrayNestingMsg)
-		return
-	}
+	n.Elements.Accept(v)
+}
 
-	length := big.NewInt(int64(len(n.Elements.Values)))
+// VisitArrayInitializationNode generates the IL Code for the array values. Nested values create nested arrays.
+func (v *ILCodeGenerationVisitor) VisitArrayInitializationNode(n *node.ArrayInitializationNode) {
+	length := big.NewInt(int64(len(n.Values)))
 	v.assembler.PushInt(length)
 	v.assembler.Emit(il.NewArr)
-	for i, value := range n.Elements.Values {
+	for i, value := range n.Values {
 		value.Accept(v)
 		v.assembler.Emit(il.Swap)                 // array is be popped from stack before value
 		v.assembler.PushInt(big.NewInt(int64(i))) // array is popped from stack before index
 		v.assembler.Emit(il.Swap)
 		v.assembler.Emit(il.ArrInsert)
 	}
-
 }
 
 // VisitBasicDesignatorNode generates the IL Code for a designator
 func (v *ILCodeGenerationVisitor) VisitBasicDesignatorNode(node *node.BasicDesignatorNode) {
+	if v.pushConstantValue(node) {
+		return
+	}
+
 	decl := v.symbolTable.GetDeclByDesignator(node)
 	if node.String() != symbol.This {
 		v.loadVariable(decl)
@@ -578,7 +974,14 @@ func (v *ILCodeGenerationVisitor) VisitBasicDesignatorNode(node *node.BasicDesig
 
 // VisitIntegerLiteralNode pushes an integer to the stack
 func (v *ILCodeGenerationVisitor) VisitIntegerLiteralNode(node *node.IntegerLiteralNode) {
-	v.assembler.PushInt(node.Value)
+	if err := v.assembler.PushInt(node.Value); err != nil {
+		v.reportError(node, err.Error())
+	}
+}
+
+// VisitAddressLiteralNode pushes the bytes of an address to the stack
+func (v *ILCodeGenerationVisitor) VisitAddressLiteralNode(node *node.AddressLiteralNode) {
+	v.assembler.PushBytes(node.Value)
 }
 
 // VisitBoolLiteralNode pushes a boolean to the stack
@@ -588,7 +991,9 @@ func (v *ILCodeGenerationVisitor) VisitBoolLiteralNode(node *node.BoolLiteralNod
 
 // VisitStringLiteralNode pushes a string to the stack
 func (v *ILCodeGenerationVisitor) VisitStringLiteralNode(node *node.StringLiteralNode) {
-	v.assembler.PushString(node.Value)
+	if err := v.assembler.PushString(node.Value); err != nil {
+		v.reportError(node, err.Error())
+	}
 }
 
 // VisitCharacterLiteralNode pushes a character to the stack
@@ -599,6 +1004,29 @@ func (v *ILCodeGenerationVisitor) VisitCharacterLiteralNode(node *node.Character
 // Helper Functions
 // ----------------
 
+// pushConstantValue pushes the value of the expression, if it has been computed at compile time
+func (v *ILCodeGenerationVisitor) pushConstantValue(expr node.ExpressionNode) bool {
+	var err error
+	switch value := v.symbolTable.GetValueByExpression(expr).(type) {
+	case *big.Int:
+		err = v.assembler.PushInt(value)
+	case bool:
+		v.assembler.PushBool(value)
+	case rune:
+		v.assembler.PushCharacter(value)
+	case string:
+		err = v.assembler.PushString(value)
+	case []byte:
+		v.assembler.PushBytes(value)
+	default:
+		return false
+	}
+	if err != nil {
+		v.reportError(expr, err.Error())
+	}
+	return true
+}
+
 func (v *ILCodeGenerationVisitor) loadVariable(decl symbol.Symbol) {
 	index := v.getVarIndex(decl)
 
@@ -634,11 +1062,17 @@ func (v *ILCodeGenerationVisitor) pushDefault(typeSymbol symbol.TypeSymbol) {
 		v.pushDefaultStruct(typeSymbol.(*symbol.StructTypeSymbol))
 		return
 	case *symbol.ArrayTypeSymbol:
-		v.assembler.PushNil()
+		// Empty array, which can grow with append and insert
+		v.assembler.PushInt(big.NewInt(0))
+		v.assembler.Emit(il.NewArr)
 		return
 	case *symbol.MapTypeSymbol:
 		v.assembler.Emit(il.NewMap)
 		return
+	case *symbol.EnumTypeSymbol:
+		// First member
+		v.assembler.PushInt(big.NewInt(0))
+		return
 	}
 
 	gs := v.symbolTable.GlobalScope
@@ -652,6 +1086,12 @@ func (v *ILCodeGenerationVisitor) pushDefault(typeSymbol symbol.TypeSymbol) {
 		v.assembler.PushString("")
 	case gs.CharType:
 		v.assembler.PushCharacter('0')
+	case gs.AddressType:
+		v.assembler.PushBytes(make([]byte, token.AddressLength))
+	case gs.HashType:
+		v.assembler.PushBytes(make([]byte, symbol.HashLength))
+	case gs.PublicKeyType:
+		v.assembler.PushBytes(make([]byte, symbol.PublicKeyLength))
 	default:
 		typeNode := v.symbolTable.GetNodeBySymbol(typeSymbol.(symbol.Symbol))
 		v.reportError(typeNode, fmt.Sprintf("%s not supported", typeSymbol.Identifier()))
@@ -671,11 +1111,33 @@ func (v *ILCodeGenerationVisitor) pushDefaultStruct(structType *symbol.StructTyp
 	}
 }
 
+// createTempVariable returns the index of a new local variable, which is not declared in the source code.
+// Temporary variables are placed after the parameters and local variables of the current function.
+func (v *ILCodeGenerationVisitor) createTempVariable() byte {
+	index := len(v.function.AllDeclarations()) + v.tempVars
+	v.tempVars++
+	return byte(index)
+}
+
+func (v *ILCodeGenerationVisitor) incrementLocal(index byte) {
+	v.assembler.LoadLocal(index)
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Add)
+	v.assembler.StoreLocal(index)
+}
+
+func (v *ILCodeGenerationVisitor) decrementLocal(index byte) {
+	v.assembler.LoadLocal(index)
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Sub)
+	v.assembler.StoreLocal(index)
+}
+
 // Returns: variable index and isContractField
 func (v *ILCodeGenerationVisitor) getVarIndex(decl symbol.Symbol) int {
 	switch decl.(type) {
 	case *symbol.LocalVariableSymbol, *symbol.ParameterSymbol:
-		index := v.function.GetVarIndex(decl.Identifier())
+		index := v.getLocalIndex(decl.Identifier())
 		if index == -1 {
 			panic(fmt.Sprintf("Variable not found %s", decl.Identifier()))
 		}
@@ -719,9 +1181,9 @@ func (v *ILCodeGenerationVisitor) isStructType(typeSymbol symbol.TypeSymbol) boo
 	return ok
 }
 
-const unsupportedArrayNestingMsg = "Generator currently does not support array nesting"
 const unsupportedElementAccessMsg = "Unsupported element access type"
 
+// reportError reports a language feature, which cannot be translated into byte code
 func (v *ILCodeGenerationVisitor) reportError(node node.Node, msg string) {
-	v.Errors = append(v.Errors, fmt.Errorf("[%s] %s", node.Pos(), msg))
+	v.Errors = append(v.Errors, diagnostic.New(diagnostic.Unsupported, node.Pos(), node.End(), msg))
 }
@@ -0,0 +1,93 @@
+package emit
+
+import (
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/parser/node"
+)
+
+// Modifiers are inlined into every function, to which they are applied. The statements before the placeholder are
+// emitted before the function body and the statements after the placeholder are emitted after it.
+// Modifiers are applied in declaration order, thus the first modifier wraps all the following ones.
+//
+// The parameters and local variables of an inlined modifier are placed after the variables of the function,
+// in the same way as temporary variables. Every invocation gets its own variables, hence a modifier can be applied
+// multiple times to the same function.
+//
+// A return in the body of a modified function must not skip the modifier statements after the placeholder.
+// Therefore, the return values are stored in temporary variables and the return jumps to the end of the body.
+// The values are loaded again after the modifier statements, right before the function returns.
+
+// VisitFunctionNode generates the IL Code for the function body wrapped by its modifiers
+func (v *ILCodeGenerationVisitor) VisitFunctionNode(node *node.FunctionNode) {
+	if len(node.Modifiers) == 0 {
+		v.VisitStatementBlock(node.Body)
+		return
+	}
+
+	v.isModified = true
+	v.returnLabel = v.assembler.CreateLabel()
+	v.returnVars = nil
+	for range v.function.ReturnTypes {
+		v.returnVars = append(v.returnVars, v.createTempVariable())
+	}
+
+	v.emitModifiers(node.Modifiers, node.Body)
+
+	for _, index := range v.returnVars {
+		v.assembler.LoadLocal(index)
+	}
+	v.isModified, v.returnVars = false, nil
+}
+
+// emitModifiedReturn stores the return values, which are on the stack, and jumps to the end of the function body
+func (v *ILCodeGenerationVisitor) emitModifiedReturn() {
+	for i := len(v.returnVars) - 1; i >= 0; i-- {
+		v.assembler.StoreLocal(v.returnVars[i])
+	}
+	v.assembler.Jmp(v.returnLabel)
+}
+
+func (v *ILCodeGenerationVisitor) emitModifiers(invocations []*node.ModifierInvocationNode, body []node.StatementNode) {
+	if len(invocations) == 0 {
+		v.VisitStatementBlock(body)
+		v.assembler.SetLabel(v.returnLabel)
+		return
+	}
+
+	invocation := invocations[0]
+	modifier := v.symbolTable.GetDeclByDesignator(invocation.Modifier).(*symbol.FunctionSymbol)
+	modifierNode := v.symbolTable.GetNodeBySymbol(modifier).(*node.ModifierNode)
+
+	base := len(v.function.AllDeclarations()) + v.tempVars
+	v.tempVars += len(modifier.AllDeclarations())
+
+	// The arguments are evaluated in the scope of the function and stored in the modifier parameters
+	for i, arg := range invocation.Args {
+		arg.Accept(v)
+		v.assembler.StoreLocal(byte(base + i))
+	}
+
+	for _, statement := range modifierNode.Body {
+		if _, ok := statement.(*node.PlaceholderStatementNode); ok {
+			v.emitModifiers(invocations[1:], body)
+			continue
+		}
+
+		v.modifier, v.modifierBase = modifier, base
+		statement.Accept(v)
+		v.modifier, v.modifierBase = nil, 0
+	}
+}
+
+// getLocalIndex returns the index of a parameter or local variable of the function or of the inlined modifier
+func (v *ILCodeGenerationVisitor) getLocalIndex(identifier string) int {
+	if v.modifier == nil {
+		return v.function.GetVarIndex(identifier)
+	}
+
+	index := v.modifier.GetVarIndex(identifier)
+	if index == -1 {
+		return index
+	}
+	return v.modifierBase + index
+}
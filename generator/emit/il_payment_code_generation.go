@@ -0,0 +1,79 @@
+package emit
+
+import (
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/generator/il"
+	"math/big"
+)
+
+const notPayableMsg = "Function does not accept Bazo coins"
+
+// amountLength is the number of bytes of an amount pushed by the VM (unsigned 64-bit little endian)
+const amountLength = 8
+
+// builtInAmountOpCodes are the built-in fields, which push an amount instead of an integer
+var builtInAmountOpCodes = map[string]il.OpCode{
+	symbol.Msg + ".value":                 il.CallVal,
+	symbol.ContractNamespace + ".balance": il.Balance,
+}
+
+// emitRejectCallValue aborts the transaction, if it sends Bazo coins to a function, which is not payable.
+// Since the VM pushes the call value as raw bytes, it is compared with a zero amount of the same length.
+func (v *ILCodeGenerationVisitor) emitRejectCallValue() {
+	v.assembler.Emit(il.CallVal)
+	v.assembler.PushBytes(make([]byte, amountLength))
+	v.assembler.Emit(il.NotEq)
+	v.assembler.JmpTrue(v.notPayable)
+}
+
+// emitNotPayableError emits the error, which is shared by all entry points rejecting the call value
+func (v *ILCodeGenerationVisitor) emitNotPayableError() {
+	v.assembler.SetLabel(v.notPayable)
+	v.assembler.PushString(notPayableMsg)
+	v.assembler.Emit(il.ErrHalt)
+}
+
+// emitAmountToInt replaces the amount on the stack with the integer.
+// The amount bytes are converted like a string, which results in the amount with reversed byte order.
+// The bytes are then read from the lowest byte on and added with the factor 256^7, ..., 256^0.
+// The loop runs until the factor is zero, since leading zero bytes cannot be detected on the reversed amount.
+func (v *ILCodeGenerationVisitor) emitAmountToInt() {
+	loopLabel := v.assembler.CreateLabel()
+	doneLabel := v.assembler.CreateLabel()
+
+	v.emitStringToNumber()
+	v.assembler.PushInt(big.NewInt(amountLength))
+	v.emitPowerOf256()
+	v.assembler.Emit(il.Mod)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.PushInt(new(big.Int).Lsh(big.NewInt(1), 8*(amountLength-1))) // Stack: reversed, value, factor
+
+	v.assembler.SetLabel(loopLabel)
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Gt)
+	v.assembler.JmpFalse(doneLabel)
+
+	v.pick(2)
+	v.assembler.PushInt(big.NewInt(256))
+	v.assembler.Emit(il.Mod)
+	v.pick(1)
+	v.assembler.Emit(il.Mul)
+	v.assembler.Roll(1)
+	v.assembler.Emit(il.Add)
+	v.assembler.Emit(il.Swap)
+	v.assembler.PushInt(big.NewInt(256))
+	v.assembler.Emit(il.Div) // Stack: reversed, value + byte * factor, factor / 256
+
+	v.assembler.Roll(1)
+	v.assembler.PushInt(big.NewInt(256))
+	v.assembler.Emit(il.Div)
+	v.assembler.Roll(1)
+	v.assembler.Roll(1) // Stack: reversed without the lowest byte, value, factor
+	v.assembler.Jmp(loopLabel)
+
+	v.assembler.SetLabel(doneLabel)
+	v.assembler.Emit(il.Pop)
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Pop)
+}
@@ -0,0 +1,481 @@
+package emit
+
+import (
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/generator/il"
+	"math/big"
+)
+
+// The Bazo VM has no string operations. Strings are raw bytes on the stack, which can only be compared.
+// Therefore, the string operations are performed on integers:
+//
+// A string is converted into an integer by storing it as value of a map with an empty key.
+// The map bytes [0x01, size, key length, value length, string] are read as a negative integer,
+// since 0x01 is the sign byte of negative integers. After the negation, the string is contained
+// in the lowest bytes of the integer: (2^32 + length) * 256^length + string.
+//
+// An integer is converted back into a string with the reverse operation. The integer
+// (2^40 + length) * 256^length + string is negated and read as map with the size 0x0100.
+// The size is not relevant for the VM, but the first byte cannot be zero, since the VM trims the integer.
+// Then, the string is returned as value of the empty key.
+//
+// All operations only use the stack, since field initializers are not executed within a function.
+var (
+	stringNumberHeader = new(big.Int).Lsh(big.NewInt(1), 32)
+	numberStringHeader = new(big.Int).Lsh(big.NewInt(1), 40)
+)
+
+const (
+	stringIndexOutOfBoundsMsg = "String index out of bounds"
+	invalidIntegerMsg         = "String is not a valid integer"
+	invalidASCIICodeMsg       = "Integer is not a valid ASCII code"
+)
+
+// visitStringMemberFunction generates the IL Code for the string member functions.
+// The arguments and the string are already on the stack.
+func (v *ILCodeGenerationVisitor) visitStringMemberFunction(funcSym *symbol.FunctionSymbol) {
+	switch funcSym.Identifier() {
+	case symbol.Length:
+		v.emitStringLength()
+	case symbol.At:
+		v.emitStringAt()
+	case symbol.Substring:
+		v.emitSubstring()
+	case symbol.Equals:
+		v.assembler.Emit(il.Eq)
+	case symbol.Contains:
+		v.emitStringContains()
+	}
+}
+
+// emitConcatenation concatenates the two strings on the stack: s1, s2 -> s1s2
+func (v *ILCodeGenerationVisitor) emitConcatenation() {
+	v.assembler.Emit(il.Dup)
+	v.emitStringLength()
+	v.assembler.Emit(il.Dup)
+	v.emitPowerOf256() // Stack: s1, s2, n2, 256^n2
+	v.assembler.Roll(1)
+	v.emitStringToNumber()
+	v.pick(1)
+	v.assembler.Emit(il.Mod) // Stack: s1, n2, 256^n2, s2 as number without header
+
+	v.assembler.Roll(2)
+	v.assembler.Emit(il.Dup)
+	v.emitStringLength()
+	v.emitPowerOf256()
+	v.assembler.Emit(il.Swap)
+	v.emitStringToNumber()
+	v.assembler.Emit(il.Swap) // Stack: n2, 256^n2, s2, s1 as number, 256^n1
+
+	// Replace the header of s1 with the header of the result, which has the length n1 + n2
+	v.assembler.Roll(3)
+	v.assembler.PushInt(new(big.Int).Sub(numberStringHeader, stringNumberHeader))
+	v.assembler.Emit(il.Add)
+	v.assembler.Emit(il.Mul)
+	v.assembler.Emit(il.Add) // Stack: 256^n2, s2, s1 with result header
+
+	v.assembler.Roll(1)
+	v.assembler.Emit(il.Mul)
+	v.assembler.Emit(il.Add)
+	v.emitNumberToString()
+}
+
+// emitStringLength replaces the string on the stack with its length.
+// The VM pushes the size as 8 bytes, which is trimmed to a regular integer by adding 0.
+func (v *ILCodeGenerationVisitor) emitStringLength() {
+	v.assembler.Emit(il.Size)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Add)
+}
+
+// emitStringAt pushes the character at the index: index, s -> s[index]
+// A character is a single byte, therefore it is equal to the substring of length 1.
+func (v *ILCodeGenerationVisitor) emitStringAt() {
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Add)
+	v.assembler.Roll(1)
+	v.emitSubstring()
+}
+
+// emitSubstring pushes the part of the string from start (inclusive) to end (exclusive): start, end, s -> s[start:end]
+// The transaction is aborted, if the range is not within the string.
+func (v *ILCodeGenerationVisitor) emitSubstring() {
+	v.assembler.Roll(1)
+	v.emitAbortIfNegative() // Stack: end, s, start
+
+	v.assembler.Roll(1)
+	v.assembler.Emit(il.Dup)
+	v.assembler.Roll(1)
+	v.assembler.Emit(il.Sub)
+	v.emitAbortIfNegative() // Stack: s, end, length = end - start
+
+	v.assembler.Roll(1)
+	v.assembler.Emit(il.Dup)
+	v.emitStringLength()
+	v.assembler.Roll(2)
+	v.assembler.Emit(il.Sub)
+	v.emitAbortIfNegative() // Stack: length, s, rest = n - end
+
+	// Remove the rest by dividing the number
+	v.emitPowerOf256()
+	v.assembler.Emit(il.Swap)
+	v.emitStringToNumber()
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Div)
+
+	// Remove the start and the header with the modulo
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Dup)
+	v.emitPowerOf256()
+	v.assembler.Roll(1)
+	v.pick(1)
+	v.assembler.Emit(il.Mod) // Stack: length, 256^length, substring as number without header
+
+	v.assembler.Roll(1)
+	v.assembler.PushInt(numberStringHeader)
+	v.assembler.Emit(il.Add)
+	v.assembler.Roll(1)
+	v.assembler.Emit(il.Mul)
+	v.assembler.Emit(il.Add)
+	v.emitNumberToString()
+}
+
+// emitStringContains checks, whether the part is contained in the string: part, s -> bool
+// The substrings with the length of the part are compared from the start of the string.
+func (v *ILCodeGenerationVisitor) emitStringContains() {
+	conditionLabel := v.assembler.CreateLabel()
+	notFoundLabel := v.assembler.CreateLabel()
+	foundLabel := v.assembler.CreateLabel()
+	endLabel := v.assembler.CreateLabel()
+
+	v.assembler.PushInt(big.NewInt(0)) // Stack: part, s, index
+
+	// Condition: index + part.length() <= s.length()
+	v.assembler.SetLabel(conditionLabel)
+	v.assembler.Emit(il.Dup)
+	v.pick(3)
+	v.emitStringLength()
+	v.assembler.Emit(il.Add)
+	v.assembler.Emit(il.Dup)
+	v.pick(3)
+	v.emitStringLength()
+	v.assembler.Emit(il.LtEq)
+	v.assembler.JmpFalse(notFoundLabel) // Stack: part, s, index, end
+
+	v.pick(1)
+	v.assembler.Emit(il.Swap)
+	v.pick(3)
+	v.emitSubstring()
+	v.pick(3)
+	v.assembler.Emit(il.Eq)
+	v.assembler.JmpTrue(foundLabel)
+
+	v.incrementIndex()
+	v.assembler.Jmp(conditionLabel)
+
+	v.assembler.SetLabel(notFoundLabel)
+	v.assembler.Emit(il.Pop)
+	v.popStringAndIndex()
+	v.assembler.PushBool(false)
+	v.assembler.Jmp(endLabel)
+
+	v.assembler.SetLabel(foundLabel)
+	v.popStringAndIndex()
+	v.assembler.PushBool(true)
+
+	v.assembler.SetLabel(endLabel)
+}
+
+func (v *ILCodeGenerationVisitor) incrementIndex() {
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Add)
+}
+
+// popStringAndIndex removes the part, the string and the index of the contains loop
+func (v *ILCodeGenerationVisitor) popStringAndIndex() {
+	v.assembler.Emit(il.Pop)
+	v.assembler.Emit(il.Pop)
+	v.assembler.Emit(il.Pop)
+}
+
+// emitStringToNumber replaces the string on the stack with the number (2^32 + length) * 256^length + string
+func (v *ILCodeGenerationVisitor) emitStringToNumber() {
+	v.assembler.PushNil()
+	v.assembler.Emit(il.NewMap)
+	v.assembler.Emit(il.MapSetVal)
+	v.assembler.Emit(il.Neg)
+}
+
+// emitNumberToString replaces the number (2^40 + length) * 256^length + string on the stack with the string
+func (v *ILCodeGenerationVisitor) emitNumberToString() {
+	v.assembler.Emit(il.Neg)
+	v.assembler.PushNil()
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.MapGetVal)
+}
+
+// emitPowerOf256 replaces the length n on the stack with 256^n, which shifts a number by n bytes
+func (v *ILCodeGenerationVisitor) emitPowerOf256() {
+	v.assembler.PushInt(big.NewInt(8))
+	v.assembler.Emit(il.Mul)
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.ShiftL)
+}
+
+// emitAbortIfNegative aborts the transaction, if the integer on top of the stack is negative
+func (v *ILCodeGenerationVisitor) emitAbortIfNegative() {
+	validLabel := v.assembler.CreateLabel()
+
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.GtEq)
+	v.assembler.JmpTrue(validLabel)
+	v.assembler.PushString(stringIndexOutOfBoundsMsg)
+	v.assembler.Emit(il.ErrHalt)
+
+	v.assembler.SetLabel(validLabel)
+}
+
+// pick pushes a copy of the element at the given depth (e.g. pick(0) is equal to Dup).
+// The element is rolled to the top and duplicated. Afterwards, the elements above are rolled over the copy,
+// until the original element is back at its position.
+func (v *ILCodeGenerationVisitor) pick(depth byte) {
+	if depth == 0 {
+		v.assembler.Emit(il.Dup)
+		return
+	}
+
+	v.assembler.Roll(depth - 1)
+	v.assembler.Emit(il.Dup)
+	for i := byte(0); i <= depth; i++ {
+		v.assembler.Roll(depth)
+	}
+}
+
+// emitIntToString replaces the integer on the stack with its decimal representation.
+// The digits are prepended from the lowest digit on, the sign is prepended at the end.
+func (v *ILCodeGenerationVisitor) emitIntToString() {
+	loopLabel := v.assembler.CreateLabel()
+	positiveLabel := v.assembler.CreateLabel()
+
+	v.assembler.Emit(il.Dup)
+	v.emitAbsoluteValue()
+	v.assembler.PushString("") // Stack: value, |value|, s
+
+	// Do-while, so that 0 results in "0"
+	v.assembler.SetLabel(loopLabel)
+	v.pick(1)
+	v.assembler.PushInt(big.NewInt(10))
+	v.assembler.Emit(il.Mod)
+	v.assembler.PushInt(big.NewInt('0'))
+	v.assembler.Emit(il.Add)
+	v.emitIntToChar()
+	v.assembler.Emit(il.Swap)
+	v.emitConcatenation() // Stack: value, |value|, digit + s
+
+	v.assembler.Emit(il.Swap)
+	v.assembler.PushInt(big.NewInt(10))
+	v.assembler.Emit(il.Div)
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Gt)
+	v.assembler.Emit(il.Swap)
+	v.assembler.Roll(1)
+	v.assembler.Roll(1) // Stack: value, |value| / 10, s, |value| / 10 > 0
+	v.assembler.JmpTrue(loopLabel)
+
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Pop)
+	v.assembler.Emit(il.Swap)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Lt)
+	v.assembler.JmpFalse(positiveLabel)
+	v.assembler.PushString("-")
+	v.assembler.Emit(il.Swap)
+	v.emitConcatenation()
+
+	v.assembler.SetLabel(positiveLabel)
+}
+
+// emitStringToInt replaces the decimal representation on the stack with the integer.
+// The transaction is aborted, if the string is not an optional minus sign followed by at least one digit.
+// The digits are read from the lowest byte of the string number on.
+func (v *ILCodeGenerationVisitor) emitStringToInt() {
+	positiveLabel := v.assembler.CreateLabel()
+	loopLabel := v.assembler.CreateLabel()
+	doneLabel := v.assembler.CreateLabel()
+	endLabel := v.assembler.CreateLabel()
+	invalidLabel := v.assembler.CreateLabel()
+
+	// Sign
+	v.assembler.Emit(il.Dup)
+	v.emitStringLength()
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Gt)
+	v.assembler.JmpFalse(invalidLabel)
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Swap)
+	v.emitStringAt()
+	v.assembler.PushCharacter('-')
+	v.assembler.Emit(il.Eq)
+	v.assembler.Emit(il.Swap)
+	v.pick(1) // Stack: isNegative, s, isNegative
+	v.assembler.JmpFalse(positiveLabel)
+
+	v.assembler.Emit(il.Dup)
+	v.emitStringLength()
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Emit(il.Swap)
+	v.assembler.Roll(1)
+	v.emitSubstring()
+
+	// Digits
+	v.assembler.SetLabel(positiveLabel)
+	v.assembler.Emit(il.Dup)
+	v.emitStringLength()
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Gt)
+	v.assembler.JmpFalse(invalidLabel)
+	v.emitPowerOf256()
+	v.assembler.Emit(il.Swap)
+	v.emitStringToNumber()
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Mod)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.PushInt(big.NewInt(1)) // Stack: isNegative, s as number without header, value, factor
+
+	// Every character is a non-zero byte, therefore the string is processed, when the number is zero
+	v.assembler.SetLabel(loopLabel)
+	v.pick(2)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Gt)
+	v.assembler.JmpFalse(doneLabel)
+
+	v.pick(2)
+	v.assembler.PushInt(big.NewInt(256))
+	v.assembler.Emit(il.Mod)
+	v.assembler.PushInt(big.NewInt('0'))
+	v.assembler.Emit(il.Sub)
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.GtEq)
+	v.assembler.JmpFalse(invalidLabel)
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(9))
+	v.assembler.Emit(il.LtEq)
+	v.assembler.JmpFalse(invalidLabel) // Stack: isNegative, s, value, factor, digit
+
+	v.pick(1)
+	v.assembler.Emit(il.Mul)
+	v.assembler.Roll(1)
+	v.assembler.Emit(il.Add)
+	v.assembler.Emit(il.Swap)
+	v.assembler.PushInt(big.NewInt(10))
+	v.assembler.Emit(il.Mul) // Stack: isNegative, s, value + digit * factor, factor * 10
+
+	v.assembler.Roll(1)
+	v.assembler.PushInt(big.NewInt(256))
+	v.assembler.Emit(il.Div)
+	v.assembler.Roll(1)
+	v.assembler.Roll(1) // Stack: isNegative, s without the lowest character, value, factor
+	v.assembler.Jmp(loopLabel)
+
+	v.assembler.SetLabel(invalidLabel)
+	v.assembler.PushString(invalidIntegerMsg)
+	v.assembler.Emit(il.ErrHalt)
+
+	v.assembler.SetLabel(doneLabel)
+	v.assembler.Emit(il.Pop)
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Pop)
+	v.assembler.Emit(il.Swap)
+	v.assembler.JmpFalse(endLabel)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Sub)
+
+	v.assembler.SetLabel(endLabel)
+}
+
+// emitBoolToString replaces the boolean on the stack with "true" or "false"
+func (v *ILCodeGenerationVisitor) emitBoolToString() {
+	falseLabel := v.assembler.CreateLabel()
+	endLabel := v.assembler.CreateLabel()
+
+	v.assembler.JmpFalse(falseLabel)
+	v.assembler.PushString("true")
+	v.assembler.Jmp(endLabel)
+
+	v.assembler.SetLabel(falseLabel)
+	v.assembler.PushString("false")
+
+	v.assembler.SetLabel(endLabel)
+}
+
+// emitBoolToInt replaces the boolean on the stack with 1 (true) or 0 (false)
+func (v *ILCodeGenerationVisitor) emitBoolToInt() {
+	falseLabel := v.assembler.CreateLabel()
+	endLabel := v.assembler.CreateLabel()
+
+	v.assembler.JmpFalse(falseLabel)
+	v.assembler.PushInt(big.NewInt(1))
+	v.assembler.Jmp(endLabel)
+
+	v.assembler.SetLabel(falseLabel)
+	v.assembler.PushInt(big.NewInt(0))
+
+	v.assembler.SetLabel(endLabel)
+}
+
+// emitIntToChar replaces the ASCII code on the stack with the character.
+// The transaction is aborted, if the code is not within 0 and 127.
+func (v *ILCodeGenerationVisitor) emitIntToChar() {
+	validLabel := v.assembler.CreateLabel()
+	invalidLabel := v.assembler.CreateLabel()
+
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.GtEq)
+	v.assembler.JmpFalse(invalidLabel)
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(127))
+	v.assembler.Emit(il.LtEq)
+	v.assembler.JmpTrue(validLabel)
+
+	v.assembler.SetLabel(invalidLabel)
+	v.assembler.PushString(invalidASCIICodeMsg)
+	v.assembler.Emit(il.ErrHalt)
+
+	// The character is the string of length 1
+	v.assembler.SetLabel(validLabel)
+	v.assembler.PushInt(new(big.Int).Mul(new(big.Int).Add(numberStringHeader, big.NewInt(1)), big.NewInt(256)))
+	v.assembler.Emit(il.Add)
+	v.emitNumberToString()
+}
+
+// emitCharToInt replaces the character on the stack with its ASCII code
+func (v *ILCodeGenerationVisitor) emitCharToInt() {
+	v.emitStringToNumber()
+	v.assembler.PushInt(big.NewInt(256))
+	v.assembler.Emit(il.Mod)
+}
+
+// emitAbsoluteValue replaces the integer on the stack with its absolute value
+func (v *ILCodeGenerationVisitor) emitAbsoluteValue() {
+	positiveLabel := v.assembler.CreateLabel()
+
+	v.assembler.Emit(il.Dup)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.GtEq)
+	v.assembler.JmpTrue(positiveLabel)
+	v.assembler.PushInt(big.NewInt(0))
+	v.assembler.Emit(il.Swap)
+	v.assembler.Emit(il.Sub)
+
+	v.assembler.SetLabel(positiveLabel)
+}
@@ -2,8 +2,19 @@ package generator
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/bazo-blockchain/bazo-vm/vm"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"github.com/bazo-blockchain/lazo/generator/util"
+	"golang.org/x/crypto/sha3"
 	"gotest.tools/assert"
+	"math"
 	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -110,6 +121,51 @@ func TestFuncCallByHashWithParams(t *testing.T) {
 	tester.assertInt(big.NewInt(8))
 }
 
+func TestPrivateFuncCallByHash(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		int x
+
+		function void doNotCall() private {
+			x = 5
+		}
+	`, "()doNotCall()")
+
+	// The dispatcher does not find the function and halts without calling it
+	x, err := tester.context.GetContractVariable(0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(x), 0)
+}
+
+func TestPrivateFuncMetadata(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		function int doCall() {
+			return helper() + helper2()
+		}
+
+		function int helper() private {
+			return 1
+		}
+
+		function int helper2() internal {
+			return 2
+		}
+	`)
+
+	contract := tester.metadata.Contract
+	assert.Equal(t, len(contract.Functions), 3)
+	assert.Equal(t, contract.Functions[0].Public, true)
+	assert.Equal(t, contract.Functions[1].Public, false)
+	assert.Equal(t, contract.Functions[2].Public, false)
+
+	abi := tester.metadata.CreateABI()
+	assert.Equal(t, len(abi.Functions), 1)
+	assert.Equal(t, abi.Functions[0].Name, "doCall")
+
+	artifact := tester.metadata.CreateArtifact()
+	assert.Equal(t, len(artifact.Functions), 1)
+	assert.Equal(t, artifact.Functions[0].Identifier, "doCall")
+}
+
 // Statements
 // ----------
 
@@ -586,6 +642,156 @@ func TestSetter(t *testing.T) {
 	tester.compareBytes(tester.context.ContractVariables[0], []byte{0, 5})
 }
 
+// Loop statements
+// ---------------
+
+func TestForStatement(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			int sum
+			for (int i : 0..5) {
+				sum += i
+			}
+			return sum
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(10))
+}
+
+func TestForStatementEmptyRange(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			int sum = 7
+			for (int i : 5..2) {
+				sum += i
+			}
+			return sum
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(7))
+}
+
+func TestForStatementUpperBoundEvaluatedOnce(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			int n = 3
+			int total
+			for (int i : 0..n) {
+				n += 1
+				total++
+			}
+			return total
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(3))
+}
+
+func TestNestedForStatement(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			int sum
+			for (int i : 0..3) {
+				for (int j : 0..i) {
+					sum += 10
+				}
+				sum++
+			}
+			return sum
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(33))
+}
+
+func TestReturnInForStatement(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			for (int i : 0..10) {
+				if (i * i > 20) {
+					return i
+				}
+			}
+			return -1
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(5))
+}
+
+func TestForEachStatement(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			int[] arr = new int[]{1, 2, 3, 4}
+			int sum
+			foreach (x in arr) {
+				sum += x
+			}
+			return sum
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(10))
+}
+
+func TestForEachStatementOnField(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		bool[] flags = new bool[]{true, false, true}
+		int total
+
+		constructor() {
+			foreach (bool b in flags) {
+				if (b) {
+					total++
+				}
+			}
+		}
+	`)
+
+	tester.assertVariableInt(1, big.NewInt(2))
+}
+
+// Abort statements
+// ----------------
+
+func TestRequireStatementPasses(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			int x = 5
+			require(x > 0, "x must be positive")
+			return x
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(5))
+}
+
+func TestAssertStatementPasses(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function bool test() {
+			assert(1 + 1 == 2)
+			return true
+		}
+	`, boolTestSig)
+
+	tester.assertBool(true)
+}
+
+func TestRevertStatementNotReached(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			if (false) {
+				revert("unreachable")
+			}
+			return 1
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(1))
+}
+
 // Function Calls
 // --------------
 
@@ -637,6 +843,24 @@ func TestFuncCallVoid(t *testing.T) {
 	tester.compareBytes(tester.context.ContractVariables[0], []byte{0, 5})
 }
 
+func TestPrivateFuncCall(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			return add(10, 20) + this.double(3)
+		}
+
+		function int add(int x, int y) private {
+			return x + y
+		}
+
+		function int double(int x) public {
+			return x * 2
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(36))
+}
+
 // Struct
 // ------
 
@@ -931,7 +1155,12 @@ func TestUninitializedArray(t *testing.T) {
 
 	variable, err := tester.context.GetContractVariable(0)
 	assert.NilError(t, err)
-	tester.compareBytes(variable, []byte{})
+
+	expected := []byte{
+		0x02,       // array type
+		0x00, 0x00, // array length = 0
+	}
+	tester.compareBytes(variable, expected)
 }
 
 func TestElementAssignment1(t *testing.T) {
@@ -1019,10 +1248,51 @@ func TestArrayLengthCreation(t *testing.T) {
 
 func TestNestedArrayLengthCreation(t *testing.T) {
 	tester := newGeneratorTestUtil(t, `
-		int[][] a = new int[2][2]
+		int[][] a = new int[2][1]
 	`)
 
-	tester.assertErrorAt(0, "Generator currently does not support array nesting")
+	expected := []byte{
+		0x02,       // array type
+		0x00, 0x02, // size = 2
+		0x00, 0x06, // length of first element
+		0x02, 0x00, 0x01, 0x00, 0x01, 0x00, // first element = int[1]
+		0x00, 0x06, // length of second element
+		0x02, 0x00, 0x01, 0x00, 0x01, 0x00, // second element = int[1]
+	}
+
+	variable, err := tester.context.GetContractVariable(0)
+	assert.NilError(t, err)
+	tester.compareBytes(variable, expected)
+}
+
+func TestNestedArrayLengthCreationInFunction(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (int, int, int) test() {
+			int[][][] a = new int[2][3][4]
+			return a.length, a[1].length, a[1][2].length
+		}
+	`, "(int,int,int)test()")
+
+	tester.assertIntAt(0, big.NewInt(2))
+	tester.assertIntAt(1, big.NewInt(3))
+	tester.assertIntAt(2, big.NewInt(4))
+}
+
+func TestStructArrayLengthCreation(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		struct Person {
+			int balance
+		}
+
+		function (int, int) test() {
+			Person[] p = new Person[2]
+			p[1].balance = 5
+			return p[0].balance, p[1].balance
+		}
+	`, "(int,int)test()")
+
+	tester.assertIntAt(0, big.NewInt(0))
+	tester.assertIntAt(1, big.NewInt(5))
 }
 
 func TestArrayValueCreation(t *testing.T) {
@@ -1045,25 +1315,47 @@ func TestArrayValueCreation(t *testing.T) {
 }
 
 func TestNestedArrayValueCreation(t *testing.T) {
-	tester := newGeneratorTestUtil(t, `
-		int[][] a = new int[][]{{1, 2}, {3}}
-	`)
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (int, int, int) test() {
+			int[][] a = new int[][]{{1, 2}, {3}}
+			return a[0][1], a[1][0], a[1].length
+		}
+	`, "(int,int,int)test()")
 
-	tester.assertErrorAt(0, "Generator currently does not support array nesting")
+	tester.assertIntAt(0, big.NewInt(2))
+	tester.assertIntAt(1, big.NewInt(3))
+	tester.assertIntAt(2, big.NewInt(1))
 }
 
-func TestArrayInStructUpdateError(t *testing.T) {
-	tester := newGeneratorTestUtil(t, `
+func TestNestedArrayElementAssignment(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (int, int, int) test() {
+			int[][] a = new int[2][2]
+			a[1][0] = 4
+			a[1][1] += 3
+			return a[0][0], a[1][0], a[1][1]
+		}
+	`, "(int,int,int)test()")
+
+	tester.assertIntAt(0, big.NewInt(0))
+	tester.assertIntAt(1, big.NewInt(4))
+	tester.assertIntAt(2, big.NewInt(3))
+}
+
+func TestArrayInStructElementUpdate(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
 		struct Person{
 			int[] nums
 		}
-		constructor(){
+
+		function int test(){
 			Person p = new Person(nums = new int[2])
 			p.nums[0] = 1
+			return p.nums[0]
 		}
-	`)
+	`, intTestSig)
 
-	tester.assertErrorAt(0, "Multiple dereferences on value types are not supported")
+	tester.assertInt(big.NewInt(1))
 }
 
 func TestArrayInStructUpdate(t *testing.T) {
@@ -1085,12 +1377,12 @@ func TestArrayInStructUpdate(t *testing.T) {
 	tester.assertInt(big.NewInt(1))
 }
 
-func TestStructArrayUpdateError(t *testing.T) {
+func TestStructArrayFieldUpdate(t *testing.T) {
 	tester := newGeneratorTestUtilWithFunc(t, `
 		struct Person{
 			int balance
 		}
-		
+
 		function int test(){
 			Person[] p = new Person[2]
 			p[0] = new Person(100)
@@ -1100,7 +1392,7 @@ func TestStructArrayUpdateError(t *testing.T) {
 		}
 	`, intTestSig)
 
-	tester.assertErrorAt(0, "Updating struct value type in array/map is not supported")
+	tester.assertInt(big.NewInt(101))
 }
 
 func TestStructArrayUpdate(t *testing.T) {
@@ -1124,6 +1416,116 @@ func TestStructArrayUpdate(t *testing.T) {
 	tester.assertInt(big.NewInt(101))
 }
 
+// Array Member Functions
+// ----------------------
+
+func TestArrayAppend(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (int, int, int) test() {
+			int[] a
+			a.append(4)
+			a.append(7)
+			return a.length, a[0], a[1]
+		}
+	`, "(int,int,int)test()")
+
+	tester.assertIntAt(0, big.NewInt(2))
+	tester.assertIntAt(1, big.NewInt(4))
+	tester.assertIntAt(2, big.NewInt(7))
+}
+
+func TestArrayAppendToField(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		int[] a = new int[]{1}
+
+		constructor() {
+			a.append(2)
+			this.a.append(3)
+		}
+	`)
+
+	tester.context.PersistChanges()
+	expected := []byte{
+		0x02,       // array type
+		0x00, 0x03, // array length = 3
+		0x00, 0x02, 0x00, 0x01, // int 1
+		0x00, 0x02, 0x00, 0x02, // int 2
+		0x00, 0x02, 0x00, 0x03, // int 3
+	}
+	tester.compareBytes(tester.context.ContractVariables[0], expected)
+}
+
+func TestArrayAppendToStructField(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		struct Wallet {
+			int[] amounts
+		}
+
+		function (int, int) test() {
+			Wallet w
+			w.amounts.append(5)
+			return w.amounts.length, w.amounts[0]
+		}
+	`, "(int,int)test()")
+
+	tester.assertIntAt(0, big.NewInt(1))
+	tester.assertIntAt(1, big.NewInt(5))
+}
+
+func TestArrayInsert(t *testing.T) {
+	code := `
+		function (int, int) test() {
+			int[] a = new int[]{1, 2, 3}
+			a.insert(%d, 9)
+			return a.length, a[0] * 1000 + a[1] * 100 + a[2] * 10 + a[3]
+		}
+	`
+
+	expectations := map[int]int64{
+		0: 9123,
+		1: 1923,
+		3: 1239,
+	}
+	for index, expected := range expectations {
+		tester := newGeneratorTestUtilWithFunc(t, fmt.Sprintf(code, index), "(int,int)test()")
+		tester.assertIntAt(0, big.NewInt(4))
+		tester.assertIntAt(1, big.NewInt(expected))
+	}
+}
+
+func TestArrayRemove(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (int, int, int) test() {
+			int[] a = new int[]{1, 2, 3}
+			a.remove(1)
+			return a.length, a[0], a[1]
+		}
+	`, "(int,int,int)test()")
+
+	tester.assertIntAt(0, big.NewInt(2))
+	tester.assertIntAt(1, big.NewInt(1))
+	tester.assertIntAt(2, big.NewInt(3))
+}
+
+func TestArrayMemberFunctionsInLoop(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			int[] a
+			for (int i : 0..4) {
+				a.insert(0, i)
+			}
+			a.remove(0)
+			int sum
+			foreach (int x in a) {
+				sum = sum * 10 + x
+			}
+			return sum
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(210))
+}
+
 // Map
 // ---
 
@@ -1265,20 +1667,23 @@ func TestMapContainsKeyFalse(t *testing.T) {
 	tester.assertBool(false)
 }
 
-func TestMapStructValError(t *testing.T) {
+func TestMapStructValFieldUpdate(t *testing.T) {
 	tester := newGeneratorTestUtilWithFunc(t, `
 		struct Person {
 			int balance
 		}
-	
-		constructor() {
+
+		function (int, int) test() {
 			Map<char, Person> m
 			m['a'] = new Person(1000)
 			m['a'].balance = 1001
+			m['b'].balance = 5
+			return m['a'].balance, m['b'].balance
 		}
-	`, intTestSig)
+	`, "(int,int)test()")
 
-	tester.assertErrorAt(0, "Updating struct value type in array/map is not supported")
+	tester.assertIntAt(0, big.NewInt(1001))
+	tester.assertIntAt(1, big.NewInt(5))
 }
 
 func TestMapStructVal(t *testing.T) {
@@ -1322,20 +1727,70 @@ func TestMapStructValOverride(t *testing.T) {
 	tester.assertInt(big.NewInt(1001))
 }
 
-func TestMapArrayValueUpdateError(t *testing.T) {
-	tester := newGeneratorTestUtil(t, `
-		constructor(){
+func TestMapArrayValueElementUpdate(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test(){
 			Map<String, int[]> m
 			m["a"] = new int[2]
 
 			m["a"][0] = 2
+			return m["a"][0]
 		}
-	`)
+	`, intTestSig)
 
-	tester.assertErrorAt(0, "Multiple dereferences on value types are not supported")
+	tester.assertInt(big.NewInt(2))
 }
 
-func TestMapArrayValueUpdate(t *testing.T) {
+func TestMapArrayValueAppend(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (int, int) test() {
+			Map<address, int[]> payments
+			payments[msg.sender].append(3)
+			payments[msg.sender].append(4)
+			return payments[msg.sender].length, payments[msg.sender][1]
+		}
+	`, "(int,int)test()")
+
+	tester.assertIntAt(0, big.NewInt(2))
+	tester.assertIntAt(1, big.NewInt(4))
+}
+
+func TestNestedMap(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (int, int, bool) test() {
+			Map<int, Map<int, int>> m
+			m[1][2] = 3
+			m[1][4] = 5
+			m[1][2] += 1
+			delete m[1][4]
+			return m[1][2], m[1].contains(2) ? 1 : 0, m[1].contains(4)
+		}
+	`, "(int,int,bool)test()")
+
+	tester.assertIntAt(0, big.NewInt(4))
+	tester.assertIntAt(1, big.NewInt(1))
+	tester.assertBoolAt(2, false)
+}
+
+func TestStructFieldInNestedCollections(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		struct Account {
+			int[] history
+		}
+
+		function int test() {
+			Map<int, Account[]> m
+			m[7] = new Account[2]
+			m[7][1].history.append(9)
+			m[7][1].history[0] *= 2
+			return m[7][1].history[0]
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(18))
+}
+
+func TestMapArrayValueUpdate(t *testing.T) {
 	tester := newGeneratorTestUtilWithFunc(t, `
 		function int test(){
 			Map<String, int[]> m
@@ -1370,6 +1825,30 @@ func TestMapElementMultiAssignment(t *testing.T) {
 	tester.assertIntAt(1, big.NewInt(20))
 }
 
+func TestNestedMapKeysEvaluatedOnce(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		int calls
+
+		function (int, int) test() {
+			calls = 0
+			Map<int, Map<int, Map<int, int>>> m
+			m[next()][next()][next()] = 7
+			m[1][2][next() - 1] += 1
+			m[1][2][4] = 0
+			delete m[1][next() - 3][4]
+			return calls, m[1][2][3]
+		}
+
+		function int next() {
+			calls++
+			return calls
+		}
+	`, "(int,int)test()")
+
+	tester.assertIntAt(0, big.NewInt(5))
+	tester.assertIntAt(1, big.NewInt(8))
+}
+
 // Ternary Expression
 // ------------------
 
@@ -1534,7 +2013,135 @@ func TestStringConcatenation(t *testing.T) {
 		}
 	`, stringTestSig)
 
-	tester.assertErrorAt(0, "String concatenation is not supported")
+	tester.assertString("HelloWorld")
+}
+
+func TestStringConcatenationWithEmptyString(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (String, String, String) test() {
+			return "" + "a", "b" + "", "" + ""
+		}
+	`, "(String,String,String)test()")
+
+	tester.assertBytesAt(0, 'a')
+	tester.assertBytesAt(1, 'b')
+	tester.assertBytesAt(2)
+}
+
+func TestStringConcatenationChain(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function String test() {
+			String s = "a"
+			for (int i : 0..3) {
+				s += "-" + s
+			}
+			return s + "!"
+		}
+	`, stringTestSig)
+
+	tester.assertString("a-a-a-a-a-a-a-a!")
+}
+
+func TestStringConcatenationInField(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		String s = "Hello" + " " + "World"
+	`)
+
+	variable, err := tester.context.GetContractVariable(0)
+	assert.NilError(t, err)
+	tester.compareBytes(variable, []byte("Hello World"))
+}
+
+// String Member Functions
+// -----------------------
+
+func TestStringLength(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (int, int, bool) test() {
+			String s = "Hello"
+			String empty
+			return s.length(), empty.length(), s.length() == 5
+		}
+	`, "(int,int,bool)test()")
+
+	tester.assertIntAt(0, big.NewInt(5))
+	tester.assertIntAt(1, big.NewInt(0))
+	tester.assertBoolAt(2, true)
+}
+
+func TestStringAt(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (char, char, bool) test() {
+			String s = "Hello"
+			return s.at(0), s.at(4), s.at(1) == 'e'
+		}
+	`, "(char,char,bool)test()")
+
+	tester.assertBytesAt(0, 'H')
+	tester.assertBytesAt(1, 'o')
+	tester.assertBoolAt(2, true)
+}
+
+func TestSubstring(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (String, String, String) test() {
+			String s = "Hello World"
+			return s.substring(0, 5), s.substring(6, 11), s.substring(3, 3)
+		}
+	`, "(String,String,String)test()")
+
+	tester.assertBytesAt(0, []byte("Hello")...)
+	tester.assertBytesAt(1, []byte("World")...)
+	tester.assertBytesAt(2)
+}
+
+func TestSubstringWithLeadingZeroBytes(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function String test() {
+			String s = "0a0" + "00"
+			return s.substring(1, 4)
+		}
+	`, stringTestSig)
+
+	tester.assertString("a00")
+}
+
+func TestStringEquals(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function (bool, bool, bool) test() {
+			String s = "Hello"
+			String t = "Hel" + "lo"
+			return s.equals("Hello"), s.equals("hello"), t.equals(s)
+		}
+	`, "(bool,bool,bool)test()")
+
+	tester.assertBoolAt(0, true)
+	tester.assertBoolAt(1, false)
+	tester.assertBoolAt(2, true)
+}
+
+func TestStringContains(t *testing.T) {
+	code := `
+		function bool test() {
+			String s = "Hello World"
+			return s.contains("%s")
+		}
+	`
+
+	expectations := map[string]bool{
+		"Hello":        true,
+		"World":        true,
+		"o W":          true,
+		"":             true,
+		"Hello World":  true,
+		"world":        false,
+		"Hello World!": false,
+		"lo Wd":        false,
+	}
+	for part, expected := range expectations {
+		tester := newGeneratorTestUtilWithFunc(t, fmt.Sprintf(code, part), boolTestSig)
+		tester.assertBool(expected)
+	}
 }
 
 // Logical Expressions
@@ -1603,13 +2210,99 @@ func TestBitwiseXOr(t *testing.T) {
 // ---------
 
 func TestIntToStringTypeCast(t *testing.T) {
-	tester := newGeneratorTestUtilWithFunc(t, `
+	code := `
 		function String test() {
-			return (String) 1
+			int i = %s
+			return (String) i
 		}
-	`, stringTestSig)
+	`
 
-	tester.assertErrorAt(0, "VM currently does not support types")
+	for _, value := range []string{"0", "7", "10", "123", "-5", "-120", "9876543210"} {
+		tester := newGeneratorTestUtilWithFunc(t, fmt.Sprintf(code, value), stringTestSig)
+		tester.assertString(value)
+	}
+}
+
+func TestBoolToStringTypeCast(t *testing.T) {
+	assertStringExpr := func(expr string, expected string) {
+		tester := newGeneratorTestUtilWithFunc(t, fmt.Sprintf(`
+			function String test() {
+				return %s
+			}
+		`, expr), stringTestSig)
+		tester.assertString(expected)
+	}
+
+	assertStringExpr("(String) true", "true")
+	assertStringExpr("(String) false", "false")
+	assertStringExpr("(String) 'a'", "a")
+	assertStringExpr(`(String) "lazo"`, "lazo")
+	assertStringExpr(`"n = " + (String) 42`, "n = 42")
+}
+
+func TestStringToIntTypeCast(t *testing.T) {
+	code := `
+		function int test() {
+			String s = "%s"
+			return (int) s
+		}
+	`
+
+	expectations := map[string]int64{
+		"0":          0,
+		"7":          7,
+		"0042":       42,
+		"123":        123,
+		"-5":         -5,
+		"-0":         0,
+		"9876543210": 9876543210,
+	}
+	for value, expected := range expectations {
+		tester := newGeneratorTestUtilWithFunc(t, fmt.Sprintf(code, value), intTestSig)
+		tester.assertInt(big.NewInt(expected))
+	}
+}
+
+func TestCharTypeCast(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			char c = 'a'
+			return (int) c
+		}
+	`, intTestSig)
+	tester.assertInt(big.NewInt(97))
+
+	tester = newGeneratorTestUtilWithFunc(t, `
+		function char test() {
+			int i = 98
+			return (char) i
+		}
+	`, charTestSig)
+	tester.assertChar('b')
+
+	tester = newGeneratorTestUtilWithFunc(t, `
+		function char test() {
+			return (char) 0
+		}
+	`, charTestSig)
+	tester.assertBytes(0)
+}
+
+func TestBoolToIntTypeCast(t *testing.T) {
+	assertIntExpr(t, "(int) true", 1)
+	assertIntExpr(t, "(int) false", 0)
+	assertIntExpr(t, "(int) false + (int) true", 1)
+}
+
+func TestTypeCastInFieldInitializer(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		int i = (int) "-34"
+		String s = (String) 120
+	`)
+	tester.assertVariableInt(0, big.NewInt(-34))
+	bytes, err := tester.context.GetContractVariable(1)
+	assert.NilError(t, err)
+	tester.compareBytes(bytes, []byte("120"))
 }
 
 // Equality Comparison
@@ -1743,3 +2436,691 @@ func TestArrayLengthMemberAccess(t *testing.T) {
 	tester.context.PersistChanges()
 	tester.compareBytes(tester.context.ContractVariables[1], []byte{0, 2})
 }
+
+// Built-in Fields
+// ---------------
+
+func TestMsgSender(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function address test() {
+			return msg.sender
+		}
+	`, "(address)test()")
+
+	tester.assertBytes(make([]byte, 32)...)
+}
+
+func TestContractOwnerAndSender(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function bool test() {
+			return msg.sender == contract.owner
+		}
+	`, boolTestSig)
+
+	tester.assertBool(true)
+}
+
+func TestContractAddress(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		publicKey account
+
+		constructor() {
+			account = contract.address
+		}
+	`)
+
+	tester.context.PersistChanges()
+	tester.compareBytes(tester.context.ContractVariables[0], make([]byte, 64))
+}
+
+func TestMsgValue(t *testing.T) {
+	amounts := []uint64{0, 1, 256, 0x0102030405060708, math.MaxUint64}
+	for _, amount := range amounts {
+		tester := newGeneratorTestUtilWithContext(t, `
+			function int test() payable {
+				return msg.value
+			}
+		`, func(context *vm.MockContext) {
+			context.Amount = amount
+		}, intTestSig)
+
+		tester.assertInt(new(big.Int).SetUint64(amount))
+	}
+}
+
+func TestContractBalance(t *testing.T) {
+	tester := newGeneratorTestUtilWithContext(t, `
+		function int test() {
+			return contract.balance - 1
+		}
+	`, func(context *vm.MockContext) {
+		context.Balance = 1000
+	}, intTestSig)
+
+	tester.assertInt(big.NewInt(999))
+}
+
+func TestMsgValueInModifier(t *testing.T) {
+	tester := newGeneratorTestUtilWithContext(t, `
+		modifier minAmount(int amount) {
+			require(msg.value >= amount, "Amount too small")
+			_
+		}
+
+		function int test() payable minAmount(10) {
+			int total = 1
+			total += msg.value
+			return total
+		}
+	`, func(context *vm.MockContext) {
+		context.Amount = 300
+	}, intTestSig)
+
+	tester.assertInt(big.NewInt(301))
+}
+
+// Addresses
+// ---------
+
+func TestAddressLiteral(t *testing.T) {
+	address := strings.Repeat("0f", 32)
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function address test() {
+			return @`+address+`
+		}
+	`, "(address)test()")
+
+	expected, _ := hex.DecodeString(address)
+	tester.assertBytes(expected...)
+}
+
+func TestAddressDefaultValue(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function bool test() {
+			address a
+			return a == msg.sender
+		}
+	`, boolTestSig)
+
+	tester.assertBool(true)
+}
+
+func TestAddressInequality(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function bool test() {
+			return msg.sender != @`+strings.Repeat("01", 32)+`
+		}
+	`, boolTestSig)
+
+	tester.assertBool(true)
+}
+
+func TestAddressMapKey(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			Map<address, int> balances
+			balances[msg.sender] = 5
+			balances[@`+strings.Repeat("01", 32)+`] = 3
+			return balances[msg.sender]
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(5))
+}
+
+// Cryptographic Functions
+// -----------------------
+
+func TestSha3(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function hash test() {
+			return sha3("hello")
+		}
+	`, "(hash)test()")
+
+	expected := sha3.Sum256([]byte("hello"))
+	tester.assertBytes(expected[:]...)
+}
+
+func TestSha3Equality(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function bool test() {
+			hash commitment = sha3("yes")
+			return sha3("yes") == commitment && sha3("no") != commitment
+		}
+	`, boolTestSig)
+
+	tester.assertBool(true)
+}
+
+func TestHashDefaultValue(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function hash test() {
+			hash h
+			return h
+		}
+	`, "(hash)test()")
+
+	tester.assertBytes(make([]byte, 32)...)
+}
+
+const checkSigTestCode = `
+	function bool test(publicKey key) {
+		return checkSig(sha3("hello"), key)
+	}
+`
+
+func TestCheckSig(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	digest := sha3.Sum256([]byte("hello"))
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+	assert.NilError(t, err)
+
+	sign := func(context *vm.MockContext) {
+		copy(context.Sig1[32-len(r.Bytes()):32], r.Bytes())
+		copy(context.Sig1[64-len(s.Bytes()):], s.Bytes())
+	}
+
+	key := make([]byte, 64)
+	copy(key[32-len(privateKey.X.Bytes()):32], privateKey.X.Bytes())
+	copy(key[64-len(privateKey.Y.Bytes()):], privateKey.Y.Bytes())
+	funcData := append([]byte{64}, key...)
+
+	tester := newGeneratorTestUtilWithContext(t, checkSigTestCode, sign, "(bool)test(publicKey)", funcData...)
+	tester.assertBool(true)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+	copy(key[32-len(otherKey.X.Bytes()):32], otherKey.X.Bytes())
+	copy(key[64-len(otherKey.Y.Bytes()):], otherKey.Y.Bytes())
+	funcData = append([]byte{64}, key...)
+
+	tester = newGeneratorTestUtilWithContext(t, checkSigTestCode, sign, "(bool)test(publicKey)", funcData...)
+	tester.assertBool(false)
+}
+
+// Artifact
+// --------
+
+func TestContractArtifact(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		int x
+		bool y
+
+		function int test() {
+			return x
+		}
+	`)
+
+	artifact := tester.metadata.CreateArtifact()
+	assert.Equal(t, artifact.Contract, "Test")
+	assert.Equal(t, artifact.TotalFields, uint16(2))
+	assert.Equal(t, len(artifact.Functions), 1)
+	assert.Equal(t, artifact.Functions[0].Identifier, "test")
+
+	funcHash := util.CreateFuncHash(intTestSig)
+	assert.Equal(t, artifact.Functions[0].Hash, "0x"+hex.EncodeToString(funcHash[:]))
+}
+
+func TestContractABI(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		struct Person {
+			String name
+			int balance
+		}
+
+		Map<int, Person> people
+		bool isOpen
+
+		constructor(bool open) {
+			isOpen = open
+		}
+
+		function (int, bool) test(int x, char c) {
+			return x, isOpen
+		}
+	`, 1, 1)
+
+	abi := tester.metadata.CreateABI()
+	assert.Equal(t, abi.Contract, "Test")
+
+	assert.Equal(t, len(abi.Constructor), 1)
+	assert.Equal(t, *abi.Constructor[0], data.VariableABI{Name: "open", Type: "bool"})
+
+	assert.Equal(t, len(abi.Fields), 2)
+	assert.Equal(t, *abi.Fields[0], data.FieldABI{Index: 0, Name: "people", Type: "Map<int,Person>"})
+	assert.Equal(t, *abi.Fields[1], data.FieldABI{Index: 1, Name: "isOpen", Type: "bool"})
+
+	assert.Equal(t, len(abi.Structs), 1)
+	assert.Equal(t, abi.Structs[0].Name, "Person")
+	assert.Equal(t, *abi.Structs[0].Fields[0], data.FieldABI{Index: 0, Name: "name", Type: "String"})
+	assert.Equal(t, *abi.Structs[0].Fields[1], data.FieldABI{Index: 1, Name: "balance", Type: "int"})
+
+	assert.Equal(t, len(abi.Functions), 1)
+	function := abi.Functions[0]
+	assert.Equal(t, function.Name, "test")
+	assert.Equal(t, *function.Parameters[0], data.VariableABI{Name: "x", Type: "int"})
+	assert.Equal(t, *function.Parameters[1], data.VariableABI{Name: "c", Type: "char"})
+	assert.DeepEqual(t, function.ReturnTypes, []string{"int", "bool"})
+	assert.Equal(t, function.Signature, "(int,bool)test(int,char)")
+
+	funcHash := util.CreateFuncHash(function.Signature)
+	assert.Equal(t, function.Hash, "0x"+hex.EncodeToString(funcHash[:]))
+}
+
+func TestEventMetadata(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		int total
+		event Transfer(address to, int amount)
+		event Opened()
+	`)
+
+	contract := tester.metadata.Contract
+	assert.Equal(t, contract.TotalFields, uint16(2))
+	assert.Equal(t, contract.EventLogIndex(), 1)
+	assert.Equal(t, len(contract.Events), 2)
+	assert.Equal(t, contract.Events[0].Signature, "Transfer(address,int)")
+	assert.Equal(t, contract.Events[0].Hash, util.CreateFuncHash("Transfer(address,int)"))
+	assert.Equal(t, contract.Events[1].Signature, "Opened()")
+
+	abi := tester.metadata.CreateABI()
+	assert.Equal(t, len(abi.Events), 2)
+	event := abi.Events[0]
+	assert.Equal(t, event.Name, "Transfer")
+	assert.Equal(t, *event.Parameters[0], data.VariableABI{Name: "to", Type: "address"})
+	assert.Equal(t, *event.Parameters[1], data.VariableABI{Name: "amount", Type: "int"})
+	assert.Equal(t, event.Hash, "0x"+hex.EncodeToString(contract.Events[0].Hash[:]))
+
+	// The event log is initialized with every transaction
+	log, err := tester.context.GetContractVariable(1)
+	assert.NilError(t, err)
+	tester.compareBytes(log, []byte{2, 0, 0})
+}
+
+func TestEmitEvent(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		event Transfer(int amount, bool done)
+
+		constructor() {
+			emit Transfer(5, true)
+			emit Transfer(-1, false)
+		}
+	`)
+
+	hash := util.CreateFuncHash("Transfer(int,bool)")
+	event1 := []byte{2, 0, 3, 0, 4}
+	event1 = append(event1, hash[:]...)
+	event1 = append(event1, 0, 2, 0, 5, 0, 1, 1)
+	event2 := []byte{2, 0, 3, 0, 4}
+	event2 = append(event2, hash[:]...)
+	event2 = append(event2, 0, 2, 1, 1, 0, 1, 0)
+
+	expected := []byte{2, 0, 2, 0, byte(len(event1))}
+	expected = append(expected, event1...)
+	expected = append(expected, 0, byte(len(event2)))
+	expected = append(expected, event2...)
+	log, err := tester.context.GetContractVariable(0)
+	assert.NilError(t, err)
+	tester.compareBytes(log, expected)
+}
+
+func TestContractWithoutEvents(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		int total
+	`)
+	assert.Equal(t, tester.metadata.Contract.TotalFields, uint16(1))
+	assert.Equal(t, len(tester.metadata.CreateABI().Events), 0)
+}
+
+// Payable functions
+// -----------------
+
+func TestPayableFunction(t *testing.T) {
+	tester := newGeneratorTestUtilWithContext(t, `
+		function int deposit() payable {
+			return 1
+		}
+	`, func(context *vm.MockContext) {
+		context.Amount = 5
+	}, "(int)deposit()")
+
+	tester.assertInt(big.NewInt(1))
+	assert.Equal(t, tester.metadata.Contract.Functions[0].Payable, true)
+	assert.Equal(t, tester.metadata.CreateABI().Functions[0].Payable, true)
+}
+
+func TestPayableConstructor(t *testing.T) {
+	tester := newGeneratorTestUtilWithSetup(t, `contract Test {
+		int x
+
+		constructor() payable {
+			x = 1
+		}
+	}`, []byte{1, 0}, func(context *vm.MockContext) {
+		context.Amount = 5
+	})
+
+	x, err := tester.context.GetContractVariable(0)
+	assert.NilError(t, err)
+	tester.compareBytes(x, []byte{0, 1})
+}
+
+func TestNonPayableFunctionWithoutCallValue(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			return 1
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(1))
+	assert.Equal(t, tester.metadata.CreateABI().Functions[0].Payable, false)
+}
+
+func TestNotPayableErrorIsShared(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			return 1
+		}
+
+		function int test2() {
+			return 2
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(1))
+	byteCode, _ := tester.metadata.CreateContract()
+	assert.Equal(t, bytes.Count(byteCode, []byte("Function does not accept Bazo coins")), 1)
+}
+
+// Modifiers
+// ---------
+
+func TestModifier(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function int test() {
+			return double(21)
+		}
+
+		modifier positive(int x) {
+			require(x > 0, "Not positive")
+			_
+		}
+
+		function int double(int x) private positive(x) {
+			return x * 2
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(42))
+}
+
+func TestNestedModifiers(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		int total
+
+		modifier wrap(int factor) {
+			total += factor
+			_
+			total *= factor
+		}
+
+		constructor() {
+			run()
+		}
+
+		function void run() private wrap(2) wrap(3) {
+			total += 10
+		}
+	`)
+
+	// ((2 + 3 + 10) * 3) * 2
+	tester.assertVariableInt(0, big.NewInt(90))
+}
+
+func TestModifierVariables(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		int result
+
+		modifier check(int x) {
+			int y = x * 2
+			_
+			for (int i : 0..3) {
+				result += y + x
+			}
+		}
+
+		constructor() {
+			run(5)
+		}
+
+		function void run(int x) private check(x + 1) {
+			int y = 100
+			for (int i : 0..2) {
+				result += x + y
+			}
+		}
+	`)
+
+	// Function: 2 * (5 + 100), modifier: 3 * (12 + 6)
+	tester.assertVariableInt(0, big.NewInt(264))
+}
+
+func TestReturnInModifiedFunction(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		bool locked
+		int a
+		int b
+
+		modifier noReentry() {
+			require(!locked, "Reentrant call")
+			locked = true
+			_
+			locked = false
+		}
+
+		constructor() {
+			a, b = get(true)
+			a, b = get(false)
+		}
+
+		function (int, int) get(bool early) private noReentry() {
+			if (early) {
+				return 1, 2
+			}
+			return 3, 4
+		}
+	`)
+
+	locked, err := tester.context.GetContractVariable(0)
+	assert.NilError(t, err)
+	tester.compareBytes(locked, []byte{0})
+	tester.assertVariableInt(1, big.NewInt(3))
+	tester.assertVariableInt(2, big.NewInt(4))
+}
+
+func TestVoidReturnInNestedModifiers(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		int total
+
+		modifier wrap(int factor) {
+			total += factor
+			_
+			total *= factor
+		}
+
+		constructor() {
+			run()
+		}
+
+		function void run() private wrap(2) wrap(3) {
+			total += 10
+			return
+			total += 100
+		}
+	`)
+
+	// ((2 + 3 + 10) * 3) * 2
+	tester.assertVariableInt(0, big.NewInt(90))
+}
+
+// Constants
+// ---------
+
+func TestConstant(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		const int FEE = 1000
+		const int TOTAL = FEE * 3 - 1
+		int x
+
+		function int test() {
+			int y = 2
+			return y * TOTAL
+		}
+	`, intTestSig)
+
+	tester.assertInt(big.NewInt(5998))
+	assert.Equal(t, tester.metadata.Contract.TotalFields, uint16(1))
+}
+
+func TestConstantTypes(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		const String NAME = "Lazo"
+		const char SUFFIX = '!'
+
+		function String test() {
+			if (SUFFIX == '!') {
+				return NAME + " Token"
+			}
+			return NAME
+		}
+	`, stringTestSig)
+
+	tester.assertString("Lazo Token")
+}
+
+func TestFoldedExpressionMatchesRuntime(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		const int DIVIDEND = -7
+
+		function bool test() {
+			int x = -7
+			return x / 2 == DIVIDEND / 2 && x % 3 == DIVIDEND % 3 && x ** 3 == DIVIDEND ** 3
+		}
+	`, boolTestSig)
+
+	tester.assertBool(true)
+}
+
+func TestLargeConstantExpressionsAtRuntime(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, `
+		function bool test() {
+			String s = "`+strings.Repeat("a", 200)+`" + "`+strings.Repeat("b", 200)+`"
+			return 2 ** 2040 / 2 ** 2039 == 2 && s.length() == 400
+		}
+	`, boolTestSig)
+
+	tester.assertBool(true)
+}
+
+func TestTooLargeLiterals(t *testing.T) {
+	tester := newGeneratorTestUtilWithFunc(t, fmt.Sprintf(`
+		function bool test() {
+			String s = "%s"
+			int x = %s
+			return s.length() > x
+		}
+	`, strings.Repeat("a", 256), new(big.Int).Lsh(big.NewInt(1), 2040).String()), boolTestSig)
+
+	tester.assertErrorAt(0, "String with 256 bytes exceeds the maximum of 255 bytes")
+	tester.assertErrorAt(1, "Integer with 256 bytes exceeds the maximum of 255 bytes")
+}
+
+// Enums
+// -----
+
+func TestEnumLowering(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		enum State { Open, Closed, Cancelled }
+		State state
+		int value
+
+		constructor() {
+			State local
+			if (local == State.Open && state == State.Open) {
+				value += 1
+			}
+			state = State.Cancelled
+			if (state != State.Closed) {
+				value += 10
+			}
+		}
+	`)
+
+	// Enum variables are initialized with the first member
+	tester.assertVariableInt(0, big.NewInt(2))
+	tester.assertVariableInt(1, big.NewInt(11))
+}
+
+func TestEnumMetadata(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		enum State { Open, Closed }
+		enum Kind { Basic }
+		State state
+
+		function State test(State s) {
+			return s
+		}
+	`)
+
+	contract := tester.metadata.Contract
+	assert.Equal(t, contract.TotalFields, uint16(1))
+	assert.Equal(t, len(contract.Enums), 2)
+	assert.Equal(t, contract.GetEnum("State"), contract.Enums[0])
+	assert.Assert(t, contract.GetEnum("Unknown") == nil)
+
+	abi := tester.metadata.CreateABI()
+	assert.Equal(t, len(abi.Enums), 2)
+	assert.Equal(t, abi.Enums[0].Name, "State")
+	assert.DeepEqual(t, abi.Enums[0].Members, []string{"Open", "Closed"})
+	assert.Equal(t, abi.Enums[1].Name, "Kind")
+	assert.DeepEqual(t, abi.Enums[1].Members, []string{"Basic"})
+	assert.Equal(t, *abi.Fields[0], data.FieldABI{Index: 0, Name: "state", Type: "State"})
+	assert.Equal(t, abi.Functions[0].Signature, "(State)test(State)")
+}
+
+// Interfaces
+// ----------
+
+func TestInterfaceImplementation(t *testing.T) {
+	funcHash := util.CreateFuncHash("(int)balanceOf(int)")
+	txData := append([]byte{2, 0, 7, 4}, funcHash[:]...)
+
+	tester := newGeneratorTestUtilWithRawInput(t, `
+		interface IToken {
+			function int balanceOf(int owner)
+		}
+
+		contract Token implements IToken {
+			function int balanceOf(int owner) {
+				return owner * 2
+			}
+		}
+	`, txData)
+
+	tester.assertInt(big.NewInt(14))
+	assert.Equal(t, tester.metadata.CreateABI().Functions[0].Signature, "(int)balanceOf(int)")
+}
+
+func TestByteCodeListing(t *testing.T) {
+	tester := newGeneratorTestUtil(t, `
+		function void test() {
+		}
+	`)
+
+	var listing bytes.Buffer
+	tester.metadata.WriteListing(&listing)
+	assert.Assert(t, strings.HasPrefix(listing.String(), "0: calldata"), listing.String())
+	assert.Assert(t, strings.Contains(listing.String(), "test: \n"), listing.String())
+}
@@ -43,19 +43,32 @@ func newGeneratorTestUtil(t *testing.T, contractCode string, funcData ...byte) *
 }
 
 func newGeneratorTestUtilWithFunc(t *testing.T, contractCode string,
+	funcSignature string, funcData ...byte) *generatorTestUtil {
+	return newGeneratorTestUtilWithContext(t, contractCode, nil, funcSignature, funcData...)
+}
+
+// newGeneratorTestUtilWithContext calls the function like newGeneratorTestUtilWithFunc,
+// but lets the test prepare the transaction context (e.g. the signature) before the execution.
+func newGeneratorTestUtilWithContext(t *testing.T, contractCode string, setup func(context *vm.MockContext),
 	funcSignature string, funcData ...byte) *generatorTestUtil {
 	funcHash := util.CreateFuncHash(funcSignature)
 	txData := append(funcData, 4)
 	txData = append(txData, funcHash[:]...)
 
-	return newGeneratorTestUtilWithRawInput(
+	return newGeneratorTestUtilWithSetup(
 		t,
 		fmt.Sprintf("contract Test {\n %s \n }", contractCode),
 		txData,
+		setup,
 	)
 }
 
 func newGeneratorTestUtilWithRawInput(t *testing.T, code string, txData []byte) *generatorTestUtil {
+	return newGeneratorTestUtilWithSetup(t, code, txData, nil)
+}
+
+func newGeneratorTestUtilWithSetup(t *testing.T, code string, txData []byte,
+	setup func(context *vm.MockContext)) *generatorTestUtil {
 	p := parser.New(lexer.New(bufio.NewReader(strings.NewReader(code))))
 	program, err := p.ParseProgram()
 	assert.Equal(t, len(err), 0, "Program has syntax errors", err)
@@ -78,6 +91,9 @@ func newGeneratorTestUtilWithRawInput(t *testing.T, code string, txData []byte)
 	context.Data = txData
 	context.Fee += (uint64(len(variables))) * 2000
 	context.Fee += 10000 // To be able to calculate 2^16
+	if setup != nil {
+		setup(context)
+	}
 	tester.context = context
 
 	bazoVM := vm.NewVM(context)
@@ -1,5 +1,11 @@
 package il
 
+import (
+	"fmt"
+	"github.com/bazo-blockchain/bazo-vm/vm"
+	"strings"
+)
+
 // OpCode is the type of byte code supported on Bazo VM
 type OpCode byte
 
@@ -72,3 +78,74 @@ const (
 	ErrHalt
 	Halt
 )
+
+// OperandKind describes how the operand of an OpCode is encoded in the byte code
+type OperandKind int
+
+// Operand kinds of the OpCodes
+const (
+	NoOperand      OperandKind = iota
+	IntOperand                 // [0] for zero, otherwise [length, sign, bytes...]
+	BoolOperand                // 1 byte (0 or 1)
+	CharOperand                // 1 byte
+	StringOperand              // [length, bytes...]
+	BytesOperand               // [length, bytes...]
+	ByteOperand                // 1 byte, e.g. variable index
+	UInt16Operand              // 2 bytes big endian, e.g. struct field index
+	LabelOperand               // 2 bytes big endian byte position
+	CallOperand                // 2 bytes big endian address, number of parameters, number of return values
+	CallExtOperand             // 32 bytes account address, 4 bytes function hash, number of arguments
+)
+
+// operandKinds contains the operand kinds of all OpCodes with an operand.
+// The sizes are the ones actually read by the Bazo VM.
+var operandKinds = map[OpCode]OperandKind{
+	PushInt:  IntOperand,
+	PushBool: BoolOperand,
+	PushChar: CharOperand,
+	PushStr:  StringOperand,
+	Push:     BytesOperand,
+	Roll:     ByteOperand,
+	Jmp:      LabelOperand,
+	JmpTrue:  LabelOperand,
+	JmpFalse: LabelOperand,
+	Call:     CallOperand,
+	CallTrue: CallOperand,
+	CallExt:  CallExtOperand,
+	StoreLoc: ByteOperand,
+	StoreSt:  ByteOperand,
+	LoadLoc:  ByteOperand,
+	LoadSt:   ByteOperand,
+	NewStr:   UInt16Operand,
+	StoreFld: UInt16Operand,
+	LoadFld:  UInt16Operand,
+}
+
+// IsValid returns true if the OpCode is supported by the Bazo VM
+func (o OpCode) IsValid() bool {
+	return int(o) < len(vm.OpCodes)
+}
+
+// String returns the mnemonic of the OpCode as named by the Bazo VM, e.g. pushint
+func (o OpCode) String() string {
+	if !o.IsValid() {
+		return fmt.Sprintf("OpCode(%d)", byte(o))
+	}
+	return vm.OpCodes[o].Name
+}
+
+// OperandKind returns the kind of the operand that follows the OpCode in the byte code
+func (o OpCode) OperandKind() OperandKind {
+	return operandKinds[o]
+}
+
+// ParseOpCode returns the OpCode for the given mnemonic of the Bazo VM.
+// The mnemonic is case-insensitive, e.g. pushint and PushInt are the same OpCode.
+func ParseOpCode(mnemonic string) (OpCode, bool) {
+	for i, opCode := range vm.OpCodes {
+		if strings.EqualFold(opCode.Name, mnemonic) {
+			return OpCode(i), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,40 @@
+package il
+
+import (
+	"github.com/bazo-blockchain/bazo-vm/vm"
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestOpCodeNames(t *testing.T) {
+	assert.Equal(t, int(Halt)+1, len(vm.OpCodes))
+	assert.Equal(t, PushInt.String(), "pushint")
+	assert.Equal(t, Mul.String(), "mult")
+	assert.Equal(t, Halt.String(), "halt")
+	assert.Equal(t, OpCode(Halt+1).String(), "OpCode(65)")
+}
+
+func TestOpCodeValidity(t *testing.T) {
+	assert.Assert(t, Halt.IsValid())
+	assert.Assert(t, !OpCode(Halt+1).IsValid())
+}
+
+func TestOperandKind(t *testing.T) {
+	assert.Equal(t, PushInt.OperandKind(), IntOperand)
+	assert.Equal(t, Call.OperandKind(), CallOperand)
+	assert.Equal(t, LoadFld.OperandKind(), UInt16Operand)
+	assert.Equal(t, Add.OperandKind(), NoOperand)
+}
+
+func TestParseOpCode(t *testing.T) {
+	opCode, ok := ParseOpCode("JmpTrue")
+	assert.Assert(t, ok)
+	assert.Equal(t, opCode, JmpTrue)
+
+	opCode, ok = ParseOpCode("neq")
+	assert.Assert(t, ok)
+	assert.Equal(t, opCode, NotEq)
+
+	_, ok = ParseOpCode("NotEq")
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,359 @@
+package lasm
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"github.com/bazo-blockchain/lazo/generator/il"
+	"github.com/bazo-blockchain/lazo/generator/util"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// labelReference is a label operand, which is resolved after all labels are defined
+type labelReference struct {
+	line   int
+	offset int
+	label  string
+}
+
+// assembler translates the IL assembly format into Bazo byte code
+type assembler struct {
+	reader     *bufio.Reader
+	line       int
+	byteCode   []byte
+	labels     map[string]uint16
+	references []*labelReference
+	Errors     []error
+}
+
+// Assemble returns the byte code of the IL assembly source and the errors of all invalid lines
+func Assemble(source io.Reader) ([]byte, []error) {
+	a := &assembler{
+		reader: bufio.NewReader(source),
+		labels: map[string]uint16{},
+	}
+
+	for {
+		line, err := a.reader.ReadString('\n')
+		if line != "" {
+			a.line++
+			a.assembleLine(line)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	a.resolveLabels()
+	return a.byteCode, a.Errors
+}
+
+func (a *assembler) assembleLine(line string) {
+	fields, err := splitFields(line)
+	if err != nil {
+		a.reportError(err.Error())
+		return
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	first := fields[0]
+	switch {
+	case first == ".function":
+		if len(fields) != 2 {
+			a.reportError(".function requires a name")
+			return
+		}
+		a.defineLabel(fields[1])
+	case strings.HasPrefix(first, "."):
+		a.reportError(fmt.Sprintf("Unknown directive %s", first))
+	case strings.HasSuffix(first, ":") && len(fields) == 1:
+		a.defineLabel(strings.TrimSuffix(first, ":"))
+	default:
+		a.assembleInstruction(first, fields[1:])
+	}
+}
+
+func (a *assembler) defineLabel(name string) {
+	if !isIdentifier(name) {
+		a.reportError(fmt.Sprintf("Invalid label name %s", name))
+		return
+	}
+	if _, ok := a.labels[name]; ok {
+		a.reportError(fmt.Sprintf("Label %s is already defined", name))
+		return
+	}
+	a.labels[name] = uint16(len(a.byteCode))
+}
+
+func (a *assembler) assembleInstruction(mnemonic string, operands []string) {
+	opCode, ok := il.ParseOpCode(mnemonic)
+	if !ok {
+		a.reportError(fmt.Sprintf("Unknown mnemonic %s", mnemonic))
+		return
+	}
+
+	expected := operandCount(opCode.OperandKind())
+	if len(operands) != expected {
+		a.reportError(fmt.Sprintf("%s requires %d operand(s), given %d", mnemonic, expected, len(operands)))
+		return
+	}
+
+	pos := len(a.byteCode)
+	operand, err := a.encodeOperand(opCode.OperandKind(), operands, pos+1)
+	if err != nil {
+		a.reportError(fmt.Sprintf("%s: %s", mnemonic, err))
+		return
+	}
+	a.byteCode = append(a.byteCode, byte(opCode))
+	a.byteCode = append(a.byteCode, operand...)
+}
+
+func operandCount(kind il.OperandKind) int {
+	switch kind {
+	case il.NoOperand:
+		return 0
+	case il.CallOperand, il.CallExtOperand:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// encodeOperand returns the operand bytes. Label operands are registered to be resolved at the given offset.
+func (a *assembler) encodeOperand(kind il.OperandKind, operands []string, offset int) ([]byte, error) {
+	switch kind {
+	case il.NoOperand:
+		return nil, nil
+	case il.IntOperand:
+		return encodeInt(operands[0])
+	case il.BoolOperand:
+		return encodeBool(operands[0])
+	case il.CharOperand:
+		return encodeChar(operands[0])
+	case il.StringOperand:
+		return encodeString(operands[0])
+	case il.BytesOperand:
+		bytes, err := decodeHex(operands[0], -1)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{byte(len(bytes))}, bytes...), nil
+	case il.ByteOperand:
+		return encodeUInt(operands[0], 8)
+	case il.UInt16Operand:
+		return encodeUInt(operands[0], 16)
+	case il.LabelOperand:
+		return a.encodeLabel(operands[0], offset)
+	case il.CallOperand:
+		return a.encodeCall(operands, offset)
+	case il.CallExtOperand:
+		return encodeCallExt(operands)
+	default:
+		return nil, fmt.Errorf("unsupported operand")
+	}
+}
+
+func (a *assembler) encodeLabel(label string, offset int) ([]byte, error) {
+	if address, err := strconv.ParseUint(label, 10, 16); err == nil {
+		return util.GetBytesFromUInt16(uint16(address)), nil
+	}
+	if !isIdentifier(label) {
+		return nil, fmt.Errorf("invalid label %s", label)
+	}
+
+	a.references = append(a.references, &labelReference{
+		line:   a.line,
+		offset: offset,
+		label:  label,
+	})
+	return []byte{0, 0}, nil
+}
+
+func (a *assembler) encodeCall(operands []string, offset int) ([]byte, error) {
+	address, err := a.encodeLabel(operands[0], offset)
+	if err != nil {
+		return nil, err
+	}
+	for _, operand := range operands[1:] {
+		count, err := encodeUInt(operand, 8)
+		if err != nil {
+			return nil, err
+		}
+		address = append(address, count...)
+	}
+	return address, nil
+}
+
+func (a *assembler) resolveLabels() {
+	for _, reference := range a.references {
+		address, ok := a.labels[reference.label]
+		if !ok {
+			a.Errors = append(a.Errors, fmt.Errorf("[%d] Label %s is not defined", reference.line, reference.label))
+			continue
+		}
+		copy(a.byteCode[reference.offset:], util.GetBytesFromUInt16(address))
+	}
+}
+
+func (a *assembler) reportError(msg string) {
+	a.Errors = append(a.Errors, fmt.Errorf("[%d] %s", a.line, msg))
+}
+
+// Operand encoding
+// ----------------
+
+func encodeInt(operand string) ([]byte, error) {
+	value, ok := new(big.Int).SetString(operand, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", operand)
+	}
+
+	bytes := value.Bytes()
+	if len(bytes) == 0 {
+		return []byte{0}, nil
+	}
+	if len(bytes) > 255 {
+		return nil, fmt.Errorf("integer %s is too large", operand)
+	}
+	return append([]byte{byte(len(bytes)), util.GetSignByte(value)}, bytes...), nil
+}
+
+func encodeBool(operand string) ([]byte, error) {
+	switch operand {
+	case "true":
+		return []byte{1}, nil
+	case "false":
+		return []byte{0}, nil
+	default:
+		return nil, fmt.Errorf("invalid bool %s", operand)
+	}
+}
+
+func encodeChar(operand string) ([]byte, error) {
+	value, err := strconv.Unquote(operand)
+	runes := []rune(value)
+	if err != nil || !strings.HasPrefix(operand, "'") || len(runes) != 1 || runes[0] > 255 {
+		return nil, fmt.Errorf("invalid character %s", operand)
+	}
+	return []byte{byte(runes[0])}, nil
+}
+
+func encodeString(operand string) ([]byte, error) {
+	value, err := strconv.Unquote(operand)
+	if err != nil || !strings.HasPrefix(operand, `"`) {
+		return nil, fmt.Errorf("invalid string %s", operand)
+	}
+	if len(value) > 255 {
+		return nil, fmt.Errorf("string is longer than 255 bytes")
+	}
+	return append([]byte{byte(len(value))}, value...), nil
+}
+
+func encodeUInt(operand string, bitSize int) ([]byte, error) {
+	value, err := strconv.ParseUint(operand, 10, bitSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %d bit unsigned integer %s", bitSize, operand)
+	}
+	if bitSize == 8 {
+		return []byte{byte(value)}, nil
+	}
+	return util.GetBytesFromUInt16(uint16(value)), nil
+}
+
+func encodeCallExt(operands []string) ([]byte, error) {
+	address, err := decodeHex(operands[0], 32)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := decodeHex(operands[1], 4)
+	if err != nil {
+		return nil, err
+	}
+	args, err := encodeUInt(operands[2], 8)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(address, hash...), args...), nil
+}
+
+// decodeHex decodes the 0x prefixed hex value. A negative length allows up to 255 bytes.
+func decodeHex(operand string, length int) ([]byte, error) {
+	bytes, err := hex.DecodeString(strings.TrimPrefix(operand, "0x"))
+	if err != nil || !strings.HasPrefix(operand, "0x") {
+		return nil, fmt.Errorf("invalid hex value %s", operand)
+	}
+	if length >= 0 && len(bytes) != length {
+		return nil, fmt.Errorf("hex value %s must have %d bytes", operand, length)
+	}
+	if len(bytes) > 255 {
+		return nil, fmt.Errorf("hex value %s is longer than 255 bytes", operand)
+	}
+	return bytes, nil
+}
+
+// Line scanning
+// -------------
+
+// splitFields splits the line by white spaces, except within quoted characters and strings.
+// Comments starting with a semicolon are skipped.
+func splitFields(line string) ([]string, error) {
+	var fields []string
+	var current strings.Builder
+	var quote rune
+	escaped := false
+
+	for _, ch := range line {
+		switch {
+		case quote != 0:
+			current.WriteRune(ch)
+			if escaped {
+				escaped = false
+			} else if ch == '\\' {
+				escaped = true
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		case ch == ';':
+			return appendField(fields, &current), nil
+		case ch == '"' || ch == '\'':
+			quote = ch
+			current.WriteRune(ch)
+		case ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n':
+			fields = appendField(fields, &current)
+		default:
+			current.WriteRune(ch)
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("Quote %c is not closed", quote)
+	}
+	return appendField(fields, &current), nil
+}
+
+func appendField(fields []string, current *strings.Builder) []string {
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+		current.Reset()
+	}
+	return fields
+}
+
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, ch := range name {
+		isLetter := ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+		if !isLetter && (i == 0 || ch < '0' || ch > '9') {
+			return false
+		}
+	}
+	return true
+}
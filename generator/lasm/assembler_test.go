@@ -0,0 +1,226 @@
+package lasm
+
+import (
+	"bytes"
+	"github.com/bazo-blockchain/bazo-vm/vm"
+	"github.com/bazo-blockchain/lazo/generator/disasm"
+	"github.com/bazo-blockchain/lazo/generator/il"
+	"gotest.tools/assert"
+	"strings"
+	"testing"
+)
+
+func assemble(t *testing.T, source string) []byte {
+	code, errors := Assemble(strings.NewReader(source))
+	assert.Equal(t, len(errors), 0, errors)
+	return code
+}
+
+func assertErrors(t *testing.T, source string, expected ...string) {
+	_, errors := Assemble(strings.NewReader(source))
+	assert.Equal(t, len(errors), len(expected), errors)
+	for i, err := range errors {
+		assert.Error(t, err, expected[i])
+	}
+}
+
+// Instructions
+// ------------
+
+func TestAssembleOperands(t *testing.T) {
+	code := assemble(t, `
+		pushint -5
+		pushint 0
+		pushbool true
+		pushchar 'c'
+		pushstr "a; b"  ; the semicolon in the string is not a comment
+		push 0x01ff
+		storeloc 3
+		loadfld 258
+		callext 0x0000000000000000000000000000000000000000000000000000000000000001 0x12345678 2
+	`)
+
+	expected := []byte{
+		byte(il.PushInt), 1, 1, 5,
+		byte(il.PushInt), 0,
+		byte(il.PushBool), 1,
+		byte(il.PushChar), 'c',
+		byte(il.PushStr), 4, 'a', ';', ' ', 'b',
+		byte(il.Push), 2, 1, 255,
+		byte(il.StoreLoc), 3,
+		byte(il.LoadFld), 1, 2,
+		byte(il.CallExt),
+	}
+	expected = append(expected, make([]byte, 31)...)
+	expected = append(expected, 1, 0x12, 0x34, 0x56, 0x78, 2)
+	assert.DeepEqual(t, code, expected)
+}
+
+func TestAssembleEscapedCharacters(t *testing.T) {
+	code := assemble(t, `
+		pushchar '\''
+		pushstr "\"\n"
+	`)
+	assert.DeepEqual(t, code, []byte{byte(il.PushChar), '\'', byte(il.PushStr), 2, '"', '\n'})
+}
+
+func TestAssembleLabels(t *testing.T) {
+	code := assemble(t, `
+	.function main
+		jmp end
+		call main 1 2
+	end:
+		jmptrue 3
+	`)
+
+	assert.DeepEqual(t, code, []byte{
+		byte(il.Jmp), 0, 8,
+		byte(il.Call), 0, 0, 1, 2,
+		byte(il.JmpTrue), 0, 3,
+	})
+}
+
+func TestAssembleExecution(t *testing.T) {
+	code := assemble(t, `
+		pushint 3
+	loop:
+		dup
+		pushint 5
+		lt
+		jmpfalse end
+		pushint 1
+		add
+		jmp loop
+	end:
+		halt
+	`)
+
+	context := vm.NewMockContext(code)
+	context.Fee = 1000
+	bazoVM := vm.NewVM(context)
+	assert.Assert(t, bazoVM.Exec(false))
+
+	result, err := bazoVM.PeekResult()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, []byte{0, 5})
+}
+
+// Round trip
+// ----------
+
+func TestRoundTrip(t *testing.T) {
+	source := `.function dispatcher
+    calldata
+    dup
+    push       0x12345678
+    neq
+    jmptrue    L0
+    pop
+    call       func_12345678 1 1
+    halt
+L0:
+    pushint    0
+    eq
+    jmptrue    constructor
+    halt
+
+.function constructor
+    pushstr    "text"
+    storest    0
+    halt
+
+.function func_12345678
+    loadloc    0
+    jmpfalse   L1
+    pushchar   'x'
+    ret
+L1:
+`
+	code := assemble(t, source)
+	program, err := disasm.Disassemble(code, nil)
+	assert.NilError(t, err)
+
+	var buffer bytes.Buffer
+	Write(&buffer, program)
+	assert.Equal(t, buffer.String(), source)
+}
+
+// Errors
+// ------
+
+func TestRoundTripWithCollidingFunctionNames(t *testing.T) {
+	metadata := compile(t, strings.NewReader(`contract Test {
+		function int dispatcher(int a) {
+			if (a > 0) {
+				return L0()
+			}
+			return 0
+		}
+
+		function int L0() {
+			return 1
+		}
+	}`))
+	code, _ := metadata.CreateContract()
+
+	var buffer bytes.Buffer
+	assert.NilError(t, WriteContract(&buffer, metadata))
+	assert.Assert(t, strings.Contains(buffer.String(), ".function dispatcher_\n"), buffer.String())
+	assert.DeepEqual(t, assemble(t, buffer.String()), code)
+}
+
+func TestUnknownMnemonic(t *testing.T) {
+	assertErrors(t, "pushnum 1", "[1] Unknown mnemonic pushnum")
+}
+
+func TestCaseInsensitiveMnemonic(t *testing.T) {
+	assert.DeepEqual(t, assemble(t, "PushInt 0\nHALT"), assemble(t, "pushint 0\nhalt"))
+}
+
+func TestUnknownDirective(t *testing.T) {
+	assertErrors(t, ".contract Test", "[1] Unknown directive .contract")
+}
+
+func TestMissingFunctionName(t *testing.T) {
+	assertErrors(t, ".function", "[1] .function requires a name")
+}
+
+func TestWrongOperandCount(t *testing.T) {
+	assertErrors(t, "add 1\ncall test",
+		"[1] add requires 0 operand(s), given 1",
+		"[2] call requires 3 operand(s), given 1")
+}
+
+func TestInvalidOperands(t *testing.T) {
+	assertErrors(t, `
+		pushint x
+		pushbool 1
+		pushchar "c"
+		pushstr 'c'
+		push 1234
+		storeloc 256
+		loadfld -1
+		callext 0x01 0x12345678 0
+		jmp 1abc
+	`,
+		"[2] pushint: invalid integer x",
+		"[3] pushbool: invalid bool 1",
+		`[4] pushchar: invalid character "c"`,
+		"[5] pushstr: invalid string 'c'",
+		"[6] push: invalid hex value 1234",
+		"[7] storeloc: invalid 8 bit unsigned integer 256",
+		"[8] loadfld: invalid 16 bit unsigned integer -1",
+		"[9] callext: hex value 0x01 must have 32 bytes",
+		"[10] jmp: invalid label 1abc")
+}
+
+func TestQuoteNotClosed(t *testing.T) {
+	assertErrors(t, `pushstr "text`, "[1] Quote \" is not closed")
+}
+
+func TestLabelErrors(t *testing.T) {
+	assertErrors(t, "a:\na:\n1a:\nJmp b",
+		"[2] Label a is already defined",
+		"[3] Invalid label name 1a",
+		"[4] Label b is not defined")
+}
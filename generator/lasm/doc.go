@@ -0,0 +1,13 @@
+// Package lasm reads and writes the textual IL assembly format (.lasm).
+//
+// Every line contains either a directive, a label or an instruction. Comments start with a semicolon:
+//
+//	.function add          ; function entry point, which can be used as label
+//	L0:                    ; label definition
+//	    pushint -5         ; mnemonic as named by the Bazo VM (case-insensitive), followed by the operands
+//	    jmptrue L0
+//	    call add 2 1       ; function, number of parameters and number of return values
+//
+// Operands are written as decimal numbers, true/false, quoted characters ('c') and strings ("text"),
+// hex encoded bytes (0x01ff) or label names.
+package lasm
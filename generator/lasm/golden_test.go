@@ -0,0 +1,71 @@
+package lasm
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"github.com/bazo-blockchain/lazo/checker"
+	"github.com/bazo-blockchain/lazo/generator"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"github.com/bazo-blockchain/lazo/lexer"
+	"github.com/bazo-blockchain/lazo/parser"
+	"gotest.tools/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Run "go test ./generator/lasm -update" to rewrite the golden files after changing the code generation
+var update = flag.Bool("update", false, "update the golden .lasm files")
+
+func compileFile(t *testing.T, sourceFile string) *data.Metadata {
+	file, err := os.Open(sourceFile)
+	assert.NilError(t, err)
+	defer file.Close()
+	return compile(t, file)
+}
+
+func compile(t *testing.T, source io.Reader) *data.Metadata {
+	program, errors := parser.New(lexer.New(bufio.NewReader(source))).ParseProgram()
+	assert.Equal(t, len(errors), 0, errors)
+
+	symbolTable, errors := checker.New(program).Run()
+	assert.Equal(t, len(errors), 0, errors)
+
+	metadata, errors := generator.New(symbolTable).Run()
+	assert.Equal(t, len(errors), 0, errors)
+	return metadata
+}
+
+func TestGoldenFiles(t *testing.T) {
+	sourceFiles, err := filepath.Glob(filepath.Join("..", "..", "examples", "*.lazo"))
+	assert.NilError(t, err)
+	assert.Assert(t, len(sourceFiles) > 0)
+
+	for _, sourceFile := range sourceFiles {
+		name := strings.TrimSuffix(filepath.Base(sourceFile), ".lazo")
+		t.Run(name, func(t *testing.T) {
+			metadata := compileFile(t, sourceFile)
+			code, _ := metadata.CreateContract()
+
+			var buffer bytes.Buffer
+			assert.NilError(t, WriteContract(&buffer, metadata))
+
+			goldenFile := filepath.Join("testdata", name+".lasm")
+			if *update {
+				assert.NilError(t, ioutil.WriteFile(goldenFile, buffer.Bytes(), 0644))
+			}
+
+			golden, err := ioutil.ReadFile(goldenFile)
+			assert.NilError(t, err)
+			assert.Equal(t, buffer.String(), string(golden))
+
+			assembled, errors := Assemble(bytes.NewReader(golden))
+			assert.Equal(t, len(errors), 0, errors)
+			assert.DeepEqual(t, assembled, code)
+		})
+	}
+}
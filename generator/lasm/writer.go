@@ -0,0 +1,46 @@
+package lasm
+
+import (
+	"fmt"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"github.com/bazo-blockchain/lazo/generator/disasm"
+	"io"
+)
+
+// WriteContract writes the byte code of the compiled contract in the IL assembly format.
+// The functions are named by their identifiers in the contract.
+func WriteContract(w io.Writer, metadata *data.Metadata) error {
+	code, _ := metadata.CreateContract()
+	program, err := disasm.Disassemble(code, metadata.CreateABI())
+	if err != nil {
+		return err
+	}
+	Write(w, program)
+	return nil
+}
+
+// Write writes the disassembled program in the IL assembly format.
+// Function entry points are written as .function directives and jump targets as labels.
+func Write(w io.Writer, program *disasm.Program) {
+	for i, instruction := range program.Instructions {
+		if name, ok := program.Functions[instruction.Pos]; ok {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, ".function %s\n", name)
+		} else if name, ok := program.Labels[instruction.Pos]; ok {
+			fmt.Fprintf(w, "%s:\n", name)
+		}
+
+		operand := program.FormatOperand(instruction)
+		if operand == "" {
+			fmt.Fprintf(w, "    %s\n", instruction.OpCode)
+		} else {
+			fmt.Fprintf(w, "    %-10s %s\n", instruction.OpCode, operand)
+		}
+	}
+
+	if name, ok := program.EndLabel(); ok {
+		fmt.Fprintf(w, "%s:\n", name)
+	}
+}
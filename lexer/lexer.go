@@ -5,12 +5,14 @@ package lexer
 
 import (
 	"bufio"
+	"encoding/hex"
 	"fmt"
 	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/pkg/errors"
 	"io"
 	"log"
 	"math/big"
+	"strings"
 )
 
 // Lexer holds the current character and position from the given reader.
@@ -20,7 +22,9 @@ type Lexer struct {
 	current    rune
 	currentPos token.Position
 	tokenPos   token.Position
-	isEnd      bool
+	// tokenLength counts the characters read since the start of the current token
+	tokenLength int
+	isEnd       bool
 }
 
 // New creates a new Lexer struct with the given reader and initializes the current position.
@@ -36,13 +40,33 @@ func New(reader *bufio.Reader) *Lexer {
 }
 
 // NextToken reads character by character from reader and creates a token when possible.
-// White space is skipped and, therefore, no token is created for that.
+// White space and comments are skipped and, therefore, no token is created for that.
 // However, tokens are created for new lines, since they are part of the syntax.
+// Doc comments (///) are returned as DocCommentToken, so that they can be attached to the following declaration.
 // It returns the created token containing the token position (line and column), the literal itself and the token type.
 func (lex *Lexer) NextToken() token.Token {
+	tok := lex.readToken()
+	if t, ok := tok.(interface{ SetEnd(token.Position) }); ok {
+		t.SetEnd(token.Position{
+			Line:   lex.tokenPos.Line,
+			Column: lex.tokenPos.Column + lex.tokenLength,
+		})
+	}
+	return tok
+}
+
+func (lex *Lexer) readToken() token.Token {
 	lex.skipWhiteSpace()
 
 	lex.tokenPos = lex.currentPos
+	lex.tokenLength = 0
+
+	if lex.isCommentStart() {
+		if tok := lex.readComment(); tok != nil {
+			return tok
+		}
+		return lex.readToken()
+	}
 
 	if lex.isEnd {
 		return &token.FixToken{
@@ -60,6 +84,8 @@ func (lex *Lexer) NextToken() token.Token {
 	}
 
 	switch lex.current {
+	case '@':
+		return lex.readAddress()
 	case '"':
 		return lex.readString()
 	case '\'':
@@ -75,6 +101,67 @@ func (lex *Lexer) skipWhiteSpace() {
 	}
 }
 
+func (lex *Lexer) isCommentStart() bool {
+	if !lex.isChar('/') {
+		return false
+	}
+	peekChar, peekError := lex.peekChar()
+	return peekError == nil && (peekChar == '/' || peekChar == '*')
+}
+
+// readComment skips a line or block comment. It returns a token only for doc comments and unclosed block comments.
+func (lex *Lexer) readComment() token.Token {
+	lex.nextChar() // skip '/'
+
+	if lex.isChar('*') {
+		lex.nextChar()
+		return lex.skipBlockComment()
+	}
+
+	lex.nextChar() // skip second '/'
+	isDocComment := false
+	if lex.isChar('/') {
+		peekChar, _ := lex.peekChar()
+		isDocComment = peekChar != '/'
+		lex.nextChar()
+	}
+
+	lexeme := lex.readLexeme(func() bool {
+		return !lex.isChar('\n')
+	})
+
+	if isDocComment {
+		return &token.DocCommentToken{
+			AbstractToken: lex.newAbstractToken(strings.TrimSpace(lexeme)),
+		}
+	}
+	return nil
+}
+
+// skipBlockComment skips the block comment including all the nested block comments
+func (lex *Lexer) skipBlockComment() token.Token {
+	depth := 1
+
+	for !lex.isEnd {
+		peekChar, _ := lex.peekChar()
+
+		if lex.isChar('/') && peekChar == '*' {
+			depth++
+			lex.nextChar()
+		} else if lex.isChar('*') && peekChar == '/' {
+			depth--
+			lex.nextChar()
+		}
+		lex.nextChar()
+
+		if depth == 0 {
+			return nil
+		}
+	}
+
+	return lex.newErrorToken(lex.newAbstractToken("/*"), "Block comment not closed")
+}
+
 func (lex *Lexer) readInteger() token.Token {
 	var lexeme string
 	value := new(big.Int)
@@ -110,6 +197,28 @@ func (lex *Lexer) readInteger() token.Token {
 	}
 }
 
+// readAddress reads an address literal, which consists of '@' followed by the address as hex digits.
+func (lex *Lexer) readAddress() token.Token {
+	lex.nextChar() // skip @
+	digits := lex.readLexeme(lex.isHexDigit)
+
+	abstractToken := lex.newAbstractToken("@" + digits)
+	if len(digits) != 2*token.AddressLength {
+		return lex.newErrorToken(abstractToken,
+			fmt.Sprintf("Address literal must have %d hex digits", 2*token.AddressLength))
+	}
+
+	value, err := hex.DecodeString(digits)
+	if err != nil {
+		return lex.newErrorToken(abstractToken, "Error while parsing address literal")
+	}
+
+	return &token.AddressToken{
+		AbstractToken: abstractToken,
+		Value:         value,
+	}
+}
+
 func (lex *Lexer) readName() token.Token {
 	lexeme := lex.readLexeme(func() bool {
 		return lex.isLetter() || lex.isChar('_') || lex.isDigit()
@@ -233,6 +342,9 @@ func (lex *Lexer) readFixToken() token.Token {
 }
 
 func (lex *Lexer) nextChar() {
+	if !lex.isEnd {
+		lex.tokenLength++
+	}
 	if char, _, err := lex.reader.ReadRune(); err != nil {
 		lex.current = 0
 		if err == io.EOF {
@@ -32,6 +32,7 @@ func TestStateWithCode(t *testing.T) {
 	tok := lex.NextToken()
 	assertIdentifier(t, tok, "test")
 	assert.Equal(t, tok.Pos().String(), "1:1")
+	assert.Equal(t, tok.End().String(), "1:5")
 	assertLexerState(t, lex, true, 0, "1:4")
 
 	tok = lex.NextToken()
@@ -118,6 +119,33 @@ func TestMixedIntegers(t *testing.T) {
 	tester.assertIdentifer(3, "G")
 }
 
+// Address Tokens
+// --------------
+
+func TestAddressLiteral(t *testing.T) {
+	address := "@" + strings.Repeat("0a", 31) + "ff"
+	tester := newLexerTestUtil(t, address+" x")
+
+	tester.assertTotal(2)
+	tok, ok := tester.tokens[0].(*token.AddressToken)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, tok.Literal(), address)
+	assert.Equal(t, len(tok.Value), token.AddressLength)
+	assert.Equal(t, tok.Value[0], byte(0x0a))
+	assert.Equal(t, tok.Value[31], byte(0xff))
+	assert.Equal(t, tok.End().String(), "1:66")
+	tester.assertIdentifer(1, "x")
+}
+
+func TestAddressLiteralWithInvalidLength(t *testing.T) {
+	tester := newLexerTestUtil(t, "@0a1f @")
+
+	tester.assertTotal(2)
+	tester.assertError(0, "@0a1f")
+	tester.assertError(1, "@")
+	assert.Equal(t, tester.tokens[0].(*token.ErrorToken).Msg, "Address literal must have 64 hex digits")
+}
+
 // ID Tokens
 // -----------------
 
@@ -167,6 +195,16 @@ func TestStringWithEscapedChars(t *testing.T) {
 	tester.assertString(2, "backslash \\")
 }
 
+func TestTokenEndWithQuotesAndEscapedChars(t *testing.T) {
+	tester := newLexerTestUtil(t, `x "a\n" '\'' >=`)
+
+	tester.assertTotal(4)
+	assert.Equal(t, tester.tokens[0].End().String(), "1:2")
+	assert.Equal(t, tester.tokens[1].End().String(), "1:8")
+	assert.Equal(t, tester.tokens[2].End().String(), "1:13")
+	assert.Equal(t, tester.tokens[3].End().String(), "1:16")
+}
+
 func TestStringWithNotAllowedEscapeChars(t *testing.T) {
 	tester := newLexerTestUtil(t, `"single quote \' "`)
 
@@ -305,6 +343,69 @@ func TestPeriod(t *testing.T) {
 	tester.assertFixToken(0, token.Period)
 }
 
+func TestRange(t *testing.T) {
+	tester := newLexerTestUtil(t, "0..n")
+	tester.assertTotal(3)
+	tester.assertInteger(0, big.NewInt(0))
+	tester.assertFixToken(1, token.Range)
+	tester.assertIdentifer(2, "n")
+}
+
+func TestLoopKeywords(t *testing.T) {
+	tester := newLexerTestUtil(t, "for foreach in")
+	tester.assertFixToken(0, token.For)
+	tester.assertFixToken(1, token.ForEach)
+	tester.assertFixToken(2, token.In)
+}
+
+func TestAbortKeywords(t *testing.T) {
+	tester := newLexerTestUtil(t, "require assert revert")
+	tester.assertFixToken(0, token.Require)
+	tester.assertFixToken(1, token.Assert)
+	tester.assertFixToken(2, token.Revert)
+}
+
+func TestEventKeywords(t *testing.T) {
+	tester := newLexerTestUtil(t, "event emit")
+	tester.assertFixToken(0, token.Event)
+	tester.assertFixToken(1, token.Emit)
+}
+
+func TestVisibilityKeywords(t *testing.T) {
+	tester := newLexerTestUtil(t, "public private internal")
+	tester.assertFixToken(0, token.Public)
+	tester.assertFixToken(1, token.Private)
+	tester.assertFixToken(2, token.Internal)
+}
+
+func TestPayableKeyword(t *testing.T) {
+	tester := newLexerTestUtil(t, "payable")
+	tester.assertFixToken(0, token.Payable)
+}
+
+func TestModifierKeywords(t *testing.T) {
+	tester := newLexerTestUtil(t, "modifier _ _x")
+	tester.assertFixToken(0, token.Modifier)
+	tester.assertFixToken(1, token.Placeholder)
+	tester.assertIdentifer(2, "_x")
+}
+
+func TestConstKeyword(t *testing.T) {
+	tester := newLexerTestUtil(t, "const")
+	tester.assertFixToken(0, token.Const)
+}
+
+func TestEnumKeyword(t *testing.T) {
+	tester := newLexerTestUtil(t, "enum")
+	tester.assertFixToken(0, token.Enum)
+}
+
+func TestInterfaceKeywords(t *testing.T) {
+	tester := newLexerTestUtil(t, "interface implements")
+	tester.assertFixToken(0, token.Interface)
+	tester.assertFixToken(1, token.Implements)
+}
+
 func TestOpenBrace(t *testing.T) {
 	tester := newLexerTestUtil(t, "{")
 	tester.assertFixToken(0, token.OpenBrace)
@@ -491,3 +592,89 @@ func TestSystemNewLine(t *testing.T) {
 	tok = lex.NextToken()
 	assertFixToken(t, tok, token.NewLine)
 }
+
+// Comments
+// --------
+
+func TestLineComment(t *testing.T) {
+	tester := newLexerTestUtil(t, "x // comment \n y")
+
+	tester.assertTotal(2)
+	tester.assertIdentifer(0, "x")
+	tester.assertIdentifer(1, "y")
+}
+
+func TestLineCommentKeepsNewLine(t *testing.T) {
+	lex := New(bufio.NewReader(strings.NewReader("// comment\nx")))
+
+	tok := lex.NextToken()
+	assertFixToken(t, tok, token.NewLine)
+	tok = lex.NextToken()
+	assertIdentifier(t, tok, "x")
+	assert.Equal(t, tok.Pos().String(), "2:1")
+}
+
+func TestLineCommentAtEnd(t *testing.T) {
+	tester := newLexerTestUtil(t, "x // comment")
+
+	tester.assertTotal(2)
+	tester.assertIdentifer(0, "x")
+	tester.assertFixToken(1, token.EOF)
+}
+
+func TestBlockComment(t *testing.T) {
+	tester := newLexerTestUtil(t, "x /* comment */ y")
+
+	tester.assertTotal(2)
+	tester.assertIdentifer(0, "x")
+	tester.assertIdentifer(1, "y")
+	assert.Equal(t, tester.tokens[1].Pos().String(), "1:17")
+}
+
+func TestMultiLineBlockComment(t *testing.T) {
+	lex := New(bufio.NewReader(strings.NewReader("/* line 1\n line 2\n */ x")))
+
+	tok := lex.NextToken()
+	assertIdentifier(t, tok, "x")
+	assert.Equal(t, tok.Pos().String(), "3:5")
+}
+
+func TestNestedBlockComment(t *testing.T) {
+	tester := newLexerTestUtil(t, "/* outer /* inner */ still comment */ x")
+
+	tester.assertTotal(1)
+	tester.assertIdentifer(0, "x")
+}
+
+func TestBlockCommentNotClosed(t *testing.T) {
+	tester := newLexerTestUtil(t, "x /* /* */")
+
+	tester.assertTotal(2)
+	tester.assertIdentifer(0, "x")
+	tester.assertError(1, "/*")
+	assert.Equal(t, tester.tokens[1].(*token.ErrorToken).Msg, "Block comment not closed")
+}
+
+func TestDivisionIsNoComment(t *testing.T) {
+	tester := newLexerTestUtil(t, "x / y")
+
+	tester.assertTotal(3)
+	tester.assertFixToken(1, token.Division)
+}
+
+func TestDocComment(t *testing.T) {
+	tester := newLexerTestUtil(t, "/// Returns x \n x")
+
+	tester.assertTotal(2)
+	tok, ok := tester.tokens[0].(*token.DocCommentToken)
+	assert.Assert(t, ok)
+	assert.Equal(t, tok.Literal(), "Returns x")
+	tester.assertIdentifer(1, "x")
+}
+
+func TestFourSlashesIsNoDocComment(t *testing.T) {
+	tester := newLexerTestUtil(t, "//// comment \n x")
+
+	tester.assertTotal(1)
+	tester.assertIdentifer(0, "x")
+}
@@ -4,8 +4,8 @@ import "fmt"
 
 // Position holds the line and column number
 type Position struct {
-	Line   int
-	Column int
+	Line   int `json:"line"`
+	Column int `json:"column"`
 }
 
 // NewPosition creates a new position with line 1 and column 0
@@ -36,6 +36,7 @@ const (
 	Colon
 	Comma
 	Period
+	Range
 	QuestionMark
 
 	Not
@@ -59,8 +60,26 @@ const (
 	Constructor
 	If
 	Else
+	For
+	ForEach
+	In
 	Function
 	Return
+	Require
+	Assert
+	Revert
+	Event
+	Emit
+	Public
+	Private
+	Internal
+	Payable
+	Modifier
+	Placeholder
+	Const
+	Enum
+	Interface
+	Implements
 	True
 	False
 )
@@ -98,6 +117,7 @@ var SymbolLexeme = map[Symbol]string{
 	Colon:        ":",
 	Comma:        ",",
 	Period:       ".",
+	Range:        "..",
 	QuestionMark: "?",
 
 	Not: "!",
@@ -121,8 +141,26 @@ var SymbolLexeme = map[Symbol]string{
 	Constructor: "constructor",
 	If:          "if",
 	Else:        "else",
+	For:         "for",
+	ForEach:     "foreach",
+	In:          "in",
 	Function:    "function",
 	Return:      "return",
+	Require:     "require",
+	Assert:      "assert",
+	Revert:      "revert",
+	Event:       "event",
+	Emit:        "emit",
+	Public:      "public",
+	Private:     "private",
+	Internal:    "internal",
+	Payable:     "payable",
+	Modifier:    "modifier",
+	Placeholder: "_",
+	Const:       "const",
+	Enum:        "enum",
+	Interface:   "interface",
+	Implements:  "implements",
 	True:        "true",
 	False:       "false",
 }
@@ -137,8 +175,26 @@ var Keywords = map[string]Symbol{
 	"constructor": Constructor,
 	"if":          If,
 	"else":        Else,
+	"for":         For,
+	"foreach":     ForEach,
+	"in":          In,
 	"function":    Function,
 	"return":      Return,
+	"require":     Require,
+	"assert":      Assert,
+	"revert":      Revert,
+	"event":       Event,
+	"emit":        Emit,
+	"public":      Public,
+	"private":     Private,
+	"internal":    Internal,
+	"payable":     Payable,
+	"modifier":    Modifier,
+	"_":           Placeholder,
+	"const":       Const,
+	"enum":        Enum,
+	"interface":   Interface,
+	"implements":  Implements,
 	"true":        True,
 	"false":       False,
 }
@@ -153,7 +209,6 @@ var BooleanConstants = map[Symbol]bool{
 var SingleCharOperators = map[rune]Symbol{
 	':': Colon,
 	',': Comma,
-	'.': Period,
 	'?': QuestionMark,
 	'{': OpenBrace,
 	'}': CloseBrace,
@@ -175,6 +230,7 @@ var PossibleMultiCharOperators = map[rune]Symbol{
 	'>': Greater,
 	'<': Less,
 	'!': Not,
+	'.': Period,
 
 	'*': Multiplication,
 
@@ -191,6 +247,8 @@ var MultiCharOperators = map[string]Symbol{
 
 	"**": Exponent,
 
+	"..": Range,
+
 	"<<": ShiftLeft,
 	">>": ShiftRight,
 
@@ -17,20 +17,28 @@ const (
 	CHARACTER
 	SYMBOL
 	ERROR
+	DOC
+	ADDRESS
 )
 
+// AddressLength is the number of bytes of an account address
+const AddressLength = 32
+
 // Token is the interface that wraps the basic Token functions
 type Token interface {
 	Pos() Position
+	End() Position
 	Literal() string
 	String() string
 	Type() TokenType
 }
 
 // AbstractToken contains token position and lexeme, which all concrete tokens have.
+// The end position points to the column after the last character of the token in the source code.
 type AbstractToken struct {
 	Position
-	Lexeme string
+	EndPosition Position
+	Lexeme      string
 }
 
 // Pos returns the token position
@@ -38,6 +46,16 @@ func (t *AbstractToken) Pos() Position {
 	return t.Position
 }
 
+// End returns the position after the last character of the token
+func (t *AbstractToken) End() Position {
+	return t.EndPosition
+}
+
+// SetEnd sets the position after the last character of the token
+func (t *AbstractToken) SetEnd(pos Position) {
+	t.EndPosition = pos
+}
+
 // Literal returns the actual token lexeme
 func (t *AbstractToken) Literal() string {
 	return t.Lexeme
@@ -80,6 +98,23 @@ func (t *IntegerToken) String() string {
 
 // --------------------------
 
+// AddressToken holds an account address literal and compose abstract token
+type AddressToken struct {
+	AbstractToken
+	Value []byte
+}
+
+// Type returns the token type
+func (t *AddressToken) Type() TokenType {
+	return ADDRESS
+}
+
+func (t *AddressToken) String() string {
+	return fmt.Sprintf("[%s] ADDRESS %s", t.Pos(), t.Literal())
+}
+
+// --------------------------
+
 // StringToken holds a string literal and compose abstract token
 type StringToken struct {
 	AbstractToken
@@ -144,3 +179,19 @@ func (t *ErrorToken) Type() TokenType {
 func (t *ErrorToken) String() string {
 	return fmt.Sprintf("[%s] Error: %s - %s", t.Pos(), t.Msg, t.Literal())
 }
+
+// --------------------------
+
+// DocCommentToken holds the text of a doc comment (///) and compose abstract token
+type DocCommentToken struct {
+	AbstractToken
+}
+
+// Type returns the token type
+func (t *DocCommentToken) Type() TokenType {
+	return DOC
+}
+
+func (t *DocCommentToken) String() string {
+	return fmt.Sprintf("[%s] DOC %s", t.Pos(), t.Literal())
+}
@@ -12,6 +12,7 @@ func TestTokenTypes(t *testing.T) {
 	assert.Equal(t, (&CharacterToken{}).Type(), CHARACTER)
 	assert.Equal(t, (&FixToken{}).Type(), SYMBOL)
 	assert.Equal(t, (&ErrorToken{}).Type(), ERROR)
+	assert.Equal(t, (&AddressToken{}).Type(), ADDRESS)
 }
 
 func TestIdentifierToken(t *testing.T) {
@@ -21,11 +22,16 @@ func TestIdentifierToken(t *testing.T) {
 				Line:   1,
 				Column: 1,
 			},
+			Position{
+				Line:   1,
+				Column: 5,
+			},
 			"test",
 		},
 	}
 
 	assert.Equal(t, i.Pos().String(), "1:1")
+	assert.Equal(t, i.End().String(), "1:5")
 	assert.Equal(t, i.Literal(), "test")
 	assert.Equal(t, i.String(), "[1:1] IDENTIFER test")
 }
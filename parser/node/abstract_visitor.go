@@ -7,17 +7,38 @@ type AbstractVisitor struct {
 	ConcreteVisitor Visitor
 }
 
-// VisitProgramNode traverses the contract node.
+// VisitProgramNode traverses the interface and contract nodes.
 func (v *AbstractVisitor) VisitProgramNode(node *ProgramNode) {
-	node.Contract.Accept(v.ConcreteVisitor)
+	for _, interfaceNode := range node.Interfaces {
+		interfaceNode.Accept(v.ConcreteVisitor)
+	}
+
+	for _, contract := range node.Contracts {
+		contract.Accept(v.ConcreteVisitor)
+	}
+}
+
+// VisitInterfaceNode traverses the function signatures.
+func (v *AbstractVisitor) VisitInterfaceNode(node *InterfaceNode) {
+	for _, function := range node.Functions {
+		function.Accept(v.ConcreteVisitor)
+	}
 }
 
-// VisitContractNode traverses the variable and function nodes.
+// VisitContractNode traverses the constant, variable, modifier and function nodes.
 func (v *AbstractVisitor) VisitContractNode(node *ContractNode) {
+	for _, constant := range node.Constants {
+		constant.Accept(v.ConcreteVisitor)
+	}
+
 	for _, variable := range node.Fields {
 		variable.Accept(v.ConcreteVisitor)
 	}
 
+	for _, modifier := range node.Modifiers {
+		modifier.Accept(v.ConcreteVisitor)
+	}
+
 	if node.Constructor != nil {
 		node.Constructor.Accept(v.ConcreteVisitor)
 	}
@@ -27,6 +48,12 @@ func (v *AbstractVisitor) VisitContractNode(node *ContractNode) {
 	}
 }
 
+// VisitConstantNode traverses the type node and the expression
+func (v *AbstractVisitor) VisitConstantNode(node *ConstantNode) {
+	node.Type.Accept(v.ConcreteVisitor)
+	node.Expression.Accept(v.ConcreteVisitor)
+}
+
 // VisitFieldNode traverses the type node and the expression (if present).
 func (v *AbstractVisitor) VisitFieldNode(node *FieldNode) {
 	node.Type.Accept(v.ConcreteVisitor)
@@ -47,6 +74,25 @@ func (v *AbstractVisitor) VisitStructFieldNode(node *StructFieldNode) {
 	node.Type.Accept(v.ConcreteVisitor)
 }
 
+// VisitEnumNode traverses the enum members
+func (v *AbstractVisitor) VisitEnumNode(node *EnumNode) {
+	for _, member := range node.Members {
+		member.Accept(v.ConcreteVisitor)
+	}
+}
+
+// VisitEnumMemberNode does nothing because it is the end node
+func (v *AbstractVisitor) VisitEnumMemberNode(node *EnumMemberNode) {
+	// Nothing to do here
+}
+
+// VisitEventNode traverses the parameters
+func (v *AbstractVisitor) VisitEventNode(node *EventNode) {
+	for _, param := range node.Parameters {
+		param.Accept(v.ConcreteVisitor)
+	}
+}
+
 // VisitConstructorNode traverses the parameters and the statement block
 func (v *AbstractVisitor) VisitConstructorNode(node *ConstructorNode) {
 	for _, paramType := range node.Parameters {
@@ -55,7 +101,7 @@ func (v *AbstractVisitor) VisitConstructorNode(node *ConstructorNode) {
 	v.ConcreteVisitor.VisitStatementBlock(node.Body)
 }
 
-// VisitFunctionNode traverses the return types, parameters and finally the statement block.
+// VisitFunctionNode traverses the return types, parameters, modifier invocations and finally the statement block.
 func (v *AbstractVisitor) VisitFunctionNode(node *FunctionNode) {
 	for _, returnType := range node.ReturnTypes {
 		returnType.Accept(v.ConcreteVisitor)
@@ -63,9 +109,27 @@ func (v *AbstractVisitor) VisitFunctionNode(node *FunctionNode) {
 	for _, paramType := range node.Parameters {
 		paramType.Accept(v.ConcreteVisitor)
 	}
+	for _, modifier := range node.Modifiers {
+		modifier.Accept(v.ConcreteVisitor)
+	}
 	v.ConcreteVisitor.VisitStatementBlock(node.Body)
 }
 
+// VisitModifierNode traverses the parameters and the statement block
+func (v *AbstractVisitor) VisitModifierNode(node *ModifierNode) {
+	for _, paramType := range node.Parameters {
+		paramType.Accept(v.ConcreteVisitor)
+	}
+	v.ConcreteVisitor.VisitStatementBlock(node.Body)
+}
+
+// VisitModifierInvocationNode traverses the arguments. The modifier is resolved by the designator resolution.
+func (v *AbstractVisitor) VisitModifierInvocationNode(node *ModifierInvocationNode) {
+	for _, arg := range node.Args {
+		arg.Accept(v.ConcreteVisitor)
+	}
+}
+
 // VisitParameterNode traverses the type node
 func (v *AbstractVisitor) VisitParameterNode(node *ParameterNode) {
 	node.Type.Accept(v.ConcreteVisitor)
@@ -117,6 +181,24 @@ func (v *AbstractVisitor) VisitIfStatementNode(node *IfStatementNode) {
 	v.ConcreteVisitor.VisitStatementBlock(node.Else)
 }
 
+// VisitForStatementNode traverses the loop variable type, the range bounds and finally the statement block.
+func (v *AbstractVisitor) VisitForStatementNode(node *ForStatementNode) {
+	node.Variable.Type.Accept(v.ConcreteVisitor)
+	node.From.Accept(v.ConcreteVisitor)
+	node.To.Accept(v.ConcreteVisitor)
+	v.ConcreteVisitor.VisitStatementBlock(node.Body)
+}
+
+// VisitForEachStatementNode traverses the loop variable type (if present), the collection and finally the
+// statement block.
+func (v *AbstractVisitor) VisitForEachStatementNode(node *ForEachStatementNode) {
+	if node.Variable.Type != nil {
+		node.Variable.Type.Accept(v.ConcreteVisitor)
+	}
+	node.Collection.Accept(v.ConcreteVisitor)
+	v.ConcreteVisitor.VisitStatementBlock(node.Body)
+}
+
 // VisitReturnStatementNode traverses all the available expressions.
 func (v *AbstractVisitor) VisitReturnStatementNode(node *ReturnStatementNode) {
 	for _, expr := range node.Expressions {
@@ -154,6 +236,34 @@ func (v *AbstractVisitor) VisitDeleteStatementNode(node *DeleteStatementNode) {
 	node.Element.Accept(v.ConcreteVisitor)
 }
 
+// VisitRequireStatementNode traverses the condition and the message
+func (v *AbstractVisitor) VisitRequireStatementNode(node *RequireStatementNode) {
+	node.Condition.Accept(v.ConcreteVisitor)
+	node.Message.Accept(v.ConcreteVisitor)
+}
+
+// VisitAssertStatementNode traverses the condition
+func (v *AbstractVisitor) VisitAssertStatementNode(node *AssertStatementNode) {
+	node.Condition.Accept(v.ConcreteVisitor)
+}
+
+// VisitRevertStatementNode traverses the message
+func (v *AbstractVisitor) VisitRevertStatementNode(node *RevertStatementNode) {
+	node.Message.Accept(v.ConcreteVisitor)
+}
+
+// VisitEmitStatementNode traverses the arguments. The event is resolved by the designator resolution.
+func (v *AbstractVisitor) VisitEmitStatementNode(node *EmitStatementNode) {
+	for _, arg := range node.Args {
+		arg.Accept(v.ConcreteVisitor)
+	}
+}
+
+// VisitPlaceholderStatementNode does nothing because it is the terminal node.
+func (v *AbstractVisitor) VisitPlaceholderStatementNode(node *PlaceholderStatementNode) {
+	// Nothing to do here
+}
+
 // VisitTernaryExpressionNode traverses the condition, true and false expressions.
 func (v *AbstractVisitor) VisitTernaryExpressionNode(node *TernaryExpressionNode) {
 	node.Condition.Accept(v.ConcreteVisitor)
@@ -245,6 +355,11 @@ func (v *AbstractVisitor) VisitIntegerLiteralNode(node *IntegerLiteralNode) {
 	// Nothing to do here
 }
 
+// VisitAddressLiteralNode does nothing because it is the terminal node.
+func (v *AbstractVisitor) VisitAddressLiteralNode(node *AddressLiteralNode) {
+	// Nothing to do here
+}
+
 // VisitStringLiteralNode does nothing because it is the terminal node.
 func (v *AbstractVisitor) VisitStringLiteralNode(node *StringLiteralNode) {
 	// Nothing to do here
@@ -12,15 +12,21 @@ type Node interface {
 	// Pos returns the position of the node in the source code.
 	// It is also the position of the first token.
 	Pos() token.Position
+	// End returns the position after the last token of the node in the source code.
+	// It is zero, if the end position is unknown.
+	End() token.Position
+	// SetEnd sets the position after the last token of the node.
+	SetEnd(pos token.Position)
 	// String returns a readable string representation of the node.
 	String() string
 	// Accept lets a visitor to traverse its node structure.
 	Accept(v Visitor)
 }
 
-// AbstractNode contains node position, which all concrete nodes have.
+// AbstractNode contains node start and end position, which all concrete nodes have.
 type AbstractNode struct {
-	Position token.Position
+	Position    token.Position
+	EndPosition token.Position
 }
 
 // Pos returns the node position
@@ -28,6 +34,16 @@ func (n *AbstractNode) Pos() token.Position {
 	return n.Position
 }
 
+// End returns the position after the last token of the node
+func (n *AbstractNode) End() token.Position {
+	return n.EndPosition
+}
+
+// SetEnd sets the position after the last token of the node
+func (n *AbstractNode) SetEnd(pos token.Position) {
+	n.EndPosition = pos
+}
+
 // StatementNode is the interface for statements, such as variable, assignment, if-statement etc.
 type StatementNode interface {
 	Node
@@ -52,14 +68,15 @@ type TypeNode interface {
 // Concrete Nodes
 // -------------------------
 
-// ProgramNode composes abstract node and holds contract.
+// ProgramNode composes abstract node and holds the interfaces and contracts of a compilation unit.
 type ProgramNode struct {
 	AbstractNode
-	Contract *ContractNode
+	Interfaces []*InterfaceNode
+	Contracts  []*ContractNode
 }
 
 func (n *ProgramNode) String() string {
-	return getNodeString(n.Contract)
+	return fmt.Sprintf("INTERFACES: %s \n\n CONTRACTS: %s", n.Interfaces, n.Contracts)
 }
 
 // Accept lets a visitor to traverse its node structure.
@@ -69,12 +86,36 @@ func (n *ProgramNode) Accept(v Visitor) {
 
 // --------------------------
 
-// ContractNode composes abstract node and holds a name, state variables and functions.
+// InterfaceNode composes abstract node and holds the name and the function signatures of an interface.
+// The functions of an interface have no body.
+type InterfaceNode struct {
+	AbstractNode
+	Name      string
+	Functions []*FunctionNode
+}
+
+func (n *InterfaceNode) String() string {
+	return fmt.Sprintf("\n [%s] INTERFACE %s \n FUNCS: %s", n.Pos(), n.Name, n.Functions)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *InterfaceNode) Accept(v Visitor) {
+	v.VisitInterfaceNode(n)
+}
+
+// --------------------------
+
+// ContractNode composes abstract node and holds a name, the implemented interfaces, state variables and functions.
 type ContractNode struct {
 	AbstractNode
 	Name        string
+	Implements  []*BasicTypeNode
+	Constants   []*ConstantNode
 	Fields      []*FieldNode
 	Structs     []*StructNode
+	Enums       []*EnumNode
+	Events      []*EventNode
+	Modifiers   []*ModifierNode
 	Constructor *ConstructorNode
 	Functions   []*FunctionNode
 }
@@ -85,8 +126,8 @@ func (n *ContractNode) String() string {
 		strConstructor = n.Constructor.String()
 	}
 
-	return fmt.Sprintf("[%s] CONTRACT %s \n FIELDS: %s \n\n STRUCTS: %s \n\n CONSTRUCTOR: %s \n\n FUNCS: %s",
-		n.Pos(), n.Name, n.Fields, n.Structs, strConstructor, n.Functions)
+	return fmt.Sprintf("[%s] CONTRACT %s IMPLEMENTS %s \n CONSTANTS: %s \n\n FIELDS: %s \n\n STRUCTS: %s \n\n ENUMS: %s \n\n EVENTS: %s \n\n MODIFIERS: %s \n\n CONSTRUCTOR: %s \n\n FUNCS: %s",
+		n.Pos(), n.Name, n.Implements, n.Constants, n.Fields, n.Structs, n.Enums, n.Events, n.Modifiers, strConstructor, n.Functions)
 }
 
 // Accept lets a visitor to traverse its node structure
@@ -98,12 +139,32 @@ func (n *ContractNode) Accept(v Visitor) {
 // Contract Body Parts
 // --------------------------
 
-// FieldNode composes abstract node and holds the type, identifier and expression
+// ConstantNode composes abstract node and holds the type, identifier and value of a named constant
+type ConstantNode struct {
+	AbstractNode
+	Type       TypeNode
+	Identifier string
+	Expression ExpressionNode
+}
+
+func (n *ConstantNode) String() string {
+	return fmt.Sprintf("\n [%s] CONSTANT %s %s = %s", n.Pos(), getNodeString(n.Type), n.Identifier, getNodeString(n.Expression))
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *ConstantNode) Accept(v Visitor) {
+	v.VisitConstantNode(n)
+}
+
+// --------------------------
+
+// FieldNode composes abstract node and holds the type, identifier, expression and doc comment
 type FieldNode struct {
 	AbstractNode
 	Type       TypeNode
 	Identifier string
 	Expression ExpressionNode
+	Doc        string
 }
 
 func (n *FieldNode) String() string {
@@ -157,10 +218,64 @@ func (n *StructFieldNode) Accept(v Visitor) {
 
 // --------------------------
 
-// ConstructorNode composes abstract node and holds parameters and statements.
+// EnumNode composes abstract node and holds the name and the members of an enum type
+type EnumNode struct {
+	AbstractNode
+	Name    string
+	Members []*EnumMemberNode
+}
+
+func (n *EnumNode) String() string {
+	return fmt.Sprintf("\n [%s] ENUM %s \n MEMBERS: %s", n.Pos(), n.Name, n.Members)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *EnumNode) Accept(v Visitor) {
+	v.VisitEnumNode(n)
+}
+
+// --------------------------
+
+// EnumMemberNode composes abstract node and holds the identifier of an enum member
+type EnumMemberNode struct {
+	AbstractNode
+	Identifier string
+}
+
+func (n *EnumMemberNode) String() string {
+	return fmt.Sprintf("[%s] %s", n.Pos(), n.Identifier)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *EnumMemberNode) Accept(v Visitor) {
+	v.VisitEnumMemberNode(n)
+}
+
+// --------------------------
+
+// EventNode composes abstract node and holds the name and the parameters of an event, which can be emitted
+type EventNode struct {
+	AbstractNode
+	Name       string
+	Parameters []*ParameterNode
+}
+
+func (n *EventNode) String() string {
+	return fmt.Sprintf("\n [%s] EVENT %s, PARAMs %s", n.Pos(), n.Name, n.Parameters)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *EventNode) Accept(v Visitor) {
+	v.VisitEventNode(n)
+}
+
+// --------------------------
+
+// ConstructorNode composes abstract node and holds parameters, the payable modifier and statements.
 type ConstructorNode struct {
 	AbstractNode
 	Parameters []*ParameterNode
+	Payable    bool
 	Body       []StatementNode
 }
 
@@ -176,18 +291,22 @@ func (n *ConstructorNode) Accept(v Visitor) {
 
 // --------------------------
 
-// FunctionNode composes abstract node and holds a name, return types, parameters and statements.
+// FunctionNode composes abstract node and holds a name, return types, parameters, modifiers, statements and doc comment.
 type FunctionNode struct {
 	AbstractNode
 	Name        string
 	ReturnTypes []TypeNode
 	Parameters  []*ParameterNode
+	Visibility  token.Symbol
+	Payable     bool
+	Modifiers   []*ModifierInvocationNode
 	Body        []StatementNode
+	Doc         string
 }
 
 func (n *FunctionNode) String() string {
-	return fmt.Sprintf("\n [%s] FUNCTION %s, PARAMs %s, RTYPES %s %s",
-		n.Pos(), n.Name, n.Parameters, n.ReturnTypes, n.Body)
+	return fmt.Sprintf("\n [%s] FUNCTION %s %s, PARAMs %s, RTYPES %s %s",
+		n.Pos(), token.SymbolLexeme[n.Visibility], n.Name, n.Parameters, n.ReturnTypes, n.Body)
 }
 
 // Accept lets a visitor to traverse its node structure
@@ -197,6 +316,44 @@ func (n *FunctionNode) Accept(v Visitor) {
 
 // --------------------------
 
+// ModifierNode composes abstract node and holds the name, the parameters and the statements of a modifier.
+// The statements contain a placeholder, which is replaced by the body of the modified function.
+type ModifierNode struct {
+	AbstractNode
+	Name       string
+	Parameters []*ParameterNode
+	Body       []StatementNode
+}
+
+func (n *ModifierNode) String() string {
+	return fmt.Sprintf("\n [%s] MODIFIER %s, PARAMs %s %s", n.Pos(), n.Name, n.Parameters, n.Body)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *ModifierNode) Accept(v Visitor) {
+	v.VisitModifierNode(n)
+}
+
+// --------------------------
+
+// ModifierInvocationNode composes abstract node and holds the modifier and the arguments, with which it is applied to a function
+type ModifierInvocationNode struct {
+	AbstractNode
+	Modifier *BasicDesignatorNode
+	Args     []ExpressionNode
+}
+
+func (n *ModifierInvocationNode) String() string {
+	return fmt.Sprintf("%s(%s)", getNodeString(n.Modifier), n.Args)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *ModifierInvocationNode) Accept(v Visitor) {
+	v.VisitModifierInvocationNode(n)
+}
+
+// --------------------------
+
 // ParameterNode composes abstract node and holds the type and identifier
 type ParameterNode struct {
 	AbstractNode
@@ -360,6 +517,49 @@ func (n *IfStatementNode) Accept(v Visitor) {
 
 // --------------------------
 
+// ForStatementNode composes abstract node and holds the loop variable, the range bounds and the statement block.
+// The range includes the lower bound (From) and excludes the upper bound (To).
+type ForStatementNode struct {
+	AbstractNode
+	Variable *VariableNode
+	From     ExpressionNode
+	To       ExpressionNode
+	Body     []StatementNode
+}
+
+func (n *ForStatementNode) String() string {
+	return fmt.Sprintf("\n [%s] FOR %s %s IN %s..%s %s", n.Pos(), getNodeString(n.Variable.Type),
+		n.Variable.Identifier, getNodeString(n.From), getNodeString(n.To), n.Body)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *ForStatementNode) Accept(v Visitor) {
+	v.VisitForStatementNode(n)
+}
+
+// --------------------------
+
+// ForEachStatementNode composes abstract node and holds the loop variable, the array expression and the statement
+// block. The type of the loop variable is optional and inferred from the array element type if missing.
+type ForEachStatementNode struct {
+	AbstractNode
+	Variable   *VariableNode
+	Collection ExpressionNode
+	Body       []StatementNode
+}
+
+func (n *ForEachStatementNode) String() string {
+	return fmt.Sprintf("\n [%s] FOREACH %s %s IN %s %s", n.Pos(), getNodeString(n.Variable.Type),
+		n.Variable.Identifier, getNodeString(n.Collection), n.Body)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *ForEachStatementNode) Accept(v Visitor) {
+	v.VisitForEachStatementNode(n)
+}
+
+// --------------------------
+
 // ReturnStatementNode composes abstract node and holds the return expressions.
 type ReturnStatementNode struct {
 	AbstractNode
@@ -469,6 +669,92 @@ func (n *DeleteStatementNode) Accept(v Visitor) {
 	v.VisitDeleteStatementNode(n)
 }
 
+// RequireStatementNode composes abstract node and holds the condition and the error message,
+// with which the transaction is aborted if the condition is false
+type RequireStatementNode struct {
+	AbstractNode
+	Condition ExpressionNode
+	Message   ExpressionNode
+}
+
+func (n *RequireStatementNode) String() string {
+	return fmt.Sprintf("\n [%s] REQUIRE %s, %s", n.Pos(), getNodeString(n.Condition), getNodeString(n.Message))
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *RequireStatementNode) Accept(v Visitor) {
+	v.VisitRequireStatementNode(n)
+}
+
+// --------------------------
+
+// AssertStatementNode composes abstract node and holds the condition, which has to be true.
+// Otherwise, the transaction is aborted.
+type AssertStatementNode struct {
+	AbstractNode
+	Condition ExpressionNode
+}
+
+func (n *AssertStatementNode) String() string {
+	return fmt.Sprintf("\n [%s] ASSERT %s", n.Pos(), getNodeString(n.Condition))
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *AssertStatementNode) Accept(v Visitor) {
+	v.VisitAssertStatementNode(n)
+}
+
+// --------------------------
+
+// RevertStatementNode composes abstract node and holds the error message, with which the transaction is aborted
+type RevertStatementNode struct {
+	AbstractNode
+	Message ExpressionNode
+}
+
+func (n *RevertStatementNode) String() string {
+	return fmt.Sprintf("\n [%s] REVERT %s", n.Pos(), getNodeString(n.Message))
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *RevertStatementNode) Accept(v Visitor) {
+	v.VisitRevertStatementNode(n)
+}
+
+// --------------------------
+
+// EmitStatementNode composes abstract node and holds the emitted event and its arguments
+type EmitStatementNode struct {
+	AbstractNode
+	Event *BasicDesignatorNode
+	Args  []ExpressionNode
+}
+
+func (n *EmitStatementNode) String() string {
+	return fmt.Sprintf("\n [%s] EMIT %s(%s)", n.Pos(), getNodeString(n.Event), n.Args)
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *EmitStatementNode) Accept(v Visitor) {
+	v.VisitEmitStatementNode(n)
+}
+
+// --------------------------
+
+// PlaceholderStatementNode composes abstract node and marks the position of the function body in a modifier
+type PlaceholderStatementNode struct {
+	AbstractNode
+}
+
+func (n *PlaceholderStatementNode) String() string {
+	return fmt.Sprintf("\n [%s] PLACEHOLDER", n.Pos())
+}
+
+// Accept lets a visitor to traverse its node structure
+func (n *PlaceholderStatementNode) Accept(v Visitor) {
+	v.VisitPlaceholderStatementNode(n)
+}
+
 // --------------------------
 // Expression Nodes
 // --------------------------
@@ -749,6 +1035,23 @@ func (n *IntegerLiteralNode) Accept(v Visitor) {
 
 // --------------------------
 
+// AddressLiteralNode composes abstract node and holds the bytes of the account address.
+type AddressLiteralNode struct {
+	AbstractNode
+	Value []byte
+}
+
+func (n *AddressLiteralNode) String() string {
+	return fmt.Sprintf("@%x", n.Value)
+}
+
+// Accept lets a visitor to traverse its node structure.
+func (n *AddressLiteralNode) Accept(v Visitor) {
+	v.VisitAddressLiteralNode(n)
+}
+
+// --------------------------
+
 // StringLiteralNode composes abstract node and holds string literal value.
 type StringLiteralNode struct {
 	AbstractNode
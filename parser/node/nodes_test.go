@@ -16,7 +16,7 @@ func TestContractNode_String(t *testing.T) {
 		Name: "Test",
 	}
 	assert.Equal(t, contract.String(),
-		"[1:1] CONTRACT Test \n FIELDS: [] \n\n STRUCTS: [] \n\n CONSTRUCTOR:  \n\n FUNCS: []")
+		"[1:1] CONTRACT Test IMPLEMENTS [] \n CONSTANTS: [] \n\n FIELDS: [] \n\n STRUCTS: [] \n\n ENUMS: [] \n\n EVENTS: [] \n\n MODIFIERS: [] \n\n CONSTRUCTOR:  \n\n FUNCS: []")
 }
 
 // Type Nodes
@@ -3,12 +3,19 @@ package node
 // Visitor is the interface that defines the functions a concrete visitor should implement.
 type Visitor interface {
 	VisitProgramNode(node *ProgramNode)
+	VisitInterfaceNode(node *InterfaceNode)
 	VisitContractNode(node *ContractNode)
+	VisitConstantNode(node *ConstantNode)
 	VisitFieldNode(node *FieldNode)
 	VisitStructNode(node *StructNode)
 	VisitStructFieldNode(node *StructFieldNode)
+	VisitEnumNode(node *EnumNode)
+	VisitEnumMemberNode(node *EnumMemberNode)
+	VisitEventNode(node *EventNode)
 	VisitConstructorNode(node *ConstructorNode)
 	VisitFunctionNode(node *FunctionNode)
+	VisitModifierNode(node *ModifierNode)
+	VisitModifierInvocationNode(node *ModifierInvocationNode)
 	VisitParameterNode(node *ParameterNode)
 	VisitStatementBlock(stmts []StatementNode)
 	VisitVariableNode(node *VariableNode)
@@ -17,12 +24,19 @@ type Visitor interface {
 	VisitArrayTypeNode(node *ArrayTypeNode)
 	VisitMapTypeNode(node *MapTypeNode)
 	VisitIfStatementNode(node *IfStatementNode)
+	VisitForStatementNode(node *ForStatementNode)
+	VisitForEachStatementNode(node *ForEachStatementNode)
 	VisitReturnStatementNode(node *ReturnStatementNode)
 	VisitAssignmentStatementNode(node *AssignmentStatementNode)
 	VisitMultiAssignmentStatementNode(node *MultiAssignmentStatementNode)
 	VisitShorthandAssignmentNode(node *ShorthandAssignmentStatementNode)
 	VisitCallStatementNode(node *CallStatementNode)
 	VisitDeleteStatementNode(node *DeleteStatementNode)
+	VisitRequireStatementNode(node *RequireStatementNode)
+	VisitAssertStatementNode(node *AssertStatementNode)
+	VisitRevertStatementNode(node *RevertStatementNode)
+	VisitEmitStatementNode(node *EmitStatementNode)
+	VisitPlaceholderStatementNode(node *PlaceholderStatementNode)
 	VisitTernaryExpressionNode(node *TernaryExpressionNode)
 	VisitBinaryExpressionNode(node *BinaryExpressionNode)
 	VisitUnaryExpressionNode(node *UnaryExpressionNode)
@@ -38,6 +52,7 @@ type Visitor interface {
 	VisitArrayLengthCreationNode(node *ArrayLengthCreationNode)
 	VisitArrayValueCreationNode(node *ArrayValueCreationNode)
 	VisitIntegerLiteralNode(node *IntegerLiteralNode)
+	VisitAddressLiteralNode(node *AddressLiteralNode)
 	VisitStringLiteralNode(node *StringLiteralNode)
 	VisitCharacterLiteralNode(node *CharacterLiteralNode)
 	VisitBoolLiteralNode(node *BoolLiteralNode)
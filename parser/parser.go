@@ -4,19 +4,27 @@ package parser
 
 import (
 	"fmt"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/lexer"
 	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 	"math/big"
+	"strings"
 )
 
 // Parser is a LL(k=2) parser, which means "Left-to-right, Leftmost derivation" top-down parser.
 // It holds 2 lookahead tokens (current and peek token) from the given lexer to parse the input.
 // It also collects all the syntactic errors.
+// Doc comments are collected from the lexer and attached to the next token, which is not a new line.
+// The end of the last consumed token, except new lines, is kept as end position of the parsed nodes.
 type Parser struct {
 	lex          *lexer.Lexer
 	currentToken token.Token
 	peekToken    token.Token
+	lastEnd      token.Position
+	currentDoc   string
+	peekDoc      string
+	docComments  []string
 	errors       []error
 }
 
@@ -45,14 +53,59 @@ func New(lex *lexer.Lexer) *Parser {
 func (p *Parser) ParseProgram() (*node.ProgramNode, []error) {
 	program := &node.ProgramNode{}
 
-	if p.isSymbol(token.Contract) {
-		program.Contract = p.parseContract()
+	for !p.isEnd() {
+		if p.isSymbol(token.Contract) {
+			program.Contracts = append(program.Contracts, p.parseContract())
+		} else if p.isSymbol(token.Interface) {
+			program.Interfaces = append(program.Interfaces, p.parseInterface())
+		} else {
+			p.addError(diagnostic.SyntaxError, "Invalid token outside contract: "+p.currentToken.String())
+			break
+		}
 	}
+	return program, p.errors
+}
 
-	if !p.isEnd() {
-		p.addError("Invalid token outside contract: " + p.currentToken.String())
+func (p *Parser) parseInterface() *node.InterfaceNode {
+	i := &node.InterfaceNode{
+		AbstractNode: p.newAbstractNode(),
 	}
-	return program, p.errors
+	p.nextToken() // skip interface keyword
+
+	i.Name = p.readIdentifier()
+	p.check(token.OpenBrace)
+	p.checkAndSkipNewLines(token.NewLine) // force new line for interface body
+
+	for !p.isEnd() && !p.isSymbol(token.CloseBrace) {
+		if p.isSymbol(token.Function) {
+			i.Functions = append(i.Functions, p.parseFunctionSignature())
+		} else {
+			p.addError(diagnostic.SyntaxError, "Unsupported interface part: "+p.currentToken.Literal())
+			p.nextToken()
+		}
+	}
+
+	p.checkAndSkipNewLines(token.CloseBrace)
+	p.setEnd(i)
+	return i
+}
+
+// parseFunctionSignature reads a function declaration without modifiers and body, e.g. function int balanceOf(address a)
+func (p *Parser) parseFunctionSignature() *node.FunctionNode {
+	function := &node.FunctionNode{
+		AbstractNode: p.newAbstractNode(),
+		Doc:          p.currentDoc,
+		Visibility:   token.Public,
+	}
+	p.nextToken() // skip function keyword
+
+	function.ReturnTypes = p.parseReturnTypes()
+	function.Name = p.readIdentifier()
+	function.Parameters = p.parseParameters()
+	p.setEnd(function)
+	p.checkAndSkipNewLines(token.NewLine)
+
+	return function
 }
 
 func (p *Parser) parseContract() *node.ContractNode {
@@ -62,6 +115,9 @@ func (p *Parser) parseContract() *node.ContractNode {
 	p.nextToken() // skip contract keyword
 
 	contract.Name = p.readIdentifier()
+	if p.isSymbol(token.Implements) {
+		contract.Implements = p.parseImplements()
+	}
 	p.check(token.OpenBrace)
 	p.checkAndSkipNewLines(token.NewLine) // force new line for contract body
 
@@ -70,9 +126,30 @@ func (p *Parser) parseContract() *node.ContractNode {
 	}
 
 	p.checkAndSkipNewLines(token.CloseBrace)
+	p.setEnd(contract)
 	return contract
 }
 
+// parseImplements reads the comma separated interface names after the 'implements' keyword
+func (p *Parser) parseImplements() []*node.BasicTypeNode {
+	var interfaces []*node.BasicTypeNode
+	p.nextToken() // skip 'implements' keyword
+
+	for {
+		interfaceType := &node.BasicTypeNode{
+			AbstractNode: p.newAbstractNode(),
+			Identifier:   p.readIdentifier(),
+		}
+		p.setEnd(interfaceType)
+		interfaces = append(interfaces, interfaceType)
+
+		if !p.isSymbol(token.Comma) {
+			return interfaces
+		}
+		p.nextToken() // skip ','
+	}
+}
+
 func (p *Parser) parseContractBody(contract *node.ContractNode) {
 	switch p.currentToken.Type() {
 	case token.IDENTIFER:
@@ -87,19 +164,27 @@ func (p *Parser) parseContractBody(contract *node.ContractNode) {
 			if contract.Constructor == nil {
 				contract.Constructor = p.parseConstructor()
 			} else {
-				p.addError(fmt.Sprintf("Only one constructor is allowed"))
+				p.addError(diagnostic.DuplicateConstructor, "Only one constructor is allowed")
 				p.nextToken()
 			}
 		case token.Struct:
 			contract.Structs = append(contract.Structs, p.parseStruct())
+		case token.Enum:
+			contract.Enums = append(contract.Enums, p.parseEnum())
+		case token.Event:
+			contract.Events = append(contract.Events, p.parseEvent())
+		case token.Modifier:
+			contract.Modifiers = append(contract.Modifiers, p.parseModifier())
+		case token.Const:
+			contract.Constants = append(contract.Constants, p.parseConstant())
 		case token.Map:
 			contract.Fields = append(contract.Fields, p.parseField())
 		default:
-			p.addError(fmt.Sprintf("Unsupported symbol %s in contract", ftok.Lexeme))
+			p.addError(diagnostic.SyntaxError, fmt.Sprintf("Unsupported symbol %s in contract", ftok.Lexeme))
 			p.nextToken()
 		}
 	default:
-		p.addError("Unsupported contract part: " + p.currentToken.Literal())
+		p.addError(diagnostic.SyntaxError, "Unsupported contract part: "+p.currentToken.Literal())
 		p.nextToken()
 	}
 }
@@ -107,18 +192,35 @@ func (p *Parser) parseContractBody(contract *node.ContractNode) {
 func (p *Parser) parseField() *node.FieldNode {
 	v := &node.FieldNode{
 		AbstractNode: p.newAbstractNode(),
-		Type:         p.parseType(),
-		Identifier:   p.readIdentifier(),
+		Doc:          p.currentDoc,
 	}
+	v.Type = p.parseType()
+	v.Identifier = p.readIdentifier()
 
 	if p.isSymbol(token.Assign) {
 		p.nextToken()
 		v.Expression = p.parseExpression()
 	}
+	p.setEnd(v)
 	p.checkAndSkipNewLines(token.NewLine)
 	return v
 }
 
+func (p *Parser) parseConstant() *node.ConstantNode {
+	c := &node.ConstantNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+	p.nextToken() // skip 'const' keyword
+
+	c.Type = p.parseType()
+	c.Identifier = p.readIdentifier()
+	p.check(token.Assign)
+	c.Expression = p.parseExpression()
+	p.setEnd(c)
+	p.checkAndSkipNewLines(token.NewLine)
+	return c
+}
+
 func (p *Parser) parseStruct() *node.StructNode {
 	s := &node.StructNode{
 		AbstractNode: p.newAbstractNode(),
@@ -135,16 +237,64 @@ func (p *Parser) parseStruct() *node.StructNode {
 			Type:         p.parseType(),
 			Identifier:   p.readIdentifier(),
 		}
+		p.setEnd(f)
 		p.checkAndSkipNewLines(token.NewLine)
 		s.Fields = append(s.Fields, f)
 	}
 
 	p.check(token.CloseBrace)
+	p.setEnd(s)
 	p.checkAndSkipNewLines(token.NewLine)
 
 	return s
 }
 
+func (p *Parser) parseEnum() *node.EnumNode {
+	e := &node.EnumNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+	p.nextToken() // skip 'enum' keyword
+
+	e.Name = p.readIdentifier()
+	p.check(token.OpenBrace)
+	p.skipNewLines()
+
+	isFirstMember := true
+	for !p.isEnd() && !p.isSymbol(token.CloseBrace) {
+		if !isFirstMember {
+			p.checkAndSkipNewLines(token.Comma)
+		}
+		member := &node.EnumMemberNode{
+			AbstractNode: p.newAbstractNode(),
+			Identifier:   p.readIdentifier(),
+		}
+		p.setEnd(member)
+		p.skipNewLines()
+		e.Members = append(e.Members, member)
+		isFirstMember = false
+	}
+
+	p.check(token.CloseBrace)
+	p.setEnd(e)
+	p.checkAndSkipNewLines(token.NewLine)
+
+	return e
+}
+
+func (p *Parser) parseEvent() *node.EventNode {
+	e := &node.EventNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+	p.nextToken() // skip 'event' keyword
+
+	e.Name = p.readIdentifier()
+	e.Parameters = p.parseParameters()
+	p.setEnd(e)
+	p.checkAndSkipNewLines(token.NewLine)
+
+	return e
+}
+
 func (p *Parser) parseConstructor() *node.ConstructorNode {
 	constructor := &node.ConstructorNode{
 		AbstractNode: p.newAbstractNode(),
@@ -152,7 +302,9 @@ func (p *Parser) parseConstructor() *node.ConstructorNode {
 	p.nextToken() // skip constructor keyword
 
 	constructor.Parameters = p.parseParameters()
+	constructor.Payable = p.parsePayable()
 	constructor.Body = p.parseStatementBlock()
+	p.setEnd(constructor)
 
 	return constructor
 }
@@ -160,17 +312,92 @@ func (p *Parser) parseConstructor() *node.ConstructorNode {
 func (p *Parser) parseFunction() *node.FunctionNode {
 	function := &node.FunctionNode{
 		AbstractNode: p.newAbstractNode(),
+		Doc:          p.currentDoc,
 	}
 	p.nextToken() // skip function keyword
 
 	function.ReturnTypes = p.parseReturnTypes()
 	function.Name = p.readIdentifier()
 	function.Parameters = p.parseParameters()
+	p.parseFunctionModifiers(function)
 	function.Body = p.parseStatementBlock()
+	p.setEnd(function)
 
 	return function
 }
 
+// parseFunctionModifiers reads the visibility, payable and user-defined modifiers after the function parameters.
+// Functions without a visibility modifier are public.
+func (p *Parser) parseFunctionModifiers(function *node.FunctionNode) {
+	function.Visibility = token.Public
+	isVisibilityDeclared := false
+	for p.isType(token.IDENTIFER) || p.isAnySymbol(token.Public, token.Private, token.Internal, token.Payable) {
+		if p.isType(token.IDENTIFER) {
+			function.Modifiers = append(function.Modifiers, p.parseModifierInvocation())
+			continue
+		}
+		if p.isSymbol(token.Payable) {
+			function.Payable = p.parsePayable()
+			continue
+		}
+		if isVisibilityDeclared {
+			p.addError(diagnostic.SyntaxError, "Only one visibility modifier is allowed")
+		}
+		function.Visibility = p.currentToken.(*token.FixToken).Value
+		isVisibilityDeclared = true
+		p.nextToken()
+	}
+}
+
+// parseModifierInvocation reads a user-defined modifier and its arguments, e.g. onlyOwner or minAmount(10).
+// The parentheses can be omitted if the modifier has no parameters.
+func (p *Parser) parseModifierInvocation() *node.ModifierInvocationNode {
+	invocation := &node.ModifierInvocationNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+	invocation.Modifier = &node.BasicDesignatorNode{
+		AbstractNode: p.newAbstractNode(),
+		Value:        p.readIdentifier(),
+	}
+	p.setEnd(invocation.Modifier)
+
+	if p.isSymbol(token.OpenParen) {
+		invocation.Args = p.parseFuncCall(invocation.Modifier).Args
+	}
+	p.setEnd(invocation)
+	return invocation
+}
+
+// parsePayable reads the optional payable modifier
+func (p *Parser) parsePayable() bool {
+	if !p.isSymbol(token.Payable) {
+		return false
+	}
+	p.nextToken()
+	if p.isSymbol(token.Payable) {
+		p.addError(diagnostic.SyntaxError, "Only one payable modifier is allowed")
+		p.nextToken()
+	}
+	return true
+}
+
+// parseModifier reads a modifier declaration. The parentheses can be omitted if the modifier has no parameters.
+func (p *Parser) parseModifier() *node.ModifierNode {
+	modifier := &node.ModifierNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+	p.nextToken() // skip modifier keyword
+
+	modifier.Name = p.readIdentifier()
+	if p.isSymbol(token.OpenParen) {
+		modifier.Parameters = p.parseParameters()
+	}
+	modifier.Body = p.parseStatementBlock()
+	p.setEnd(modifier)
+
+	return modifier
+}
+
 func (p *Parser) parseReturnTypes() []node.TypeNode {
 	var returnTypes []node.TypeNode
 
@@ -203,6 +430,7 @@ func (p *Parser) parseParameters() []*node.ParameterNode {
 			Type:         p.parseType(),
 			Identifier:   p.readIdentifier(),
 		}
+		p.setEnd(param)
 		parameters = append(parameters, param)
 		isFirstParam = false
 	}
@@ -232,16 +460,22 @@ func (p *Parser) parseStatementBlock() []node.StatementNode {
 }
 
 func (p *Parser) parseStatement() node.StatementNode {
+	var stmt node.StatementNode
 	switch p.currentToken.Type() {
 	case token.IDENTIFER:
-		return p.parseStatementWithIdentifier()
+		stmt = p.parseStatementWithIdentifier()
 	case token.SYMBOL:
-		return p.parseStatementWithFixToken()
+		stmt = p.parseStatementWithFixToken()
 	default:
-		p.addError("Unsupported statement starting with " + p.currentToken.Literal())
+		p.addError(diagnostic.SyntaxError, "Unsupported statement starting with "+p.currentToken.Literal())
 		p.nextToken()
 		return nil
 	}
+
+	if stmt != nil {
+		p.setEnd(stmt)
+	}
+	return stmt
 }
 
 func (p *Parser) parseStatementWithIdentifier() node.StatementNode {
@@ -251,7 +485,10 @@ func (p *Parser) parseStatementWithIdentifier() node.StatementNode {
 	if p.currentToken.Type() == token.IDENTIFER || p.isSymbol(token.OpenBracket) && p.peekIsSymbol(token.CloseBracket) {
 		return p.parseVariableStatementWithIdentifier(abstractNode, identifier)
 	}
-	designator := p.parseDesignatorWithIdentifier(abstractNode, identifier)
+	return p.parseStatementWithDesignator(p.parseDesignatorWithIdentifier(abstractNode, identifier))
+}
+
+func (p *Parser) parseStatementWithDesignator(designator node.DesignatorNode) node.StatementNode {
 	if p.isType(token.SYMBOL) {
 		tok := p.currentToken.(*token.FixToken)
 		switch tok.Value {
@@ -267,11 +504,11 @@ func (p *Parser) parseStatementWithIdentifier() node.StatementNode {
 	}
 
 	if p.isType(token.IDENTIFER) {
-		p.addError("Invalid Array declaration")
+		p.addError(diagnostic.InvalidTypeSyntax, "Invalid Array declaration")
 		return nil
 	}
 
-	p.addError("not yet implemented " + p.currentToken.Literal())
+	p.addError(diagnostic.SyntaxError, "not yet implemented "+p.currentToken.Literal())
 	p.nextToken()
 	return nil
 }
@@ -282,14 +519,30 @@ func (p *Parser) parseStatementWithFixToken() node.StatementNode {
 	switch ftok.Value {
 	case token.If:
 		return p.parseIfStatement()
+	case token.For:
+		return p.parseForStatement()
+	case token.ForEach:
+		return p.parseForEachStatement()
 	case token.Return:
 		return p.parseReturnStatement()
 	case token.Map:
 		return p.parseVariableStatement()
 	case token.Delete:
 		return p.parseDeleteStatement()
+	case token.Require:
+		return p.parseRequireStatement()
+	case token.Assert:
+		return p.parseAssertStatement()
+	case token.Revert:
+		return p.parseRevertStatement()
+	case token.Emit:
+		return p.parseEmitStatement()
+	case token.Placeholder:
+		return p.parsePlaceholderStatement()
+	case token.Contract:
+		return p.parseStatementWithDesignator(p.parseContractDesignator())
 	default:
-		p.addError("Unsupported statement starting with " + ftok.Literal())
+		p.addError(diagnostic.SyntaxError, "Unsupported statement starting with "+ftok.Literal())
 		p.nextToken()
 		return nil
 	}
@@ -321,6 +574,7 @@ func (p *Parser) parseVariableStatementWithType(varType node.TypeNode) node.Stat
 		p.nextToken()
 		v.Expression = p.parseExpression()
 	}
+	p.setEnd(v)
 	p.checkAndSkipNewLines(token.NewLine)
 	return v
 }
@@ -391,7 +645,7 @@ var allowedShorthandOperators = []token.Symbol{
 
 func (p *Parser) parseShorthandAssignmentStatement(designator node.DesignatorNode, operator token.Symbol) node.StatementNode {
 	if !containsSymbol(allowedShorthandOperators, operator) {
-		p.addError(fmt.Sprintf("Unsupported symbol %s", token.SymbolLexeme[operator]))
+		p.addError(diagnostic.SyntaxError, fmt.Sprintf("Unsupported symbol %s", token.SymbolLexeme[operator]))
 		return nil
 	}
 
@@ -403,7 +657,7 @@ func (p *Parser) parseShorthandAssignmentStatement(designator node.DesignatorNod
 
 	p.nextToken()
 	if !p.isType(token.SYMBOL) {
-		p.addError(fmt.Sprintf("Symbol token expected"))
+		p.addError(diagnostic.SyntaxError, "Symbol token expected")
 		return nil
 	}
 
@@ -453,6 +707,61 @@ func (p *Parser) parseIfStatement() *node.IfStatementNode {
 	}
 }
 
+func (p *Parser) parseForStatement() *node.ForStatementNode {
+	abstractNode := p.newAbstractNode()
+	p.nextToken() // skip 'for' keyword
+
+	// Loop variable and range, e.g. (int i : 0..10)
+	p.check(token.OpenParen)
+	variable := &node.VariableNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+	variable.Type = p.parseType()
+	variable.Identifier = p.readIdentifier()
+	p.setEnd(variable)
+
+	p.check(token.Colon)
+	from := p.parseExpression()
+	p.check(token.Range)
+	to := p.parseExpression()
+	p.check(token.CloseParen)
+
+	return &node.ForStatementNode{
+		AbstractNode: abstractNode,
+		Variable:     variable,
+		From:         from,
+		To:           to,
+		Body:         p.parseStatementBlock(),
+	}
+}
+
+func (p *Parser) parseForEachStatement() *node.ForEachStatementNode {
+	abstractNode := p.newAbstractNode()
+	p.nextToken() // skip 'foreach' keyword
+
+	// Loop variable with optional type and the collection, e.g. (x in arr) or (int x in arr)
+	p.check(token.OpenParen)
+	variable := &node.VariableNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+	if !p.isType(token.IDENTIFER) || !p.peekIsSymbol(token.In) {
+		variable.Type = p.parseType()
+	}
+	variable.Identifier = p.readIdentifier()
+	p.setEnd(variable)
+
+	p.check(token.In)
+	collection := p.parseExpression()
+	p.check(token.CloseParen)
+
+	return &node.ForEachStatementNode{
+		AbstractNode: abstractNode,
+		Variable:     variable,
+		Collection:   collection,
+		Body:         p.parseStatementBlock(),
+	}
+}
+
 func (p *Parser) parseReturnStatement() *node.ReturnStatementNode {
 	var returnValues []node.ExpressionNode
 	abstractNode := p.newAbstractNode()
@@ -496,12 +805,81 @@ func (p *Parser) parseDeleteStatement() *node.DeleteStatementNode {
 	if elementAccess, ok := designator.(*node.ElementAccessNode); ok {
 		d.Element = elementAccess
 	} else {
-		p.addError("delete requires element access expression")
+		p.addErrorAt(diagnostic.InvalidDelete, designator, "delete requires element access expression")
 	}
 	p.checkAndSkipNewLines(token.NewLine)
 	return d
 }
 
+func (p *Parser) parseRequireStatement() *node.RequireStatementNode {
+	r := &node.RequireStatementNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+
+	p.check(token.Require)
+	p.check(token.OpenParen)
+	r.Condition = p.parseExpression()
+	p.check(token.Comma)
+	r.Message = p.parseExpression()
+	p.check(token.CloseParen)
+	p.checkAndSkipNewLines(token.NewLine)
+	return r
+}
+
+func (p *Parser) parseAssertStatement() *node.AssertStatementNode {
+	a := &node.AssertStatementNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+
+	p.check(token.Assert)
+	p.check(token.OpenParen)
+	a.Condition = p.parseExpression()
+	p.check(token.CloseParen)
+	p.checkAndSkipNewLines(token.NewLine)
+	return a
+}
+
+func (p *Parser) parseRevertStatement() *node.RevertStatementNode {
+	r := &node.RevertStatementNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+
+	p.check(token.Revert)
+	p.check(token.OpenParen)
+	r.Message = p.parseExpression()
+	p.check(token.CloseParen)
+	p.checkAndSkipNewLines(token.NewLine)
+	return r
+}
+
+func (p *Parser) parseEmitStatement() *node.EmitStatementNode {
+	e := &node.EmitStatementNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+
+	p.check(token.Emit)
+	e.Event = &node.BasicDesignatorNode{
+		AbstractNode: p.newAbstractNode(),
+		Value:        p.readIdentifier(),
+	}
+	p.setEnd(e.Event)
+	e.Args = p.parseFuncCall(e.Event).Args
+	p.setEnd(e)
+	p.checkAndSkipNewLines(token.NewLine)
+	return e
+}
+
+func (p *Parser) parsePlaceholderStatement() *node.PlaceholderStatementNode {
+	placeholder := &node.PlaceholderStatementNode{
+		AbstractNode: p.newAbstractNode(),
+	}
+
+	p.check(token.Placeholder)
+	p.setEnd(placeholder)
+	p.checkAndSkipNewLines(token.NewLine)
+	return placeholder
+}
+
 // ----------------------------------------- End of Statements
 
 func (p *Parser) parseType() node.TypeNode {
@@ -513,7 +891,7 @@ func (p *Parser) parseType() node.TypeNode {
 		return p.parseMapType()
 	}
 
-	p.addError("Invalid type")
+	p.addError(diagnostic.InvalidTypeSyntax, "Invalid type")
 	p.nextToken()
 	return nil
 }
@@ -523,6 +901,7 @@ func (p *Parser) parseTypeWithIdentifier(abstractNode node.AbstractNode, identif
 		AbstractNode: abstractNode,
 		Identifier:   identifier,
 	}
+	p.setEnd(typeNode)
 
 	if p.isSymbol(token.OpenBracket) {
 		return p.parseArrayType(typeNode)
@@ -539,6 +918,7 @@ func (p *Parser) parseArrayType(arrayType node.TypeNode) node.TypeNode {
 		AbstractNode: p.newAbstractNodeWithPos(arrayType.Pos()),
 		ElementType:  arrayType,
 	}
+	p.setEnd(arrayTypeNode)
 	if p.isSymbol(token.OpenBracket) {
 		return p.parseArrayType(arrayTypeNode)
 	}
@@ -554,17 +934,63 @@ func (p *Parser) parseMapType() node.TypeNode {
 	mapType.KeyType = p.parseType()
 	p.check(token.Comma)
 	mapType.ValueType = p.parseType()
-	p.check(token.Greater)
+	p.checkClosingAngle()
+	p.setEnd(mapType)
 
 	return mapType
 }
 
+// checkClosingAngle checks the closing '>' of a map type.
+// The lexer reads the closing symbols of a nested map type (e.g. Map<int, Map<int, int>>) as '>>'.
+// In this case, only the first '>' is consumed and the second one remains as current token.
+func (p *Parser) checkClosingAngle() {
+	shiftRight, ok := p.currentToken.(*token.FixToken)
+	if !ok || shiftRight.Value != token.ShiftRight {
+		p.check(token.Greater)
+		return
+	}
+
+	pos := shiftRight.Pos()
+	pos.MoveRight()
+	p.lastEnd = pos
+	p.currentToken = &token.FixToken{
+		AbstractToken: token.AbstractToken{
+			Position:    pos,
+			EndPosition: shiftRight.End(),
+			Lexeme:      token.SymbolLexeme[token.Greater],
+		},
+		Value: token.Greater,
+	}
+}
+
 // Helper functions
 // -----------------
 
 func (p *Parser) nextToken() {
+	if p.currentToken != nil && !p.isSymbol(token.NewLine) {
+		p.lastEnd = p.currentToken.End()
+	}
 	p.currentToken = p.peekToken
-	p.peekToken = p.lex.NextToken()
+	p.currentDoc = p.peekDoc
+	p.peekToken, p.peekDoc = p.readToken()
+}
+
+// readToken reads the next token from lexer and returns it together with the preceding doc comments.
+// Doc comments are kept across new lines, so that they are attached to the following declaration.
+func (p *Parser) readToken() (token.Token, string) {
+	tok := p.lex.NextToken()
+	for tok.Type() == token.DOC {
+		p.docComments = append(p.docComments, tok.Literal())
+		tok = p.lex.NextToken()
+	}
+
+	if ftok, ok := tok.(*token.FixToken); ok && ftok.Value == token.NewLine {
+		return tok, ""
+	}
+
+	doc := strings.Join(p.docComments, "\n")
+	p.docComments = nil
+	return tok, doc
 }
 
 func (p *Parser) nextTokenWhileNewLine() {
@@ -612,7 +1038,7 @@ func (p *Parser) check(symbol token.Symbol) {
 		} else {
 			lexeme = p.currentToken.Literal()
 		}
-		p.addError(fmt.Sprintf("Symbol %s expected, but got %s", token.SymbolLexeme[symbol], lexeme))
+		p.addError(diagnostic.SyntaxError, fmt.Sprintf("Symbol %s expected, but got %s", token.SymbolLexeme[symbol], lexeme))
 	}
 	p.nextToken()
 }
@@ -634,7 +1060,7 @@ func (p *Parser) readIdentifier() string {
 	if tok, ok := p.currentToken.(*token.IdentifierToken); ok {
 		identifier = tok.Literal()
 	} else {
-		p.addError("Identifier expected")
+		p.addError(diagnostic.IdentifierExpected, "Identifier expected")
 		identifier = "ERROR"
 	}
 
@@ -663,22 +1089,39 @@ func (p *Parser) newAbstractNodeWithPos(pos token.Position) node.AbstractNode {
 	}
 }
 
-func (p *Parser) newErrorNode(msg string) *node.ErrorNode {
-	p.addError(msg)
+func (p *Parser) newErrorNode(code diagnostic.Code, msg string) *node.ErrorNode {
+	p.addError(code, msg)
 	e := &node.ErrorNode{
 		AbstractNode: p.newAbstractNode(),
 		Message:      msg,
 	}
 
 	p.nextToken()
+	p.setEnd(e)
 	return e
 }
 
+// setEnd sets the end position of the node to the end of the last consumed token
+func (p *Parser) setEnd(n node.Node) {
+	n.SetEnd(p.lastEnd)
+}
+
 func (p *Parser) isEnd() bool {
 	return p.isSymbol(token.EOF)
 }
 
-func (p *Parser) addError(msg string) {
-	p.errors = append(p.errors,
-		fmt.Errorf("[%s] ERROR: %s", p.currentToken.Pos().String(), msg))
+// addError reports a syntax error at the current token
+// A new line token is located at the start of the next line. Therefore, the error is reported after the last token.
+func (p *Parser) addError(code diagnostic.Code, msg string) {
+	start, end := p.currentToken.Pos(), p.currentToken.End()
+	if p.isSymbol(token.NewLine) {
+		start = p.lastEnd
+		end = token.Position{Line: start.Line, Column: start.Column + 1}
+	}
+	p.errors = append(p.errors, diagnostic.New(code, start, end, msg))
+}
+
+// addErrorAt reports a syntax error spanning the given node
+func (p *Parser) addErrorAt(code diagnostic.Code, n node.Node, msg string) {
+	p.errors = append(p.errors, diagnostic.New(code, n.Pos(), n.End(), msg))
 }
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 )
@@ -26,6 +27,7 @@ func (p *Parser) parseTernaryExpression() node.ExpressionNode {
 		}
 		p.check(token.Colon)
 		ternary.Else = p.parseOr()
+		p.setEnd(ternary)
 		return ternary
 	}
 	return expr
@@ -42,6 +44,7 @@ func (p *Parser) parseOr() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseAnd(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -58,6 +61,7 @@ func (p *Parser) parseAnd() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseBitwiseOr(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -74,6 +78,7 @@ func (p *Parser) parseBitwiseOr() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseBitwiseXOr(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -90,6 +95,7 @@ func (p *Parser) parseBitwiseXOr() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseBitwiseAnd(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -106,6 +112,7 @@ func (p *Parser) parseBitwiseAnd() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseEquality(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -122,6 +129,7 @@ func (p *Parser) parseEquality() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseRelationalComparison(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -138,6 +146,7 @@ func (p *Parser) parseRelationalComparison() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseBitwiseShift(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -154,6 +163,7 @@ func (p *Parser) parseBitwiseShift() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseTerm(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -170,6 +180,7 @@ func (p *Parser) parseTerm() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseFactor(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -186,6 +197,7 @@ func (p *Parser) parseFactor() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseExponent(),
 		}
+		p.setEnd(binExpr)
 		leftExpr = binExpr
 	}
 	return leftExpr
@@ -202,6 +214,7 @@ func (p *Parser) parseExponent() node.ExpressionNode {
 			Operator:     p.readSymbol(),
 			Right:        p.parseExponent(), // recursive because of right-to-left associativity
 		}
+		p.setEnd(binExpr)
 		return binExpr
 	}
 	return leftExpr
@@ -219,8 +232,8 @@ func (p *Parser) parseExpressionRest() node.ExpressionNode {
 		p.check(token.CloseParen)
 
 		switch p.currentToken.Type() {
-		case token.IDENTIFER, token.CHARACTER, token.INTEGER:
-			// (String) x.y.z, (String) 'c', (String) 5
+		case token.IDENTIFER, token.CHARACTER, token.INTEGER, token.STRING:
+			// (String) x.y.z, (String) 'c', (String) 5, (int) "5"
 			return p.parseTypeCast(abstractNode, expr)
 		case token.SYMBOL:
 			// (String) true
@@ -235,11 +248,13 @@ func (p *Parser) parseExpressionRest() node.ExpressionNode {
 }
 
 func (p *Parser) parseUnaryExpression() *node.UnaryExpressionNode {
-	return &node.UnaryExpressionNode{
+	unary := &node.UnaryExpressionNode{
 		AbstractNode: p.newAbstractNode(),
 		Operator:     p.readSymbol(),
 		Expression:   p.parseFactor(),
 	}
+	p.setEnd(unary)
+	return unary
 }
 
 func (p *Parser) parseTypeCast(abstractNode node.AbstractNode, expr node.ExpressionNode) *node.TypeCastNode {
@@ -253,9 +268,11 @@ func (p *Parser) parseTypeCast(abstractNode node.AbstractNode, expr node.Express
 			AbstractNode: p.newAbstractNodeWithPos(expr.Pos()),
 			Identifier:   basicDesignator.Value,
 		}
+		typeCast.Type.SetEnd(expr.End())
 	} else {
-		p.addError(fmt.Sprintf("Invalid type %s", expr))
+		p.addErrorAt(diagnostic.InvalidTypeSyntax, expr, fmt.Sprintf("Invalid type %s", expr))
 	}
+	p.setEnd(typeCast)
 	return typeCast
 }
 
@@ -269,6 +286,8 @@ func (p *Parser) parseOperand() node.ExpressionNode {
 		return designator
 	case token.INTEGER:
 		return p.parseInteger()
+	case token.ADDRESS:
+		return p.parseAddress()
 	case token.CHARACTER:
 		return p.parseCharacter()
 	case token.STRING:
@@ -284,7 +303,7 @@ func (p *Parser) parseOperand() node.ExpressionNode {
 		panic("Unsupported token type: " + p.currentToken.Literal())
 	}
 
-	return p.newErrorNode(error)
+	return p.newErrorNode(diagnostic.InvalidToken, error)
 }
 
 func (p *Parser) parseOperandSymbol() node.ExpressionNode {
@@ -299,8 +318,10 @@ func (p *Parser) parseOperandSymbol() node.ExpressionNode {
 		return p.parseBoolean(tok)
 	case token.New:
 		return p.parseCreation()
+	case token.Contract:
+		return p.parseContractDesignator()
 	default:
-		return p.newErrorNode("Unsupported expression symbol " + p.currentToken.Literal())
+		return p.newErrorNode(diagnostic.SyntaxError, "Unsupported expression symbol "+p.currentToken.Literal())
 	}
 }
 
@@ -308,11 +329,20 @@ func (p *Parser) parseDesignator() node.DesignatorNode {
 	return p.parseDesignatorWithIdentifier(p.newAbstractNode(), p.readIdentifier())
 }
 
+// parseContractDesignator parses the built-in contract fields (e.g. contract.owner).
+// The keyword is used as identifier of the built-in namespace.
+func (p *Parser) parseContractDesignator() node.DesignatorNode {
+	abstractNode := p.newAbstractNode()
+	p.nextToken() // skip 'contract' keyword
+	return p.parseDesignatorWithIdentifier(abstractNode, token.SymbolLexeme[token.Contract])
+}
+
 func (p *Parser) parseDesignatorWithIdentifier(abstractNode node.AbstractNode, identifier string) node.DesignatorNode {
 	var left node.DesignatorNode = &node.BasicDesignatorNode{
 		AbstractNode: abstractNode,
 		Value:        identifier,
 	}
+	p.setEnd(left)
 
 	for p.isSymbol(token.Period) || p.isSymbol(token.OpenBracket) {
 		if p.isSymbol(token.Period) {
@@ -333,6 +363,7 @@ func (p *Parser) parseDesignatorWithIdentifier(abstractNode node.AbstractNode, i
 				Expression:   exp,
 			}
 		}
+		p.setEnd(left)
 	}
 	return left
 }
@@ -353,6 +384,7 @@ func (p *Parser) parseFuncCall(designator node.DesignatorNode) *node.FuncCallNod
 		isFirstArg = false
 	}
 	p.check(token.CloseParen)
+	p.setEnd(funcCall)
 	return funcCall
 }
 
@@ -367,7 +399,7 @@ func (p *Parser) parseCreation() node.ExpressionNode {
 		return p.parseArrayCreation(abstractNode, identifier)
 	}
 
-	return p.newErrorNode(fmt.Sprintf("Unsupported creation type with %s", p.currentToken.Literal()))
+	return p.newErrorNode(diagnostic.SyntaxError, fmt.Sprintf("Unsupported creation type with %s", p.currentToken.Literal()))
 }
 
 func (p *Parser) parseArrayCreation(abstractNode node.AbstractNode, identifier string) node.ExpressionNode {
@@ -379,11 +411,13 @@ func (p *Parser) parseArrayCreation(abstractNode node.AbstractNode, identifier s
 	// Initialization using values: new int[][]{{1, 2}, {3, 4}}
 	if p.peekIsSymbol(token.CloseBracket) {
 		arrayType = p.parseArrayType(arrayType)
-		return &node.ArrayValueCreationNode{
+		creation := &node.ArrayValueCreationNode{
 			AbstractNode: abstractNode,
 			Type:         arrayType,
 			Elements:     p.parseArrayInitialization(),
 		}
+		p.setEnd(creation)
+		return creation
 	}
 
 	// Initialization using Length: new int[2][3]
@@ -402,13 +436,16 @@ func (p *Parser) parseArrayCreation(abstractNode node.AbstractNode, identifier s
 			AbstractNode: p.newAbstractNodeWithPos(arrayType.Pos()),
 			ElementType:  arrayType,
 		}
+		p.setEnd(arrayType)
 	}
 
-	return &node.ArrayLengthCreationNode{
+	creation := &node.ArrayLengthCreationNode{
 		AbstractNode: abstractNode,
 		ElementType:  arrayType,
 		Lengths:      expressions,
 	}
+	p.setEnd(creation)
+	return creation
 }
 
 func (p *Parser) parseArrayInitialization() *node.ArrayInitializationNode {
@@ -423,10 +460,12 @@ func (p *Parser) parseArrayInitialization() *node.ArrayInitializationNode {
 		}
 		p.check(token.CloseBrace)
 
-		return &node.ArrayInitializationNode{
+		arrayInitialization := &node.ArrayInitializationNode{
 			AbstractNode: abstractNode,
 			Values:       expressions,
 		}
+		p.setEnd(arrayInitialization)
+		return arrayInitialization
 	}
 
 	arrayInitialization := &node.ArrayInitializationNode{
@@ -447,9 +486,10 @@ func (p *Parser) parseArrayInitialization() *node.ArrayInitializationNode {
 
 		arrayInitialization.Values = expressions
 	} else {
-		p.addError("Invalid array initialization")
+		p.addError(diagnostic.SyntaxError, "Invalid array initialization")
 	}
 
+	p.setEnd(arrayInitialization)
 	return arrayInitialization
 }
 
@@ -474,6 +514,7 @@ func (p *Parser) parseStructCreation(abstractNode node.AbstractNode, identifier
 		isFirstArg = false
 	}
 	p.check(token.CloseParen)
+	p.setEnd(structCreation)
 	return structCreation
 }
 
@@ -495,6 +536,7 @@ func (p *Parser) parseStructNamedCreation(abstractNode node.AbstractNode, identi
 		}
 		p.check(token.Assign)
 		field.Expression = p.parseExpression()
+		p.setEnd(field)
 		structCreation.FieldValues = append(structCreation.FieldValues, field)
 
 		p.skipNewLines()
@@ -502,6 +544,7 @@ func (p *Parser) parseStructNamedCreation(abstractNode node.AbstractNode, identi
 	}
 
 	p.check(token.CloseParen)
+	p.setEnd(structCreation)
 	return structCreation
 }
 
@@ -513,9 +556,22 @@ func (p *Parser) parseInteger() *node.IntegerLiteralNode {
 		Value:        tok.Value,
 	}
 	p.nextToken()
+	p.setEnd(i)
 	return i
 }
 
+func (p *Parser) parseAddress() *node.AddressLiteralNode {
+	tok, _ := p.currentToken.(*token.AddressToken)
+
+	a := &node.AddressLiteralNode{
+		AbstractNode: p.newAbstractNode(),
+		Value:        tok.Value,
+	}
+	p.nextToken()
+	p.setEnd(a)
+	return a
+}
+
 func (p *Parser) parseCharacter() *node.CharacterLiteralNode {
 	tok, _ := p.currentToken.(*token.CharacterToken)
 
@@ -524,6 +580,7 @@ func (p *Parser) parseCharacter() *node.CharacterLiteralNode {
 		Value:        tok.Value,
 	}
 	p.nextToken()
+	p.setEnd(c)
 	return c
 }
 
@@ -535,6 +592,7 @@ func (p *Parser) parseString() *node.StringLiteralNode {
 		Value:        tok.Literal(),
 	}
 	p.nextToken()
+	p.setEnd(s)
 	return s
 }
 
@@ -545,8 +603,9 @@ func (p *Parser) parseBoolean(tok *token.FixToken) node.ExpressionNode {
 			Value:        value,
 		}
 		p.nextToken()
+		p.setEnd(b)
 		return b
 	}
 
-	return p.newErrorNode("Invalid boolean value " + tok.Literal())
+	return p.newErrorNode(diagnostic.SyntaxError, "Invalid boolean value "+tok.Literal())
 }
@@ -1,10 +1,12 @@
 package parser
 
 import (
+	"encoding/hex"
 	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 	"gotest.tools/assert"
 	"math/big"
+	"strings"
 	"testing"
 )
 
@@ -16,6 +18,17 @@ func TestTernaryExpression(t *testing.T) {
 	assertTernaryExpression(t, e, "(x == y)", "true", "false")
 }
 
+func TestTernaryExpressionEnd(t *testing.T) {
+	e := parseExpressionFromInput(t, "x ? (a + b) * c : -1")
+	assertPosition(t, e.End(), 1, 21)
+
+	then := e.(*node.TernaryExpressionNode).Then.(*node.BinaryExpressionNode)
+	assertPosition(t, then.Pos(), 1, 5)
+	assertPosition(t, then.End(), 1, 16)
+	assertPosition(t, then.Left.Pos(), 1, 6)
+	assertPosition(t, then.Left.End(), 1, 11)
+}
+
 func TestNestedTernary(t *testing.T) {
 	p := newParserFromInput("x ? y ? 1 : 2 : z")
 	_ = p.parseExpression()
@@ -223,6 +236,11 @@ func TestBoolTypeCast(t *testing.T) {
 	assertTypeCast(t, e, "String", "true")
 }
 
+func TestStringTypeCast(t *testing.T) {
+	e := parseExpressionFromInput(t, `(int) "5"`)
+	assertTypeCast(t, e, "int", "5")
+}
+
 func TestDesignatorTypeCast(t *testing.T) {
 	e := parseExpressionFromInput(t, "(String) x")
 	assertTypeCast(t, e, "String", "x")
@@ -334,6 +352,14 @@ func TestFuncCallMissingComma(t *testing.T) {
 	assertErrorAt(t, p, 0, ", expected, but got 2")
 }
 
+func TestFuncCallEnd(t *testing.T) {
+	e := parseExpressionFromInput(t, "a.f(x, 'c')")
+	assertPosition(t, e.End(), 1, 12)
+
+	designator := e.(*node.FuncCallNode).Designator
+	assertPosition(t, designator.End(), 1, 4)
+}
+
 func TestFuncCallOnMember(t *testing.T) {
 	e := parseExpressionFromInput(t, "a.f()")
 	assertFuncCall(t, e, "a.f")
@@ -389,6 +415,17 @@ func TestMultipleMemberAccess(t *testing.T) {
 	assertMemberAccess(t, e, "a.x", "y")
 }
 
+func TestContractMemberAccess(t *testing.T) {
+	e := parseExpressionFromInput(t, "contract.balance")
+	assertMemberAccess(t, e, "contract", "balance")
+	assertPosition(t, e.End(), 1, 17)
+}
+
+func TestContractMemberAccessPrecedence(t *testing.T) {
+	e := parseExpressionFromInput(t, "msg.value > contract.balance")
+	assertBinaryExpression(t, e, "msg.value", "contract.balance", token.Greater)
+}
+
 func TestInvalidMemberAccess(t *testing.T) {
 	p := newParserFromInput("a.0")
 	p.parseExpression()
@@ -566,6 +603,25 @@ func TestInvalidHexLiteral(t *testing.T) {
 	assertError(t, e, "Error while parsing string to big int")
 }
 
+func TestAddressLiteral(t *testing.T) {
+	address := strings.Repeat("ab", 32)
+	p := newParserFromInput("@" + address)
+	a := p.parseOperand().(*node.AddressLiteralNode)
+
+	assert.Equal(t, hex.EncodeToString(a.Value), address)
+	assert.Equal(t, a.String(), "@"+address)
+	assertNoErrors(t, p)
+}
+
+func TestInvalidAddressLiteral(t *testing.T) {
+	p := newParserFromInput("@ab")
+	o := p.parseOperand()
+
+	assertHasError(t, p)
+	e := o.(*node.ErrorNode)
+	assertError(t, e, "Address literal must have 64 hex digits")
+}
+
 func TestStringLiteral(t *testing.T) {
 	p := newParserFromInput(`"test"`)
 	s := p.parseString()
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
 	"gotest.tools/assert"
@@ -15,7 +16,7 @@ func TestEmptyProgram(t *testing.T) {
 	program, _ := p.ParseProgram()
 
 	assertNoErrors(t, p)
-	assertProgram(t, program, false)
+	assertProgram(t, program, 0, 0)
 }
 
 func TestProgramWithNewlines(t *testing.T) {
@@ -44,8 +45,132 @@ func TestEmptyContract(t *testing.T) {
 	program, _ := p.ParseProgram()
 
 	assertNoErrors(t, p)
-	assertProgram(t, program, true)
-	assertContract(t, program.Contract, "Test", 0, 0)
+	assertProgram(t, program, 0, 1)
+	assertContract(t, program.Contracts[0], "Test", 0, 0)
+}
+
+func TestMultipleContracts(t *testing.T) {
+	p := newParserFromInput(`
+		contract A {
+		}
+
+		contract B {
+		}
+	`)
+	program, _ := p.ParseProgram()
+
+	assertNoErrors(t, p)
+	assertProgram(t, program, 0, 2)
+	assertContract(t, program.Contracts[0], "A", 0, 0)
+	assertContract(t, program.Contracts[1], "B", 0, 0)
+}
+
+func TestContractImplements(t *testing.T) {
+	p := newParserFromInput(`contract Token implements IToken, IOwned {
+	}`)
+	c := p.parseContract()
+
+	assertNoErrors(t, p)
+	assertContract(t, c, "Token", 0, 0)
+	assert.Equal(t, len(c.Implements), 2)
+	assert.Equal(t, c.Implements[0].Identifier, "IToken")
+	assert.Equal(t, c.Implements[1].Identifier, "IOwned")
+	assert.Equal(t, c.Implements[0].Pos().String(), "1:27")
+	assert.Equal(t, c.Implements[0].End().String(), "1:33")
+}
+
+func TestContractImplementsMissingInterface(t *testing.T) {
+	p := newParserFromInput(`contract Token implements {
+	}`)
+	_ = p.parseContract()
+
+	assertErrorSpan(t, p, 0, diagnostic.IdentifierExpected, "1:27", "1:28")
+}
+
+// Interface Nodes
+// ---------------
+
+func TestInterfaceDeclaration(t *testing.T) {
+	p := newParserFromInput(`interface IToken {
+		function int balanceOf(address owner)
+		function (bool, int) transfer(address to, int amount)
+		function void reset()
+	}`)
+	i := p.parseInterface()
+
+	assertNoErrors(t, p)
+	assertInterface(t, i, "IToken", 3)
+	assertFunction(t, i.Functions[0], "balanceOf", 1, 1, 0)
+	assertFunction(t, i.Functions[1], "transfer", 2, 2, 0)
+	assertFunction(t, i.Functions[2], "reset", 1, 0, 0)
+	assert.Equal(t, i.Functions[0].Visibility, token.Public)
+
+	// Positions
+	assert.Equal(t, i.Pos().String(), "1:1")
+	assert.Equal(t, i.End().String(), "5:3")
+	assert.Equal(t, i.Functions[0].Pos().String(), "2:3")
+	assert.Equal(t, i.Functions[0].End().String(), "2:40")
+}
+
+func TestEmptyInterface(t *testing.T) {
+	p := newParserFromInput(`interface IEmpty {
+	}`)
+	i := p.parseInterface()
+
+	assertNoErrors(t, p)
+	assertInterface(t, i, "IEmpty", 0)
+}
+
+func TestInterfaceWithFunctionBody(t *testing.T) {
+	p := newParserFromInput(`interface IToken {
+		function void reset() {
+		}
+	}`)
+	_ = p.parseInterface()
+
+	assertHasError(t, p)
+}
+
+func TestInterfaceWithField(t *testing.T) {
+	p := newParserFromInput(`interface IToken {
+		int x
+	}`)
+	_ = p.parseInterface()
+
+	assertErrorSpan(t, p, 0, diagnostic.SyntaxError, "2:3", "2:6")
+}
+
+func TestProgramWithInterfacesAndContracts(t *testing.T) {
+	p := newParserFromInput(`
+		interface IToken {
+			function int balanceOf(address owner)
+		}
+
+		contract Token implements IToken {
+		}
+
+		contract Other {
+		}
+	`)
+	program, _ := p.ParseProgram()
+
+	assertNoErrors(t, p)
+	assertProgram(t, program, 1, 2)
+	assertInterface(t, program.Interfaces[0], "IToken", 1)
+	assertContract(t, program.Contracts[0], "Token", 0, 0)
+	assertContract(t, program.Contracts[1], "Other", 0, 0)
+}
+
+func TestInvalidTokenBetweenContracts(t *testing.T) {
+	p := newParserFromInput(`
+		contract A {
+		}
+		int x
+	`)
+	program, _ := p.ParseProgram()
+
+	assertErrorSpan(t, p, 0, diagnostic.SyntaxError, "4:3", "4:6")
+	assertProgram(t, program, 0, 1)
 }
 
 func TestContractWithVariable(t *testing.T) {
@@ -79,6 +204,32 @@ func TestContractWithFunction(t *testing.T) {
 	assertFunction(t, c.Functions[0], "test", 1, 0, 0)
 }
 
+func TestContractWithComments(t *testing.T) {
+	p := newParserFromInput(`// License header
+	/* Test contract */
+	contract Test {
+		/// Number of likes
+		int x // inline comment
+
+		int y
+
+		/// Increments x
+		/// by one
+		function void test() {
+			x++ /* block comment */
+		}
+	}`)
+	c := p.parseContract()
+
+	assertNoErrors(t, p)
+	assertContract(t, c, "Test", 2, 1)
+	assert.Equal(t, c.Fields[0].Doc, "Number of likes")
+	assert.Equal(t, c.Fields[1].Doc, "")
+	assert.Equal(t, c.Functions[0].Doc, "Increments x\nby one")
+	assertStatementBlock(t, c.Functions[0].Body, 1)
+	assert.Equal(t, c.Fields[1].Pos().String(), "7:3")
+}
+
 // Field Nodes
 // --------------
 
@@ -104,6 +255,39 @@ func TestFieldAssignment(t *testing.T) {
 	assertNoErrors(t, p)
 }
 
+// Constant Nodes
+// --------------
+
+func TestConstant(t *testing.T) {
+	p := newParserFromInput("const int FEE = 2 * 500\n")
+	c := p.parseConstant()
+
+	assertNoErrors(t, p)
+	assertType(t, c.Type, "int")
+	assert.Equal(t, c.Identifier, "FEE")
+	assertBinaryExpression(t, c.Expression, "2", "500", token.Multiplication)
+	assertPosition(t, c.End(), 1, 24)
+}
+
+func TestConstantWithoutValue(t *testing.T) {
+	p := newParserFromInput("const int FEE\n")
+	_ = p.parseConstant()
+	assertErrorAt(t, p, 0, "Symbol = expected")
+}
+
+func TestContractWithConstant(t *testing.T) {
+	p := newParserFromInput(`contract Test {
+		const String NAME = "Test"
+		int x = 5
+	}`)
+	c := p.parseContract()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, len(c.Constants), 1)
+	assert.Equal(t, c.Constants[0].Identifier, "NAME")
+	assert.Equal(t, len(c.Fields), 1)
+}
+
 // Struct Declaration nodes
 // -------------------------
 
@@ -170,6 +354,189 @@ func TestStructMissingNewlineAtEnd(t *testing.T) {
 	assertErrorAt(t, p, 0, "Symbol \\n expected, but got EOF")
 }
 
+// Enum Declaration nodes
+// -----------------------
+
+func TestEnumDeclaration(t *testing.T) {
+	p := newParserFromInput("enum State { Open, Closed }\n")
+	e := p.parseEnum()
+
+	assertNoErrors(t, p)
+	assertEnum(t, e, "State", "Open", "Closed")
+	assertPosition(t, e.Pos(), 1, 1)
+	assertPosition(t, e.End(), 1, 28)
+	assertPosition(t, e.Members[1].Pos(), 1, 20)
+}
+
+func TestMultiLineEnumDeclaration(t *testing.T) {
+	p := newParserFromInput(`enum State {
+			Open,
+			Closed,
+			Cancelled
+		}
+	`)
+	e := p.parseEnum()
+
+	assertNoErrors(t, p)
+	assertEnum(t, e, "State", "Open", "Closed", "Cancelled")
+}
+
+func TestEmptyEnumDeclaration(t *testing.T) {
+	p := newParserFromInput("enum State {}\n")
+	e := p.parseEnum()
+
+	assertNoErrors(t, p)
+	assertEnum(t, e, "State")
+}
+
+func TestEnumMissingComma(t *testing.T) {
+	p := newParserFromInput("enum State { Open Closed }\n")
+	_ = p.parseEnum()
+	assertErrorAt(t, p, 0, "Symbol , expected, but got Closed")
+}
+
+func TestEnumInvalidMember(t *testing.T) {
+	p := newParserFromInput("enum State { Open, 1 }\n")
+	_ = p.parseEnum()
+	assertErrorAt(t, p, 0, "Identifier expected")
+}
+
+func TestContractWithEnum(t *testing.T) {
+	p := newParserFromInput(`contract Test {
+		enum State { Open, Closed }
+		State state
+	}`)
+	c := p.parseContract()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, len(c.Enums), 1)
+	assertEnum(t, c.Enums[0], "State", "Open", "Closed")
+	assertField(t, c.Fields[0], "State", "state", "")
+}
+
+// Event Nodes
+// -----------
+
+func TestEventDeclaration(t *testing.T) {
+	p := newParserFromInput(`event Transfer(address from, address to, int amount)
+	`)
+	e := p.parseEvent()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, e.Name, "Transfer")
+	assert.Equal(t, len(e.Parameters), 3)
+	assertType(t, e.Parameters[0].Type, "address")
+	assert.Equal(t, e.Parameters[0].Identifier, "from")
+	assertType(t, e.Parameters[2].Type, "int")
+	assert.Equal(t, e.Parameters[2].Identifier, "amount")
+	assertPosition(t, e.End(), 1, 53)
+}
+
+func TestEventWithoutParameters(t *testing.T) {
+	p := newParserFromInput(`event Opened()
+	`)
+	e := p.parseEvent()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, e.Name, "Opened")
+	assert.Equal(t, len(e.Parameters), 0)
+}
+
+func TestEventMissingNewline(t *testing.T) {
+	p := newParserFromInput(`event Opened() int x`)
+	_ = p.parseEvent()
+	assertErrorAt(t, p, 0, "Symbol \\n expected, but got int")
+}
+
+func TestContractWithEvent(t *testing.T) {
+	p := newParserFromInput(`contract Test {
+		event Opened(int x)
+
+		function void test() {
+			emit Opened(1)
+		}
+	}`)
+	c := p.parseContract()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, len(c.Events), 1)
+	assert.Equal(t, c.Events[0].Name, "Opened")
+	assertFunction(t, c.Functions[0], "test", 1, 0, 1)
+}
+
+// Modifier Nodes
+// --------------
+
+func TestModifierDeclaration(t *testing.T) {
+	p := newParserFromInput(`modifier minAmount(int amount) {
+		require(amount > 0, "Invalid amount")
+		_
+	}
+	`)
+	m := p.parseModifier()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, m.Name, "minAmount")
+	assert.Equal(t, len(m.Parameters), 1)
+	assertType(t, m.Parameters[0].Type, "int")
+	assert.Equal(t, m.Parameters[0].Identifier, "amount")
+	assertStatementBlock(t, m.Body, 2)
+	_, ok := m.Body[1].(*node.PlaceholderStatementNode)
+	assert.Assert(t, ok)
+	assertPosition(t, m.End(), 4, 3)
+}
+
+func TestModifierWithoutParentheses(t *testing.T) {
+	p := newParserFromInput("modifier onlyOwner {\n_\n}\n")
+	m := p.parseModifier()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, m.Name, "onlyOwner")
+	assert.Equal(t, len(m.Parameters), 0)
+	assertStatementBlock(t, m.Body, 1)
+}
+
+func TestFunctionWithModifiers(t *testing.T) {
+	p := newParserFromInput("function void test(int x) private onlyOwner minAmount(x, 5) payable {\n}\n")
+	f := p.parseFunction()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, f.Visibility, token.Private)
+	assert.Equal(t, f.Payable, true)
+	assert.Equal(t, len(f.Modifiers), 2)
+	assertDesignator(t, f.Modifiers[0].Modifier, "onlyOwner")
+	assert.Equal(t, len(f.Modifiers[0].Args), 0)
+	assertDesignator(t, f.Modifiers[1].Modifier, "minAmount")
+	assert.Equal(t, len(f.Modifiers[1].Args), 2)
+	assertExpression(t, f.Modifiers[1].Args[0], "x")
+	assertPosition(t, f.Modifiers[1].End(), 1, 60)
+}
+
+func TestContractWithModifier(t *testing.T) {
+	p := newParserFromInput(`contract Test {
+		modifier onlyOwner() {
+			_
+		}
+
+		function void test() onlyOwner {
+		}
+	}`)
+	c := p.parseContract()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, len(c.Modifiers), 1)
+	assert.Equal(t, c.Modifiers[0].Name, "onlyOwner")
+	assert.Equal(t, len(c.Functions[0].Modifiers), 1)
+}
+
+func TestPlaceholderStatement(t *testing.T) {
+	p := newParserFromInput("_\n")
+	_, ok := p.parseStatement().(*node.PlaceholderStatementNode)
+
+	assert.Assert(t, ok)
+	assertNoErrors(t, p)
+}
+
 // Array Nodes
 // -----------
 
@@ -276,6 +643,32 @@ func TestMapDeclaration(t *testing.T) {
 	assertNoErrors(t, p)
 }
 
+func TestNestedMapDeclaration(t *testing.T) {
+	p := newParserFromInput("Map<int, Map<int, int>> map \n")
+	f := p.parseField()
+
+	assertField(t, f, "Map<int,Map<int,int>>", "map", "")
+	assert.Equal(t, f.Type.(*node.MapTypeNode).ValueType.End().String(), "1:23")
+	assert.Equal(t, f.Type.End().String(), "1:24")
+	assertNoErrors(t, p)
+}
+
+func TestNestedMapDeclarationWithSpace(t *testing.T) {
+	p := newParserFromInput("Map<int, Map<int, int[]> > map \n")
+	f := p.parseField()
+
+	assertField(t, f, "Map<int,Map<int,int[]>>", "map", "")
+	assertNoErrors(t, p)
+}
+
+func TestMapOfArrayDeclaration(t *testing.T) {
+	p := newParserFromInput("Map<address, int[]> map \n")
+	f := p.parseField()
+
+	assertField(t, f, "Map<address,int[]>", "map", "")
+	assertNoErrors(t, p)
+}
+
 func TestInvalidMapDeclaration(t *testing.T) {
 	p := newParserFromInput("Map String, int> map \n")
 	_ = p.parseMapType()
@@ -354,6 +747,22 @@ func TestConstructorWithParamsAndStatements(t *testing.T) {
 	assertNoErrors(t, p)
 }
 
+func TestPayableConstructor(t *testing.T) {
+	p := newParserFromInput("constructor(int a) payable { \n } \n")
+	c := p.parseConstructor()
+
+	assertConstructor(t, c, 1, 0)
+	assert.Equal(t, c.Payable, true)
+	assertNoErrors(t, p)
+}
+
+func TestConstructorWithVisibility(t *testing.T) {
+	p := newParserFromInput("constructor() private { \n } \n")
+	p.parseConstructor()
+
+	assertErrorAt(t, p, 0, "Symbol { expected, but got private")
+}
+
 func TestMultipleConstructors(t *testing.T) {
 	p := newParserFromInput(`contract Test {
 		constructor(int a) {
@@ -451,6 +860,69 @@ func TestFunctionMissingParamComma(t *testing.T) {
 	assertHasError(t, p)
 }
 
+func TestFunctionDefaultVisibility(t *testing.T) {
+	p := newParserFromInput("function void test(){\n}\n")
+	f := p.parseFunction()
+	assert.Equal(t, f.Visibility, token.Public)
+	assertNoErrors(t, p)
+}
+
+func TestFunctionVisibility(t *testing.T) {
+	for input, visibility := range map[string]token.Symbol{
+		"public":   token.Public,
+		"private":  token.Private,
+		"internal": token.Internal,
+	} {
+		p := newParserFromInput("function void test(int a) " + input + " {\n}\n")
+		f := p.parseFunction()
+		assertFunction(t, f, "test", 1, 1, 0)
+		assert.Equal(t, f.Visibility, visibility)
+		assertNoErrors(t, p)
+	}
+}
+
+func TestFunctionDuplicateVisibility(t *testing.T) {
+	p := newParserFromInput("function void test() private public {\n}\n")
+	f := p.parseFunction()
+	assert.Equal(t, f.Visibility, token.Public)
+	assertErrorAt(t, p, 0, "Only one visibility modifier is allowed")
+}
+
+func TestPayableFunction(t *testing.T) {
+	p := newParserFromInput("function void test() payable {\n}\n")
+	f := p.parseFunction()
+	assert.Equal(t, f.Payable, true)
+	assert.Equal(t, f.Visibility, token.Public)
+	assertNoErrors(t, p)
+}
+
+func TestPayableFunctionWithVisibility(t *testing.T) {
+	p := newParserFromInput("function void test() payable public {\n}\n")
+	f := p.parseFunction()
+	assert.Equal(t, f.Payable, true)
+	assert.Equal(t, f.Visibility, token.Public)
+	assertNoErrors(t, p)
+
+	p = newParserFromInput("function void test() private payable {\n}\n")
+	f = p.parseFunction()
+	assert.Equal(t, f.Payable, true)
+	assert.Equal(t, f.Visibility, token.Private)
+	assertNoErrors(t, p)
+}
+
+func TestFunctionDuplicatePayable(t *testing.T) {
+	p := newParserFromInput("function void test() payable payable {\n}\n")
+	f := p.parseFunction()
+	assert.Equal(t, f.Payable, true)
+	assertErrorAt(t, p, 0, "Only one payable modifier is allowed")
+}
+
+func TestFunctionVisibilityBeforeParams(t *testing.T) {
+	p := newParserFromInput("function void private test() {\n}\n")
+	p.parseFunction()
+	assertHasError(t, p)
+}
+
 // Statement Nodes
 // ---------------
 
@@ -679,6 +1151,63 @@ func TestIfStatementWOElseWONewline(t *testing.T) {
 	assertHasError(t, p)
 }
 
+// Loop Statements
+// ---------------
+
+func TestForStatement(t *testing.T) {
+	p := newParserFromInput("for (int i : 0..n) {\nx += i\n}\n")
+	v := p.parseForStatement()
+
+	assertNoErrors(t, p)
+	assertVariableStatement(t, v.Variable, "int", "i", "")
+	assertExpression(t, v.From, "0")
+	assertExpression(t, v.To, "n")
+	assertStatementBlock(t, v.Body, 1)
+}
+
+func TestForStatementWithExpressions(t *testing.T) {
+	p := newParserFromInput("for (int i : x + 1..arr.length) {\n}\n")
+	v := p.parseForStatement()
+
+	assertNoErrors(t, p)
+	assertBinaryExpression(t, v.From, "x", "1", token.Plus)
+	assertMemberAccess(t, v.To, "arr", "length")
+}
+
+func TestForStatementMissingRange(t *testing.T) {
+	p := newParserFromInput("for (int i : 10) {\n}\n")
+	_ = p.parseForStatement()
+
+	assertErrorAt(t, p, 0, "Symbol .. expected, but got )")
+}
+
+func TestForEachStatement(t *testing.T) {
+	p := newParserFromInput("foreach (x in arr) {\nsum += x\n}\n")
+	v := p.parseForEachStatement()
+
+	assertNoErrors(t, p)
+	assert.Equal(t, v.Variable.Type, nil)
+	assert.Equal(t, v.Variable.Identifier, "x")
+	assertExpression(t, v.Collection, "arr")
+	assertStatementBlock(t, v.Body, 1)
+}
+
+func TestForEachStatementWithType(t *testing.T) {
+	p := newParserFromInput("foreach (Person p in this.people) {\n}\n")
+	v := p.parseForEachStatement()
+
+	assertNoErrors(t, p)
+	assertVariableStatement(t, v.Variable, "Person", "p", "")
+	assertMemberAccess(t, v.Collection, "this", "people")
+}
+
+func TestForEachStatementMissingIn(t *testing.T) {
+	p := newParserFromInput("foreach (x : arr) {\n}\n")
+	_ = p.parseForEachStatement()
+
+	assertHasError(t, p)
+}
+
 // Function Call Statements
 // ------------------------
 
@@ -712,6 +1241,15 @@ func TestAssignmentStatement(t *testing.T) {
 	assertNoErrors(t, p)
 }
 
+func TestContractMemberAssignmentStatement(t *testing.T) {
+	p := newParserFromInput("contract.balance = 5\n")
+	a := p.parseStatement().(*node.AssignmentStatementNode)
+
+	assertAssignmentStatement(t, a, "contract.balance", "5")
+	assertPosition(t, a.Position, 1, 1)
+	assertNoErrors(t, p)
+}
+
 func TestAssignmentStatementChar(t *testing.T) {
 	p := newParserFromInput("a = 'c'\n")
 	s := p.parseStatementWithIdentifier()
@@ -831,7 +1369,7 @@ func TestPostfixIncrementError2(t *testing.T) {
 }
 
 func TestPostfixIncrementError3(t *testing.T) {
-	p := newParserFromInput("x// \n")
+	p := newParserFromInput("x/ / \n")
 	_, ok := p.parseStatement().(*node.ShorthandAssignmentStatementNode)
 
 	assert.Assert(t, ok)
@@ -937,6 +1475,43 @@ func TestStatementWithIdentifierAssignment(t *testing.T) {
 	assertNoErrors(t, p)
 }
 
+// Source Spans
+// ------------
+
+func TestStatementEnd(t *testing.T) {
+	p := newParserFromInput("int x = a + \"b\" \n")
+	v := p.parseStatement().(*node.VariableNode)
+
+	assertNoErrors(t, p)
+	assertPosition(t, v.End(), 1, 16)
+	assertPosition(t, v.Type.End(), 1, 4)
+	assertPosition(t, v.Expression.Pos(), 1, 9)
+	assertPosition(t, v.Expression.End(), 1, 16)
+}
+
+func TestIfStatementEnd(t *testing.T) {
+	p := newParserFromInput("if (x) {\n y = 1 \n} else {\n y = 2\n}\n")
+	s := p.parseStatement().(*node.IfStatementNode)
+
+	assertNoErrors(t, p)
+	assertPosition(t, s.End(), 5, 2)
+	assertPosition(t, s.Then[0].End(), 2, 7)
+}
+
+func TestSyntaxErrorAtNewLine(t *testing.T) {
+	p := newParserFromInput("x = 5 +\n")
+	_ = p.parseStatement()
+
+	assertErrorSpan(t, p, 0, diagnostic.SyntaxError, "1:8", "1:9")
+}
+
+func TestIdentifierExpectedSpan(t *testing.T) {
+	p := newParserFromInput("int 123 \n")
+	_ = p.parseVariableStatement()
+
+	assertErrorSpan(t, p, 0, diagnostic.IdentifierExpected, "1:5", "1:8")
+}
+
 // Delete Statement
 // ----------------
 
@@ -955,6 +1530,73 @@ func TestInvalidDeleteStatement(t *testing.T) {
 
 	assert.Assert(t, ok)
 	assertErrorAt(t, p, 0, "delete requires element access expression")
+	assertErrorSpan(t, p, 0, diagnostic.InvalidDelete, "1:8", "1:15")
+}
+
+func TestRequireStatement(t *testing.T) {
+	p := newParserFromInput("require(x > 0, \"x must be positive\") \n")
+	r, ok := p.parseStatement().(*node.RequireStatementNode)
+
+	assert.Assert(t, ok)
+	assertBinaryExpression(t, r.Condition, "x", "0", token.Greater)
+	assertStringLiteral(t, r.Message.(*node.StringLiteralNode), "x must be positive")
+	assertPosition(t, r.End(), 1, 37)
+	assertNoErrors(t, p)
+}
+
+func TestRequireStatementWithoutMessage(t *testing.T) {
+	p := newParserFromInput("require(x > 0) \n")
+	p.parseStatement()
+
+	assertErrorAt(t, p, 0, "Symbol , expected")
+}
+
+func TestAssertStatement(t *testing.T) {
+	p := newParserFromInput("assert(x) \n")
+	a, ok := p.parseStatement().(*node.AssertStatementNode)
+
+	assert.Assert(t, ok)
+	assertExpression(t, a.Condition, "x")
+	assertNoErrors(t, p)
+}
+
+func TestRevertStatement(t *testing.T) {
+	p := newParserFromInput("revert(\"failed\") \n")
+	r, ok := p.parseStatement().(*node.RevertStatementNode)
+
+	assert.Assert(t, ok)
+	assertStringLiteral(t, r.Message.(*node.StringLiteralNode), "failed")
+	assertNoErrors(t, p)
+}
+
+func TestEmitStatement(t *testing.T) {
+	p := newParserFromInput("emit Transfer(a, b, 5) \n")
+	e, ok := p.parseStatement().(*node.EmitStatementNode)
+
+	assert.Assert(t, ok)
+	assertDesignator(t, e.Event, "Transfer")
+	assert.Equal(t, len(e.Args), 3)
+	assertExpression(t, e.Args[0], "a")
+	assertExpression(t, e.Args[2], "5")
+	assertPosition(t, e.End(), 1, 23)
+	assertNoErrors(t, p)
+}
+
+func TestEmitStatementWithoutArguments(t *testing.T) {
+	p := newParserFromInput("emit Opened() \n")
+	e, ok := p.parseStatement().(*node.EmitStatementNode)
+
+	assert.Assert(t, ok)
+	assertDesignator(t, e.Event, "Opened")
+	assert.Equal(t, len(e.Args), 0)
+	assertNoErrors(t, p)
+}
+
+func TestEmitStatementWithoutParentheses(t *testing.T) {
+	p := newParserFromInput("emit Opened \n")
+	p.parseStatement()
+
+	assertErrorAt(t, p, 0, "Symbol ( expected")
 }
 
 // Type Nodes
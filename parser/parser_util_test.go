@@ -2,6 +2,7 @@ package parser
 
 import (
 	"bufio"
+	"github.com/bazo-blockchain/lazo/diagnostic"
 	"github.com/bazo-blockchain/lazo/lexer"
 	"github.com/bazo-blockchain/lazo/lexer/token"
 	"github.com/bazo-blockchain/lazo/parser/node"
@@ -32,14 +33,30 @@ func assertErrorAt(t *testing.T, p *Parser, index int, errSubStr string) {
 	assert.Assert(t, strings.Contains(err, errSubStr), err)
 }
 
+func assertErrorSpan(t *testing.T, p *Parser, index int, code diagnostic.Code, start string, end string) {
+	assert.Assert(t, len(p.errors) > index)
+	d, ok := p.errors[index].(*diagnostic.Diagnostic)
+	assert.Assert(t, ok)
+	assert.Equal(t, d.Code, code)
+	assert.Equal(t, d.Start.String(), start)
+	assert.Equal(t, d.End.String(), end)
+}
+
 func assertPosition(t *testing.T, actualPos token.Position, line int, col int) {
 	assert.Equal(t, actualPos.Line, line)
 	assert.Equal(t, actualPos.Column, col)
 }
 
-func assertProgram(t *testing.T, node *node.ProgramNode, hasContract bool) {
-	assert.Equal(t, node.Contract != nil, hasContract)
+func assertProgram(t *testing.T, node *node.ProgramNode, totalInterfaces int, totalContracts int) {
+	assert.Equal(t, len(node.Interfaces), totalInterfaces)
+	assert.Equal(t, len(node.Contracts), totalContracts)
 }
+
+func assertInterface(t *testing.T, node *node.InterfaceNode, name string, totalFunctions int) {
+	assert.Equal(t, node.Name, name)
+	assert.Equal(t, len(node.Functions), totalFunctions)
+}
+
 func assertContract(t *testing.T, node *node.ContractNode, name string, totalVars int, totalFunctions int) {
 	assert.Equal(t, node.Name, name)
 	assert.Equal(t, len(node.Fields), totalVars)
@@ -59,6 +76,14 @@ func assertStruct(t *testing.T, node *node.StructNode, name string, totalFields
 	assert.Equal(t, len(node.Fields), totalFields)
 }
 
+func assertEnum(t *testing.T, node *node.EnumNode, name string, members ...string) {
+	assert.Equal(t, node.Name, name)
+	assert.Equal(t, len(node.Members), len(members))
+	for i, member := range members {
+		assert.Equal(t, node.Members[i].Identifier, member)
+	}
+}
+
 func assertStructField(t *testing.T, node *node.StructFieldNode, varType string, id string) {
 	assert.Equal(t, node.Type.String(), varType)
 	assert.Equal(t, node.Identifier, id)
@@ -0,0 +1,3 @@
+// Package runner executes compiled contracts on the Bazo VM. It encodes the call arguments, decodes the return values
+// and keeps the contract variables between executions.
+package runner
@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"strings"
+)
+
+// Event is a decoded event, which has been emitted by a transaction
+type Event struct {
+	Name string
+	Args []string
+}
+
+func (e *Event) String() string {
+	return fmt.Sprintf("event %s(%s)", e.Name, strings.Join(e.Args, ", "))
+}
+
+// decodeEvents returns the events of the event log. The event log is an array of events.
+// Every event is an array, which contains the event hash followed by the arguments.
+func decodeEvents(contract *data.ContractData, log []byte) ([]*Event, error) {
+	records, err := readElements(arrayTag, log, 1)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event log: %s", err)
+	}
+
+	var events []*Event
+	for _, record := range records {
+		elements, err := readElements(arrayTag, record, 1)
+		if err != nil || len(elements) == 0 {
+			return nil, fmt.Errorf("invalid event %x", record)
+		}
+
+		eventData := findEvent(contract, elements[0])
+		if eventData == nil || len(eventData.Parameters) != len(elements)-1 {
+			return nil, fmt.Errorf("unknown event %x", elements[0])
+		}
+
+		event := &Event{Name: eventData.Identifier}
+		for i, param := range eventData.Parameters {
+			value := decodeContractValue(contract, param.Type, elements[i+1])
+			event.Args = append(event.Args, fmt.Sprintf("%s: %s", param.Identifier, value.Value))
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func findEvent(contract *data.ContractData, hash []byte) *data.EventData {
+	for _, event := range contract.Events {
+		if bytes.Equal(event.Hash[:], hash) {
+			return event
+		}
+	}
+	return nil
+}
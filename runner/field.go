@@ -0,0 +1,121 @@
+package runner
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"strconv"
+	"strings"
+)
+
+// Type tags of the collections in the Bazo VM
+const (
+	mapTag   = 0x01
+	arrayTag = 0x02
+)
+
+// DecodeField returns the readable value of a contract variable according to its declared type.
+// Arrays, maps and structs are decoded recursively and enum values are written as member names.
+// Values, which cannot be decoded, are hex encoded.
+func DecodeField(fieldType symbol.TypeSymbol, bytes []byte) string {
+	if len(bytes) == 0 && fieldType.Identifier() != "String" {
+		return "<uninitialized>"
+	}
+
+	var value string
+	var err error
+	switch t := fieldType.(type) {
+	case *symbol.ArrayTypeSymbol:
+		value, err = decodeArray(t, bytes)
+	case *symbol.MapTypeSymbol:
+		value, err = decodeMap(t, bytes)
+	case *symbol.StructTypeSymbol:
+		value, err = decodeStruct(t, bytes)
+	case *symbol.EnumTypeSymbol:
+		value, err = decodeEnum(t, bytes)
+	default:
+		value = DecodeValue(fieldType.Identifier(), bytes).Value
+	}
+
+	if err != nil {
+		return "0x" + hex.EncodeToString(bytes)
+	}
+	return value
+}
+
+func decodeArray(arrayType *symbol.ArrayTypeSymbol, bytes []byte) (string, error) {
+	elements, err := readElements(arrayTag, bytes, 1)
+	if err != nil {
+		return "", err
+	}
+
+	values := make([]string, len(elements))
+	for i, element := range elements {
+		values[i] = DecodeField(arrayType.ElementType, element)
+	}
+	return "[" + strings.Join(values, ", ") + "]", nil
+}
+
+func decodeMap(mapType *symbol.MapTypeSymbol, bytes []byte) (string, error) {
+	elements, err := readElements(mapTag, bytes, 2)
+	if err != nil {
+		return "", err
+	}
+
+	var values []string
+	for i := 0; i < len(elements); i += 2 {
+		values = append(values, fmt.Sprintf("%s: %s",
+			DecodeField(mapType.KeyType, elements[i]), DecodeField(mapType.ValueType, elements[i+1])))
+	}
+	return "{" + strings.Join(values, ", ") + "}", nil
+}
+
+func decodeStruct(structType *symbol.StructTypeSymbol, bytes []byte) (string, error) {
+	elements, err := readElements(arrayTag, bytes, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(elements) != len(structType.Fields) {
+		return "", errors.New("struct fields do not match")
+	}
+
+	values := make([]string, len(elements))
+	for i, field := range structType.Fields {
+		values[i] = fmt.Sprintf("%s: %s", field.Identifier(), DecodeField(field.Type, elements[i]))
+	}
+	return structType.Identifier() + "{" + strings.Join(values, ", ") + "}", nil
+}
+
+func decodeEnum(enumType *symbol.EnumTypeSymbol, bytes []byte) (string, error) {
+	index, err := strconv.Atoi(DecodeValue("int", bytes).Value)
+	if err != nil || index < 0 || index >= len(enumType.Members) {
+		return "", errors.New("invalid enum member")
+	}
+	return enumType.Members[index].Identifier(), nil
+}
+
+// readElements returns the elements of a VM collection: [tag, size (2 bytes), (length (2 bytes), bytes...)*].
+// A map entry consists of two elements, the key and the value.
+func readElements(tag byte, bytes []byte, elementsPerEntry int) ([][]byte, error) {
+	if len(bytes) < 3 || bytes[0] != tag {
+		return nil, errors.New("invalid collection")
+	}
+
+	size := int(binary.BigEndian.Uint16(bytes[1:3])) * elementsPerEntry
+	var elements [][]byte
+	for pos := 3; len(elements) < size; {
+		if pos+2 > len(bytes) {
+			return nil, errors.New("invalid collection element")
+		}
+		length := int(binary.BigEndian.Uint16(bytes[pos : pos+2]))
+		pos += 2
+		if pos+length > len(bytes) {
+			return nil, errors.New("invalid collection element")
+		}
+		elements = append(elements, bytes[pos:pos+length])
+		pos += length
+	}
+	return elements, nil
+}
@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"gotest.tools/assert"
+	"testing"
+)
+
+var intType = symbol.NewBasicTypeSymbol(nil, "int")
+var stringType = symbol.NewBasicTypeSymbol(nil, "String")
+
+func TestDecodeBasicField(t *testing.T) {
+	assert.Equal(t, DecodeField(intType, []byte{1, 5}), "-5")
+	assert.Equal(t, DecodeField(intType, nil), "<uninitialized>")
+	assert.Equal(t, DecodeField(stringType, nil), `""`)
+}
+
+func TestDecodeArrayField(t *testing.T) {
+	arrayType := symbol.NewArrayTypeSymbol(nil, stringType)
+	assert.Equal(t, DecodeField(arrayType, []byte{2, 0, 2, 0, 1, 'a', 0, 0}), `["a", ""]`)
+	assert.Equal(t, DecodeField(arrayType, []byte{2, 0, 0}), "[]")
+}
+
+func TestDecodeNestedArrayField(t *testing.T) {
+	arrayType := symbol.NewArrayTypeSymbol(nil, symbol.NewArrayTypeSymbol(nil, intType))
+	assert.Equal(t, DecodeField(arrayType, []byte{2, 0, 1, 0, 7, 2, 0, 1, 0, 2, 0, 9}), "[[9]]")
+}
+
+func TestDecodeMapField(t *testing.T) {
+	mapType := symbol.NewMapTypeSymbol(nil, intType, stringType)
+	assert.Equal(t, DecodeField(mapType, []byte{1, 0, 1, 0, 2, 0, 1, 0, 1, 'x'}), `{1: "x"}`)
+}
+
+func TestDecodeStructField(t *testing.T) {
+	structType := symbol.NewStructTypeSymbol(nil, "Point")
+	for _, id := range []string{"x", "y"} {
+		field := symbol.NewFieldSymbol(structType, id)
+		field.Type = intType
+		structType.Fields = append(structType.Fields, field)
+	}
+	assert.Equal(t, DecodeField(structType, []byte{2, 0, 2, 0, 2, 0, 1, 0, 1, 0}), "Point{x: 1, y: 0}")
+}
+
+func TestDecodeEnumField(t *testing.T) {
+	enumType := symbol.NewEnumTypeSymbol(nil, "State")
+	for _, id := range []string{"Open", "Closed"} {
+		enumType.Members = append(enumType.Members, symbol.NewConstantSymbol(enumType, id, enumType))
+	}
+	assert.Equal(t, DecodeField(enumType, []byte{0, 1}), "Closed")
+	assert.Equal(t, DecodeField(enumType, []byte{0, 2}), "0x0002")
+}
+
+func TestDecodeInvalidCollection(t *testing.T) {
+	arrayType := symbol.NewArrayTypeSymbol(nil, intType)
+	assert.Equal(t, DecodeField(arrayType, []byte{1, 0, 0}), "0x010000")
+	assert.Equal(t, DecodeField(arrayType, []byte{2, 0, 1, 0, 5}), "0x0200010005")
+}
@@ -0,0 +1,145 @@
+package runner
+
+import (
+	"fmt"
+	"github.com/bazo-blockchain/bazo-vm/vm"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"strings"
+)
+
+// Runner executes the byte code of a compiled contract and keeps the contract variables between the executions.
+// Events contains the events emitted by the last execution.
+// Value is the amount of Bazo coins sent with the executed transactions.
+type Runner struct {
+	metadata  *data.Metadata
+	code      []byte
+	Variables [][]byte
+	Events    []*Event
+	Value     uint64
+	Trace     bool
+}
+
+// New creates a runner for the compiled contract with uninitialized contract variables
+func New(metadata *data.Metadata) *Runner {
+	code, variables := metadata.CreateContract()
+	return &Runner{
+		metadata:  metadata,
+		code:      code,
+		Variables: variables,
+	}
+}
+
+// Deploy executes the constructor with the given arguments
+func (r *Runner) Deploy(args []string) error {
+	parameters := r.metadata.Contract.ConstructorParameters
+	txData, err := encodeArgs(r.metadata.Contract, "constructor", parameters, args)
+	if err != nil {
+		return err
+	}
+
+	txData = append(txData, 1, 0) // Contract init flag
+	_, err = r.execute(txData)
+	return err
+}
+
+// Call executes the function with the given signature and arguments and returns the decoded return values.
+// The signature can be given with or without return types, e.g. (int)add(int,int), add(int,int) or add.
+func (r *Runner) Call(signature string, args []string) ([]*Value, error) {
+	function, err := r.FindFunction(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	txData, err := encodeArgs(r.metadata.Contract, function.Identifier, function.Parameters, args)
+	if err != nil {
+		return nil, err
+	}
+	txData = append(txData, 4)
+	txData = append(txData, function.Hash[:]...)
+
+	results, err := r.execute(txData)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []*Value
+	for i, result := range results {
+		valueType := "unknown"
+		if i < len(function.ReturnTypes) {
+			valueType = function.ReturnTypes[i]
+		}
+		values = append(values, decodeContractValue(r.metadata.Contract, valueType, result))
+	}
+	return values, nil
+}
+
+// FindFunction returns the public contract function matching the signature
+func (r *Runner) FindFunction(signature string) (*data.FunctionData, error) {
+	for _, function := range r.metadata.Contract.Functions {
+		withoutReturnTypes := function.Signature[strings.Index(function.Signature, ")")+1:]
+		if signature == function.Signature || signature == withoutReturnTypes || signature == function.Identifier {
+			if !function.Public {
+				return nil, fmt.Errorf("function %s is not public", function.Signature)
+			}
+			return function, nil
+		}
+	}
+	return nil, fmt.Errorf("function %s does not exist in contract %s", signature, r.metadata.Contract.Identifier)
+}
+
+// execute runs the byte code with the transaction data and persists the changed contract variables on success.
+// Returns the evaluation stack.
+func (r *Runner) execute(txData []byte) ([][]byte, error) {
+	context := vm.NewMockContext(r.code)
+	context.ContractVariables = r.Variables
+	context.Data = txData
+	context.Amount = r.Value
+	context.Fee += (uint64(len(r.Variables)))*1000*10 + 10000
+
+	bazoVM := vm.NewVM(context)
+	isSuccess := bazoVM.Exec(r.Trace)
+	if !isSuccess {
+		result, _ := bazoVM.PeekResult()
+		return nil, fmt.Errorf("runtime error: %s", result)
+	}
+
+	context.PersistChanges()
+	r.Variables = context.ContractVariables
+
+	r.Events = nil
+	if contract := r.metadata.Contract; len(contract.Events) > 0 {
+		events, err := decodeEvents(contract, r.Variables[contract.EventLogIndex()])
+		if err != nil {
+			return nil, err
+		}
+		r.Events = events
+	}
+	return bazoVM.PeekEvalStack(), nil
+}
+
+// encodeArgs returns the transaction data of the arguments. Enum arguments are given by their member names.
+func encodeArgs(contract *data.ContractData, function string, parameters []*data.VariableData, args []string) ([]byte, error) {
+	if len(args) != len(parameters) {
+		return nil, fmt.Errorf("%s requires %d argument(s), given %d", function, len(parameters), len(args))
+	}
+
+	var txData []byte
+	for i, parameter := range parameters {
+		var bytes []byte
+		var err error
+		if enum := contract.GetEnum(parameter.Type); enum != nil {
+			bytes, err = encodeEnumValue(enum, args[i])
+		} else {
+			bytes, err = EncodeValue(parameter.Type, args[i])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("argument %s of %s: %s", parameter.Identifier, function, err)
+		}
+		if len(bytes) > 255 {
+			return nil, fmt.Errorf("argument %s of %s is longer than 255 bytes", parameter.Identifier, function)
+		}
+		txData = append(txData, byte(len(bytes)))
+		txData = append(txData, bytes...)
+	}
+	return txData, nil
+}
@@ -0,0 +1,499 @@
+package runner
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/bazo-blockchain/lazo/checker"
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/generator"
+	"github.com/bazo-blockchain/lazo/lexer"
+	"github.com/bazo-blockchain/lazo/parser"
+	"gotest.tools/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testContract = `contract Counter {
+	int total
+
+	constructor(int start) {
+		total = start
+	}
+
+	function (int, bool) add(int a, int b) {
+		total += a - b
+		return total, a > b
+	}
+
+	function String greet(String name, char c) {
+		return name
+	}
+
+	function void fail() {
+		int[] a = new int[1]
+		total = a[2]
+	}
+
+	function void reset() private {
+		total = 0
+	}
+}`
+
+func check(t *testing.T, code string) *symbol.SymbolTable {
+	p := parser.New(lexer.New(bufio.NewReader(strings.NewReader(code))))
+	program, errors := p.ParseProgram()
+	assert.Equal(t, len(errors), 0, errors)
+
+	symbolTable, errors := checker.New(program).Run()
+	assert.Equal(t, len(errors), 0, errors)
+	return symbolTable
+}
+
+func newTestRunner(t *testing.T, code string) *Runner {
+	metadata, errors := generator.New(check(t, code)).Run()
+	assert.Equal(t, len(errors), 0, errors)
+	return New(metadata)
+}
+
+func assertValues(t *testing.T, values []*Value, expected ...string) {
+	assert.Equal(t, len(values), len(expected))
+	for i, value := range values {
+		assert.Equal(t, value.String(), expected[i])
+	}
+}
+
+// Execution
+// ---------
+
+func TestDeploy(t *testing.T) {
+	runner := newTestRunner(t, testContract)
+	assert.NilError(t, runner.Deploy([]string{"10"}))
+	assert.DeepEqual(t, runner.Variables[0], []byte{0, 10})
+}
+
+func TestCall(t *testing.T) {
+	runner := newTestRunner(t, testContract)
+	assert.NilError(t, runner.Deploy([]string{"10"}))
+
+	values, err := runner.Call("add(int,int)", []string{"7", "2"})
+	assert.NilError(t, err)
+	assertValues(t, values, "int 15", "bool true")
+
+	// The contract variables are kept between the calls
+	values, err = runner.Call("(int,bool)add(int,int)", []string{"1", "5"})
+	assert.NilError(t, err)
+	assertValues(t, values, "int 11", "bool false")
+}
+
+func TestCallWithStringArgument(t *testing.T) {
+	runner := newTestRunner(t, testContract)
+
+	values, err := runner.Call("greet", []string{"lazo", "c"})
+	assert.NilError(t, err)
+	assertValues(t, values, `String "lazo"`)
+}
+
+func TestCallUnknownFunction(t *testing.T) {
+	runner := newTestRunner(t, testContract)
+	_, err := runner.Call("add(int)", nil)
+	assert.Error(t, err, "function add(int) does not exist in contract Counter")
+}
+
+func TestCallPrivateFunction(t *testing.T) {
+	runner := newTestRunner(t, testContract)
+	_, err := runner.Call("reset", nil)
+	assert.Error(t, err, "function ()reset() is not public")
+}
+
+func TestCallWithWrongArguments(t *testing.T) {
+	runner := newTestRunner(t, testContract)
+	_, err := runner.Call("add", []string{"1"})
+	assert.Error(t, err, "add requires 2 argument(s), given 1")
+
+	_, err = runner.Call("add", []string{"1", "x"})
+	assert.Error(t, err, "argument b of add: invalid int x")
+}
+
+func TestRuntimeErrorKeepsVariables(t *testing.T) {
+	runner := newTestRunner(t, testContract)
+	assert.NilError(t, runner.Deploy([]string{"10"}))
+
+	_, err := runner.Call("fail", nil)
+	assert.ErrorContains(t, err, "runtime error: ")
+	assert.DeepEqual(t, runner.Variables[0], []byte{0, 10})
+}
+
+func TestRequireShowsMessage(t *testing.T) {
+	runner := newTestRunner(t, `contract Test {
+		function int withdraw(int amount) {
+			require(amount <= 100, "amount exceeds limit")
+			return amount
+		}
+	}`)
+	assert.NilError(t, runner.Deploy(nil))
+
+	values, err := runner.Call("withdraw", []string{"100"})
+	assert.NilError(t, err)
+	assertValues(t, values, "int 100")
+
+	_, err = runner.Call("withdraw", []string{"101"})
+	assert.Error(t, err, "runtime error: amount exceeds limit")
+}
+
+func TestAssertShowsPosition(t *testing.T) {
+	runner := newTestRunner(t, `contract Test {
+		function void test() {
+			assert(false)
+		}
+	}`)
+	assert.NilError(t, runner.Deploy(nil))
+
+	_, err := runner.Call("test", nil)
+	assert.Error(t, err, "runtime error: assertion failed at 3:4")
+}
+
+func TestRevertShowsMessage(t *testing.T) {
+	runner := newTestRunner(t, `contract Test {
+		function void test() {
+			revert("not allowed")
+		}
+	}`)
+	assert.NilError(t, runner.Deploy(nil))
+
+	_, err := runner.Call("test", nil)
+	assert.Error(t, err, "runtime error: not allowed")
+}
+
+func TestStringIndexOutOfBounds(t *testing.T) {
+	runner := newTestRunner(t, `contract Test {
+		function String part(String s, int start, int end) {
+			return s.substring(start, end)
+		}
+	}`)
+	assert.NilError(t, runner.Deploy(nil))
+
+	values, err := runner.Call("part", []string{"lazo", "1", "3"})
+	assert.NilError(t, err)
+	assertValues(t, values, `String "az"`)
+
+	for _, args := range [][]string{{"-1", "2"}, {"3", "2"}, {"2", "5"}} {
+		_, err = runner.Call("part", append([]string{"lazo"}, args...))
+		assert.Error(t, err, "runtime error: String index out of bounds")
+	}
+}
+
+func TestInvalidTypeCast(t *testing.T) {
+	runner := newTestRunner(t, `contract Test {
+		function int parse(String s) {
+			return (int) s
+		}
+
+		function char toChar(int i) {
+			return (char) i
+		}
+	}`)
+	assert.NilError(t, runner.Deploy(nil))
+
+	values, err := runner.Call("parse", []string{"-42"})
+	assert.NilError(t, err)
+	assertValues(t, values, "int -42")
+
+	for _, arg := range []string{"", "-", "4a", "+1", " 1"} {
+		_, err = runner.Call("parse", []string{arg})
+		assert.Error(t, err, "runtime error: String is not a valid integer")
+	}
+
+	for _, arg := range []string{"-1", "128"} {
+		_, err = runner.Call("toChar", []string{arg})
+		assert.Error(t, err, "runtime error: Integer is not a valid ASCII code")
+	}
+}
+
+const eventContract = `contract Bank {
+	Map<address, int> balances
+	event Deposit(address account, int amount)
+	event Withdrawal(address account, int amount, String note)
+
+	constructor() {
+		balances[msg.sender] = 0
+		deposit(10)
+	}
+
+	function void deposit(int amount) {
+		balances[msg.sender] += amount
+		emit Deposit(msg.sender, amount)
+	}
+
+	function int withdraw(int amount) {
+		balances[msg.sender] -= amount
+		emit Withdrawal(msg.sender, amount, "cash")
+		emit Withdrawal(msg.sender, 0, "fee")
+		return balances[msg.sender]
+	}
+}`
+
+func TestEvents(t *testing.T) {
+	runner := newTestRunner(t, eventContract)
+	assert.NilError(t, runner.Deploy(nil))
+	sender := "@" + strings.Repeat("00", 32)
+
+	assert.Equal(t, len(runner.Events), 1)
+	assert.Equal(t, runner.Events[0].String(), "event Deposit(account: "+sender+", amount: 10)")
+
+	values, err := runner.Call("withdraw", []string{"4"})
+	assert.NilError(t, err)
+	assertValues(t, values, "int 6")
+	assert.Equal(t, len(runner.Events), 2)
+	assert.Equal(t, runner.Events[0].String(), "event Withdrawal(account: "+sender+", amount: 4, note: \"cash\")")
+	assert.Equal(t, runner.Events[1].String(), "event Withdrawal(account: "+sender+", amount: 0, note: \"fee\")")
+
+	// The events of the previous transaction are discarded
+	_, err = runner.Call("deposit", []string{"1"})
+	assert.NilError(t, err)
+	assert.Equal(t, len(runner.Events), 1)
+	assert.Equal(t, runner.Events[0].String(), "event Deposit(account: "+sender+", amount: 1)")
+}
+
+func TestSaveAndLoadStateWithEvents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazo")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state.json")
+
+	runner := newTestRunner(t, eventContract)
+	assert.NilError(t, runner.Deploy(nil))
+	assert.NilError(t, runner.SaveState(stateFile))
+
+	state, err := readState(stateFile)
+	assert.NilError(t, err)
+	assert.Equal(t, len(state.Fields), 1)
+
+	runner = newTestRunner(t, eventContract)
+	assert.NilError(t, runner.LoadState(stateFile))
+	values, err := runner.Call("withdraw", []string{"3"})
+	assert.NilError(t, err)
+	assertValues(t, values, "int 7")
+	assert.Equal(t, len(runner.Events), 2)
+}
+
+// Payable functions
+// -----------------
+
+const paymentContract = `contract Payment {
+	int total
+
+	constructor() payable {
+	}
+
+	function void deposit() payable {
+		total += 1
+	}
+
+	function void pay(address to, int amount) {
+		total -= amount
+	}
+}`
+
+func TestPayableFunction(t *testing.T) {
+	runner := newTestRunner(t, paymentContract)
+	runner.Value = 100
+	assert.NilError(t, runner.Deploy(nil))
+	_, err := runner.Call("deposit", nil)
+	assert.NilError(t, err)
+}
+
+func TestNonPayableFunctionRejectsCallValue(t *testing.T) {
+	runner := newTestRunner(t, paymentContract)
+	assert.NilError(t, runner.Deploy(nil))
+
+	runner.Value = 1
+	_, err := runner.Call("pay", []string{"@" + strings.Repeat("00", 32), "5"})
+	assert.Error(t, err, "runtime error: Function does not accept Bazo coins")
+
+	runner = newTestRunner(t, testContract)
+	runner.Value = 1
+	err = runner.Deploy([]string{"1"})
+	assert.Error(t, err, "runtime error: Function does not accept Bazo coins")
+}
+
+// Modifiers
+// ---------
+
+const modifierContract = `contract Guarded {
+	int total
+
+	modifier minAmount(int amount, int min) {
+		require(amount >= min, "Amount too low")
+		_
+		total += amount
+	}
+
+	function void add(int amount) minAmount(amount, 10) {
+		total += 1
+	}
+}`
+
+func TestModifier(t *testing.T) {
+	runner := newTestRunner(t, modifierContract)
+	assert.NilError(t, runner.Deploy(nil))
+
+	_, err := runner.Call("add", []string{"20"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, runner.Variables[0], []byte{0, 21})
+}
+
+func TestModifierAbortsFunction(t *testing.T) {
+	runner := newTestRunner(t, modifierContract)
+	assert.NilError(t, runner.Deploy(nil))
+
+	_, err := runner.Call("add", []string{"5"})
+	assert.Error(t, err, "runtime error: Amount too low")
+	assert.DeepEqual(t, runner.Variables[0], []byte{0})
+}
+
+// Enums
+// -----
+
+const enumContract = `contract Auction {
+	enum State { Open, Closed, Cancelled }
+	State state
+	event Changed(State from, State to)
+
+	function State change(State next) {
+		require(next != State.Open, "Auction cannot be reopened")
+		emit Changed(state, next)
+		state = next
+		return state
+	}
+}`
+
+func TestEnumArgumentsAndResults(t *testing.T) {
+	runner := newTestRunner(t, enumContract)
+	assert.NilError(t, runner.Deploy(nil))
+
+	values, err := runner.Call("change", []string{"Closed"})
+	assert.NilError(t, err)
+	assertValues(t, values, "State Closed")
+	assert.Equal(t, runner.Events[0].String(), "event Changed(from: Open, to: Closed)")
+
+	values, err = runner.Call("change", []string{"State.Cancelled"})
+	assert.NilError(t, err)
+	assertValues(t, values, "State Cancelled")
+	assert.Equal(t, runner.Events[0].String(), "event Changed(from: Closed, to: Cancelled)")
+}
+
+func TestInvalidEnumArgument(t *testing.T) {
+	runner := newTestRunner(t, enumContract)
+	assert.NilError(t, runner.Deploy(nil))
+
+	_, err := runner.Call("change", []string{"Pending"})
+	assert.Error(t, err, "argument next of change: invalid State Pending")
+	_, err = runner.Call("change", []string{"Open"})
+	assert.Error(t, err, "runtime error: Auction cannot be reopened")
+}
+
+// State
+// -----
+
+func TestSaveAndLoadState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazo")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state.json")
+
+	runner := newTestRunner(t, testContract)
+	assert.NilError(t, runner.Deploy([]string{"-3"}))
+	assert.NilError(t, runner.SaveState(stateFile))
+
+	content, err := ioutil.ReadFile(stateFile)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), `{
+  "contract": "Counter",
+  "fields": [
+    {
+      "name": "total",
+      "type": "int",
+      "value": "0103"
+    }
+  ]
+}
+`)
+
+	runner = newTestRunner(t, testContract)
+	assert.NilError(t, runner.LoadState(stateFile))
+	values, err := runner.Call("add", []string{"0", "0"})
+	assert.NilError(t, err)
+	assertValues(t, values, "int -3", "bool false")
+}
+
+func TestLoadStateOfOtherContract(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lazo")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+	stateFile := filepath.Join(dir, "state.json")
+	runner := newTestRunner(t, testContract)
+
+	assert.NilError(t, ioutil.WriteFile(stateFile, []byte(`{"contract": "Other", "fields": []}`), 0644))
+	err = runner.LoadState(stateFile)
+	assert.Error(t, err, "state file "+stateFile+" belongs to contract Other, not Counter")
+
+	assert.NilError(t, ioutil.WriteFile(stateFile, []byte(`{"contract": "Counter", "fields": []}`), 0644))
+	err = runner.LoadState(stateFile)
+	assert.Error(t, err, "state file "+stateFile+" contains 0 field(s), but contract Counter has 1 field(s)")
+
+	assert.NilError(t, ioutil.WriteFile(stateFile,
+		[]byte(`{"contract": "Counter", "fields": [{"name": "total", "type": "bool", "value": "01"}]}`), 0644))
+	err = runner.LoadState(stateFile)
+	assert.Error(t, err, "field 0 in state file "+stateFile+" is bool total, but contract Counter declares int total")
+}
+
+func TestWriteFields(t *testing.T) {
+	code := `contract People {
+		struct Person {
+			String name
+			int age
+		}
+		Person owner
+		Map<int,bool> flags
+		int[] numbers
+		char initial
+		String note
+
+		constructor() {
+			owner = new Person("ann", 33)
+			flags[3] = true
+			flags[-7] = false
+			numbers = new int[]{1, 2}
+			initial = 'a'
+		}
+	}`
+
+	symbolTable := check(t, code)
+	metadata, errors := generator.New(symbolTable).Run()
+	assert.Equal(t, len(errors), 0, errors)
+	runner := New(metadata)
+
+	var buffer bytes.Buffer
+	runner.WriteFields(&buffer, symbolTable.GlobalScope.Contract)
+	assert.Equal(t, buffer.String(), `contract People
+  Person owner = <uninitialized>
+  Map<int,bool> flags = <uninitialized>
+  int[] numbers = <uninitialized>
+  char initial = <uninitialized>
+  String note = ""
+`)
+
+	assert.NilError(t, runner.Deploy(nil))
+	buffer.Reset()
+	runner.WriteFields(&buffer, symbolTable.GlobalScope.Contract)
+	assert.Equal(t, buffer.String(), `contract People
+  Person owner = Person{name: "ann", age: 33}
+  Map<int,bool> flags = {3: true, -7: false}
+  int[] numbers = [1, 2]
+  char initial = 'a'
+  String note = ""
+`)
+}
@@ -0,0 +1,107 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"io"
+	"io/ioutil"
+)
+
+// State contains the contract variables of a deployed contract. It is stored in a local state file, so that
+// subsequent runs can call the contract functions against the persisted contract variables.
+type State struct {
+	Contract string        `json:"contract"`
+	Fields   []*StateField `json:"fields"`
+}
+
+// StateField contains the name, type and the hex encoded value of a contract variable
+type StateField struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// readState reads and parses the state file
+func readState(stateFile string) (*State, error) {
+	content, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &State{}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("invalid state file %s: %s", stateFile, err)
+	}
+	return state, nil
+}
+
+// LoadState reads the state file and sets the contract variables of the runner.
+// The state file must contain the same fields as the contract.
+func (r *Runner) LoadState(stateFile string) error {
+	state, err := readState(stateFile)
+	if err != nil {
+		return err
+	}
+
+	contract := r.metadata.Contract
+	if state.Contract != contract.Identifier {
+		return fmt.Errorf("state file %s belongs to contract %s, not %s", stateFile, state.Contract, contract.Identifier)
+	}
+	if len(state.Fields) != len(contract.Fields) {
+		return fmt.Errorf("state file %s contains %d field(s), but contract %s has %d field(s)",
+			stateFile, len(state.Fields), contract.Identifier, len(contract.Fields))
+	}
+
+	// The event log is not stored, since it only contains the events of the last transaction
+	variables := make([][]byte, contract.TotalFields)
+	for i, field := range state.Fields {
+		declared := contract.Fields[i]
+		if field.Name != declared.Identifier || field.Type != declared.Type {
+			return fmt.Errorf("field %d in state file %s is %s %s, but contract %s declares %s %s",
+				i, stateFile, field.Type, field.Name, contract.Identifier, declared.Type, declared.Identifier)
+		}
+		if variables[i], err = hex.DecodeString(field.Value); err != nil {
+			return fmt.Errorf("invalid value of field %s in state file %s: %s", field.Name, stateFile, err)
+		}
+	}
+	r.Variables = variables
+	return nil
+}
+
+// SaveState writes the contract variables of the runner into the state file
+func (r *Runner) SaveState(stateFile string) error {
+	contract := r.metadata.Contract
+	state := &State{
+		Contract: contract.Identifier,
+		Fields:   []*StateField{},
+	}
+	for i, field := range contract.Fields {
+		state.Fields = append(state.Fields, &StateField{
+			Name:  field.Identifier,
+			Type:  field.Type,
+			Value: hex.EncodeToString(r.Variables[i]),
+		})
+	}
+
+	// Type names like Map<int,int> are not HTML escaped
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(state); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(stateFile, buffer.Bytes(), 0644)
+}
+
+// WriteFields writes the contract variables of the runner decoded by their declared types
+func (r *Runner) WriteFields(w io.Writer, contract *symbol.ContractSymbol) {
+	fmt.Fprintf(w, "contract %s\n", contract.Identifier())
+	for i, field := range contract.Fields {
+		value := DecodeField(field.Type, r.Variables[i])
+		fmt.Fprintf(w, "  %s %s = %s\n", field.Type.Identifier(), field.Identifier(), value)
+	}
+}
@@ -0,0 +1,144 @@
+package runner
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/bazo-blockchain/lazo/checker/symbol"
+	"github.com/bazo-blockchain/lazo/generator/data"
+	"github.com/bazo-blockchain/lazo/generator/util"
+	"github.com/bazo-blockchain/lazo/lexer/token"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Value is a decoded value of the evaluation stack or a contract variable
+type Value struct {
+	Type  string
+	Value string
+}
+
+func (v *Value) String() string {
+	return fmt.Sprintf("%s %s", v.Type, v.Value)
+}
+
+// EncodeValue returns the bytes of the given value as they are stored on the evaluation stack
+func EncodeValue(valueType string, value string) ([]byte, error) {
+	switch valueType {
+	case "int":
+		number, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid int %s", value)
+		}
+		return append([]byte{util.GetSignByte(number)}, number.Bytes()...), nil
+	case "bool":
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool %s", value)
+		}
+		if boolean {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case "char":
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		if len(value) != 1 {
+			return nil, fmt.Errorf("invalid char %s", value)
+		}
+		return []byte(value), nil
+	case "String":
+		return []byte(value), nil
+	case "address":
+		address, err := hex.DecodeString(strings.TrimPrefix(value, "@"))
+		if err != nil || len(address) != token.AddressLength {
+			return nil, fmt.Errorf("invalid address %s", value)
+		}
+		return address, nil
+	case "hash":
+		return parseHex(value, symbol.HashLength, "hash")
+	case "publicKey":
+		return parseHex(value, symbol.PublicKeyLength, "publicKey")
+	default:
+		return nil, fmt.Errorf("type %s is not supported as argument", valueType)
+	}
+}
+
+func parseHex(value string, length int, valueType string) ([]byte, error) {
+	bytes, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+	if err != nil || len(bytes) != length {
+		return nil, fmt.Errorf("invalid %s %s", valueType, value)
+	}
+	return bytes, nil
+}
+
+// DecodeValue returns the readable value of the bytes according to the given type.
+// Addresses are written like address literals. Values of other types are hex encoded.
+func DecodeValue(valueType string, bytes []byte) *Value {
+	value := &Value{Type: valueType}
+
+	switch valueType {
+	case "int":
+		if len(bytes) > 0 {
+			number := new(big.Int).SetBytes(bytes[1:])
+			if bytes[0] == 1 {
+				number.Neg(number)
+			}
+			value.Value = number.String()
+			return value
+		}
+	case "bool":
+		if len(bytes) == 1 && bytes[0] <= 1 {
+			value.Value = strconv.FormatBool(bytes[0] == 1)
+			return value
+		}
+	case "char":
+		if len(bytes) == 1 {
+			value.Value = strconv.QuoteRune(rune(bytes[0]))
+			return value
+		}
+	case "String":
+		if utf8.Valid(bytes) {
+			value.Value = strconv.Quote(string(bytes))
+			return value
+		}
+	case "address":
+		if len(bytes) == token.AddressLength {
+			value.Value = "@" + hex.EncodeToString(bytes)
+			return value
+		}
+	}
+
+	value.Value = "0x" + hex.EncodeToString(bytes)
+	return value
+}
+
+// encodeEnumValue returns the bytes of the enum member given by its name (e.g. Open or State.Open).
+// Enum values are stored as the index of the member.
+func encodeEnumValue(enum *data.EnumData, value string) ([]byte, error) {
+	member := strings.TrimPrefix(value, enum.Identifier+".")
+	for i, name := range enum.Members {
+		if name == member {
+			return EncodeValue("int", strconv.Itoa(i))
+		}
+	}
+	return nil, fmt.Errorf("invalid %s %s", enum.Identifier, value)
+}
+
+// decodeContractValue returns the readable value like DecodeValue, but writes the values of enum types
+// declared in the contract as member names
+func decodeContractValue(contract *data.ContractData, valueType string, bytes []byte) *Value {
+	enum := contract.GetEnum(valueType)
+	if enum == nil {
+		return DecodeValue(valueType, bytes)
+	}
+
+	value := DecodeValue("int", bytes)
+	value.Type = valueType
+	if index, err := strconv.Atoi(value.Value); err == nil && index >= 0 && index < len(enum.Members) {
+		value.Value = enum.Members[index]
+	}
+	return value
+}
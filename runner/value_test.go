@@ -0,0 +1,90 @@
+package runner
+
+import (
+	"bytes"
+	"gotest.tools/assert"
+	"strings"
+	"testing"
+)
+
+func assertEncodeValue(t *testing.T, valueType string, value string, expected ...byte) {
+	bytes, err := EncodeValue(valueType, value)
+	assert.NilError(t, err)
+	assert.Equal(t, string(bytes), string(expected))
+}
+
+func assertDecodeValue(t *testing.T, valueType string, bytes []byte, expected string) {
+	assert.Equal(t, DecodeValue(valueType, bytes).String(), expected)
+}
+
+func TestEncodeInt(t *testing.T) {
+	assertEncodeValue(t, "int", "0", 0)
+	assertEncodeValue(t, "int", "5", 0, 5)
+	assertEncodeValue(t, "int", "-256", 1, 1, 0)
+}
+
+func TestEncodeBool(t *testing.T) {
+	assertEncodeValue(t, "bool", "true", 1)
+	assertEncodeValue(t, "bool", "false", 0)
+}
+
+func TestEncodeChar(t *testing.T) {
+	assertEncodeValue(t, "char", "c", 'c')
+	assertEncodeValue(t, "char", "','", ',')
+}
+
+func TestEncodeString(t *testing.T) {
+	assertEncodeValue(t, "String", "hello", []byte("hello")...)
+	assertEncodeValue(t, "String", "")
+}
+
+func TestEncodeAddress(t *testing.T) {
+	address := strings.Repeat("ab", 32)
+	expected := bytes.Repeat([]byte{0xab}, 32)
+	assertEncodeValue(t, "address", "@"+address, expected...)
+	assertEncodeValue(t, "address", address, expected...)
+}
+
+func TestEncodeHashAndPublicKey(t *testing.T) {
+	assertEncodeValue(t, "hash", "0x"+strings.Repeat("01", 32), bytes.Repeat([]byte{1}, 32)...)
+	assertEncodeValue(t, "publicKey", strings.Repeat("02", 64), bytes.Repeat([]byte{2}, 64)...)
+}
+
+func TestEncodeInvalidValues(t *testing.T) {
+	_, err := EncodeValue("int", "1.5")
+	assert.Error(t, err, "invalid int 1.5")
+
+	_, err = EncodeValue("bool", "yes")
+	assert.Error(t, err, "invalid bool yes")
+
+	_, err = EncodeValue("char", "ab")
+	assert.Error(t, err, "invalid char ab")
+
+	_, err = EncodeValue("address", "@ab")
+	assert.Error(t, err, "invalid address @ab")
+
+	_, err = EncodeValue("hash", "0x01")
+	assert.Error(t, err, "invalid hash 0x01")
+
+	_, err = EncodeValue("publicKey", "xyz")
+	assert.Error(t, err, "invalid publicKey xyz")
+
+	_, err = EncodeValue("int[]", "1")
+	assert.Error(t, err, "type int[] is not supported as argument")
+}
+
+func TestDecodeValues(t *testing.T) {
+	assertDecodeValue(t, "int", []byte{0}, "int 0")
+	assertDecodeValue(t, "int", []byte{1, 1, 0}, "int -256")
+	assertDecodeValue(t, "bool", []byte{1}, "bool true")
+	assertDecodeValue(t, "char", []byte{'x'}, "char 'x'")
+	assertDecodeValue(t, "String", []byte("a\"b"), `String "a\"b"`)
+	assertDecodeValue(t, "address", bytes.Repeat([]byte{1}, 32), "address @"+strings.Repeat("01", 32))
+}
+
+func TestDecodeInvalidValues(t *testing.T) {
+	assertDecodeValue(t, "int", []byte{}, "int 0x")
+	assertDecodeValue(t, "bool", []byte{2}, "bool 0x02")
+	assertDecodeValue(t, "address", []byte{1}, "address 0x01")
+	assertDecodeValue(t, "Map<int,int>", []byte{1, 0, 0}, "Map<int,int> 0x010000")
+}